@@ -4,6 +4,10 @@ import (
 	"context"
 
 	"github.com/jmozgit/datagen/cmd/datagen/gen"
+	"github.com/jmozgit/datagen/cmd/datagen/initconfig"
+	"github.com/jmozgit/datagen/cmd/datagen/inspect"
+	"github.com/jmozgit/datagen/cmd/datagen/preview"
+	"github.com/jmozgit/datagen/cmd/datagen/profile"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +20,10 @@ func New(ctx context.Context) *cobra.Command {
 	c.SetContext(ctx)
 
 	c.AddCommand(gen.New())
+	c.AddCommand(profile.New())
+	c.AddCommand(inspect.New())
+	c.AddCommand(preview.New())
+	c.AddCommand(initconfig.New())
 
 	return c
 }
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
 	"os"
 	"runtime"
 	"time"
 
 	"github.com/jmozgit/datagen/internal/acceptor/registry"
+	"github.com/jmozgit/datagen/internal/checkpoint"
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/execution"
 	"github.com/jmozgit/datagen/internal/pkg/closer"
@@ -20,9 +23,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	ErrResumeWithoutCheckpoint = errors.New("--resume requires --checkpoint")
+	// files of an interrupted run may hold rows the checkpoint doesn't know of, they can't be continued
+	ErrCheckpointWithOutput = errors.New("--checkpoint and --resume only work for rows saved to the connection")
+)
+
 type cmd struct {
 	flags              flags
 	cfg                config.Config
+	checkpoints        *checkpoint.Store
 	closer             *closer.Registry
 	refSvc             *refresolver.Service
 	acceptors          *registry.Acceptors
@@ -32,8 +42,12 @@ type cmd struct {
 }
 
 type flags struct {
-	path    string
-	workCnt int
+	path       string
+	workCnt    int
+	seed       uint64
+	seedSet    bool
+	checkpoint string
+	resume     bool
 }
 
 func New() *cobra.Command {
@@ -46,6 +60,7 @@ func New() *cobra.Command {
 		Use:   "gen",
 		Short: "generate data",
 		PreRunE: func(cobraCmd *cobra.Command, _ []string) error {
+			flags.seedSet = cobraCmd.Flags().Changed("seed")
 			if err := cmd.init(cobraCmd.Context(), flags); err != nil {
 				return fmt.Errorf("%w: pre run", err)
 			}
@@ -85,13 +100,25 @@ func (c *cmd) init(ctx context.Context, flags flags) error {
 	if err != nil {
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
+	if flags.seedSet {
+		c.cfg.Options.Seed = &flags.seed
+	}
+	if err := c.initCheckpoints(flags); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+	slog.Info(
+		"generation seed and reference time, pass them with --seed and options.referenceTime to reproduce the run",
+		slog.Uint64("seed", *c.cfg.Options.Seed),
+		slog.Time("referenceTime", c.cfg.Options.FixReferenceTime()),
+	)
+
 	c.closer = closer.NewRegistry()
 	c.refSvc = refresolver.NewService()
 	c.acceptors, err = registry.PrepareAcceptors(ctx, c.cfg, c.refSvc, c.closer)
 	if err != nil {
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
-	c.saver, err = factory.GetSaver(ctx, c.cfg)
+	c.saver, err = factory.GetSaver(ctx, c.cfg, c.closer)
 	if err != nil {
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
@@ -100,6 +127,9 @@ func (c *cmd) init(ctx context.Context, flags flags) error {
 		c.cfg.Options.BatchSize,
 		c.cfg.Options.NoProgressAttempts,
 	)
+	if c.checkpoints != nil {
+		c.taskExecutor.WithCheckpointer(c.checkpoints)
+	}
 	terminal := terminal.New(os.Stdout)
 	c.progressController = progress.NewController(terminal, flags.workCnt)
 	c.closer.Add(closer.Fn(c.progressController.Close))
@@ -107,7 +137,65 @@ func (c *cmd) init(ctx context.Context, flags flags) error {
 	return nil
 }
 
+// initCheckpoints picks the seed and the reference time of the run:
+// a resumed run must keep the ones of the interrupted one.
+func (c *cmd) initCheckpoints(flags flags) error {
+	const fnName = "init checkpoints"
+
+	if (flags.resume || flags.checkpoint != "") && c.cfg.Output != nil {
+		return fmt.Errorf("%w: output %s %s", ErrCheckpointWithOutput, c.cfg.Output.Type, fnName)
+	}
+
+	if flags.resume {
+		if flags.checkpoint == "" {
+			return fmt.Errorf("%w: %s", ErrResumeWithoutCheckpoint, fnName)
+		}
+
+		store, err := checkpoint.Load(flags.checkpoint)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if c.cfg.Options.Seed != nil && *c.cfg.Options.Seed != store.Seed() {
+			return fmt.Errorf(
+				"%w: %d != %d %s",
+				checkpoint.ErrSeedMismatch, *c.cfg.Options.Seed, store.Seed(), fnName,
+			)
+		}
+
+		seed := store.Seed()
+		c.cfg.Options.Seed = &seed
+		c.checkpoints = store
+
+		if referenceTime, ok := store.ReferenceTime(); ok {
+			if c.cfg.Options.ReferenceTime != nil && !c.cfg.Options.ReferenceTime.Equal(referenceTime) {
+				return fmt.Errorf(
+					"%w: %s != %s %s",
+					checkpoint.ErrReferenceTimeMismatch, c.cfg.Options.ReferenceTime, referenceTime, fnName,
+				)
+			}
+			c.cfg.Options.ReferenceTime = &referenceTime
+		}
+
+		return nil
+	}
+
+	if c.cfg.Options.Seed == nil {
+		seed := rand.Uint64() //nolint:gosec // only picks the seed
+		c.cfg.Options.Seed = &seed
+	}
+
+	if flags.checkpoint != "" {
+		c.checkpoints = checkpoint.New(flags.checkpoint, *c.cfg.Options.Seed, c.cfg.Options.FixReferenceTime())
+	}
+
+	return nil
+}
+
 func parseFlags(rootCmd *cobra.Command, flags *flags) {
 	rootCmd.PersistentFlags().StringVarP(&flags.path, "config", "f", "config.yaml", "path to config file")
 	rootCmd.PersistentFlags().IntVarP(&flags.workCnt, "workers", "w", runtime.NumCPU(), "count of parallel workers")
+	rootCmd.PersistentFlags().Uint64Var(&flags.seed, "seed", 0, "seed for reproducible generation, overrides options.seed")
+	rootCmd.PersistentFlags().StringVar(&flags.checkpoint, "checkpoint", "", "path to the file keeping progress of the run")
+	rootCmd.PersistentFlags().BoolVar(&flags.resume, "resume", false, "continue the run saved in the checkpoint file")
 }
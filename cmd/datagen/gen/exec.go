@@ -3,9 +3,12 @@ package gen
 import (
 	"fmt"
 
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/factory"
 	"github.com/jmozgit/datagen/internal/taskbuilder"
 	"github.com/jmozgit/datagen/internal/workmanager"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +25,20 @@ func (c *cmd) exec(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
 
+	if orderer, ok := c.saver.(factory.TableOrderer); ok {
+		orderer.SetTableOrder(lo.Map(tasks, func(task model.Task, _ int) model.TableName {
+			return task.DatasetSchema.TableName
+		}))
+	}
+
+	if c.flags.resume {
+		for _, task := range tasks {
+			if err := c.checkpoints.Restore(task); err != nil {
+				return fmt.Errorf("%w: %s", err, fnName)
+			}
+		}
+	}
+
 	wm := workmanager.New(
 		min(len(tasks), c.flags.workCnt),
 		c.taskExecutor.Execute,
@@ -0,0 +1,98 @@
+package initconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/scaffold"
+
+	"gopkg.in/yaml.v3"
+)
+
+// render writes the config with generators of every table target commented out after its settings,
+// columns of a table are in the same order as cfg.Targets.
+func render(cfg config.Config, columns [][]scaffold.Column) ([]byte, error) {
+	const fnName = "render"
+
+	var doc yaml.Node
+	if err := doc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	targets := mappingValue(&doc, "targets")
+	for i := 0; targets != nil && i < len(targets.Content) && i < len(columns); i++ {
+		table := mappingValue(targets.Content[i], "table")
+		if table == nil || len(table.Content) == 0 {
+			continue
+		}
+
+		if limit := mappingValue(table, "limitRows"); limit != nil {
+			limit.LineComment = "placeholder, set limitBytes to limit the size instead"
+		}
+
+		generators, err := commentedGenerators(columns[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		// a foot comment of the last key goes after its value
+		table.Content[len(table.Content)-2].FootComment = generators
+	}
+
+	data, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return data, nil
+}
+
+// commentedGenerators renders the generators section with notes next to column names.
+func commentedGenerators(columns []scaffold.Column) (string, error) {
+	const fnName = "commented generators"
+
+	gens := make([]config.Generator, len(columns))
+	for i, col := range columns {
+		gens[i] = col.Generator
+	}
+
+	var section yaml.Node
+	if err := section.Encode(map[string][]config.Generator{"generators": gens}); err != nil {
+		return "", fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if list := mappingValue(&section, "generators"); list != nil {
+		for i, item := range list.Content {
+			if name := mappingValue(item, "column"); name != nil && columns[i].Note != "" {
+				name.LineComment = columns[i].Note
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(&section)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// mappingValue finds the value of the key in a mapping node or a document holding one.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package inspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/acceptor/registry"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/closer"
+	"github.com/jmozgit/datagen/internal/progress"
+	"github.com/jmozgit/datagen/internal/progress/terminal"
+	"github.com/jmozgit/datagen/internal/refresolver"
+	"github.com/jmozgit/datagen/internal/taskbuilder"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+type flags struct {
+	path string
+}
+
+func New() *cobra.Command {
+	var flags flags
+
+	//nolint:exhaustruct // it's okay for now
+	c := &cobra.Command{
+		Use:   "inspect",
+		Short: "explain how generators are picked without generating data",
+		Long: "inspect builds the tasks of the config like gen does and prints for every column its detected type, " +
+			"providers which accepted it, the chosen one and its null and reuse fractions, then partitions " +
+			"createPartitions would add, references between tables and the order they are filled in. " +
+			"Nothing is written to the database.",
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return run(cobraCmd.Context(), flags, cobraCmd.OutOrStdout())
+		},
+	}
+
+	c.Flags().StringVarP(&flags.path, "config", "f", "config.yaml", "path to config file")
+
+	return c
+}
+
+func run(ctx context.Context, flags flags, stdout io.Writer) (err error) {
+	const fnName = "inspect"
+
+	cfg, err := config.Load(flags.path)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	closers := closer.NewRegistry()
+	defer func() {
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer closeCancel()
+
+		err = errors.Join(err, closers.CloseAll(closeCtx))
+	}()
+
+	refSvc := refresolver.NewService()
+	acceptors, err := registry.PrepareAcceptors(ctx, cfg, refSvc, closers, registry.WithDryRun())
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+	acceptors.Record()
+
+	progressController := progress.NewController(terminal.New(io.Discard), 1)
+	closers.Add(closer.Fn(progressController.Close))
+
+	planned := make([]string, 0)
+	tasks, buildErr := taskbuilder.Build(
+		ctx, cfg, acceptors, refSvc, progressController, closers,
+		taskbuilder.WithDryRun(func(table model.TableName, partitions []string) {
+			planned = append(planned, fmt.Sprintf("    %s: %s", table.String(), strings.Join(partitions, ", ")))
+		}),
+	)
+
+	// choices made before a failure tell which column it is about
+	printChoices(stdout, acceptors.Choices())
+	if buildErr != nil {
+		return fmt.Errorf("%w: %s", buildErr, fnName)
+	}
+
+	if len(planned) > 0 {
+		fmt.Fprintln(stdout, "partitions to create")
+		fmt.Fprintln(stdout, strings.Join(planned, "\n"))
+	}
+
+	printDeps(stdout, refSvc.DepsOn())
+	printOrder(stdout, tasks)
+
+	return nil
+}
+
+func printChoices(w io.Writer, choices []registry.Choice) {
+	var table model.TableName
+	for i, choice := range choices {
+		if choice.Depth == 0 && (i == 0 || choice.Table != table) {
+			table = choice.Table
+			fmt.Fprintf(w, "table %s\n", table.String())
+		}
+
+		indent := strings.Repeat("    ", choice.Depth+1)
+		col := choice.Column
+		fmt.Fprintf(w, "%s%s: %s (%s)", indent, col.SourceName.AsArgument(), col.Type, col.SourceType)
+		if col.IsNullable {
+			fmt.Fprint(w, " nullable")
+		}
+		fmt.Fprintln(w)
+
+		candidates := lo.Map(choice.Candidates, func(c registry.Candidate, _ int) string {
+			return fmt.Sprintf("%s (%s)", c.Provider, c.Reason)
+		})
+		if len(candidates) == 0 {
+			candidates = []string{"none"}
+		}
+		fmt.Fprintf(w, "%s  accepted: %s\n", indent, strings.Join(candidates, ", "))
+
+		if choice.Err != nil {
+			fmt.Fprintf(w, "%s  error: %s\n", indent, choice.Err)
+		} else {
+			fmt.Fprintf(w, "%s  winner: %s\n", indent, choice.Winner)
+		}
+
+		if choice.NullFraction != 0 || choice.ReuseFraction != 0 {
+			fmt.Fprintf(w, "%s  null: %d%%, reuse: %d%%\n", indent, choice.NullFraction, choice.ReuseFraction)
+		}
+	}
+}
+
+func printDeps(w io.Writer, deps map[model.TableName][]model.TableName) {
+	fmt.Fprintln(w, "references")
+
+	lines := make([]string, 0, len(deps))
+	for from, to := range deps {
+		names := lo.Uniq(lo.Map(to, func(t model.TableName, _ int) string { return t.String() }))
+		lines = append(lines, fmt.Sprintf("    %s -> %s", from.String(), strings.Join(names, ", ")))
+	}
+	slices.Sort(lines)
+
+	if len(lines) == 0 {
+		lines = []string{"    none"}
+	}
+	fmt.Fprintln(w, strings.Join(lines, "\n"))
+}
+
+func printOrder(w io.Writer, tasks []model.Task) {
+	fmt.Fprintln(w, "order")
+
+	for i, task := range tasks {
+		fmt.Fprintf(w, "    %d. %s\n", i+1, task.TableName())
+	}
+}
@@ -0,0 +1,201 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/acceptor/registry"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/execution"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/closer"
+	"github.com/jmozgit/datagen/internal/progress"
+	"github.com/jmozgit/datagen/internal/progress/terminal"
+	"github.com/jmozgit/datagen/internal/refresolver"
+	"github.com/jmozgit/datagen/internal/taskbuilder"
+
+	"github.com/spf13/cobra"
+)
+
+var ErrUnknownFormat = errors.New("preview format is unknown")
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type flags struct {
+	path    string
+	rows    int
+	format  string
+	seed    uint64
+	seedSet bool
+	timeout time.Duration
+}
+
+func New() *cobra.Command {
+	var flags flags
+
+	//nolint:exhaustruct // it's okay for now
+	c := &cobra.Command{
+		Use:   "preview",
+		Short: "print sample rows of every table without writing them",
+		Long: "preview builds the tasks of the config like gen does and prints rows of every table " +
+			"as a table or json. Nothing is written: partitions aren't created, large objects are kept in memory, " +
+			"sequences are counted from instead of taken, reference columns take previewed rows " +
+			"of the parent tables and values already in them, or NULL if there are none.",
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			flags.seedSet = cobraCmd.Flags().Changed("seed")
+
+			return run(cobraCmd.Context(), flags, cobraCmd.OutOrStdout())
+		},
+	}
+
+	c.Flags().StringVarP(&flags.path, "config", "f", "config.yaml", "path to config file")
+	c.Flags().IntVarP(&flags.rows, "rows", "n", 10, "count of rows to print per table")
+	c.Flags().StringVar(&flags.format, "format", formatTable, "output format, table or json")
+	c.Flags().Uint64Var(&flags.seed, "seed", 0, "seed for reproducible generation, overrides options.seed")
+	c.Flags().DurationVar(
+		&flags.timeout, "timeout", 10*time.Second,
+		"time to generate rows of a table",
+	)
+
+	return c
+}
+
+func run(ctx context.Context, flags flags, stdout io.Writer) (err error) {
+	const fnName = "preview"
+
+	var render func(io.Writer, []table) error
+	switch flags.format {
+	case formatTable:
+		render = renderTable
+	case formatJSON:
+		render = renderJSON
+	default:
+		return fmt.Errorf("%w: %s %s", ErrUnknownFormat, flags.format, fnName)
+	}
+
+	cfg, err := config.Load(flags.path)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+	if flags.seedSet {
+		cfg.Options.Seed = &flags.seed
+	}
+	if cfg.Options.Seed == nil {
+		seed := rand.Uint64() //nolint:gosec // only picks the seed
+		cfg.Options.Seed = &seed
+	}
+	slog.Info(
+		"generation seed and reference time, pass them with --seed and options.referenceTime to reproduce the preview",
+		slog.Uint64("seed", *cfg.Options.Seed),
+		slog.Time("referenceTime", cfg.Options.FixReferenceTime()),
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	closers := closer.NewRegistry()
+	defer func() {
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer closeCancel()
+
+		err = errors.Join(err, closers.CloseAll(closeCtx))
+	}()
+
+	refSvc := refresolver.NewService()
+	tasks, err := buildTasks(ctx, cfg, refSvc, closers)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// parents go first, their rows are what references of children take,
+	// nothing else comes to them, self references included
+	tables := make([]table, 0, len(tasks))
+	for _, task := range tasks {
+		for _, parent := range refSvc.DepsOn()[task.DatasetSchema.TableName] {
+			refSvc.OnFinished(parent)
+		}
+		refSvc.OnFinished(task.DatasetSchema.TableName)
+
+		previewed := previewTask(ctx, task, flags)
+		refSvc.OnProcessed(model.SaveBatch{
+			Schema:      task.DatasetSchema,
+			Data:        previewed.rows,
+			SavingHints: nil,
+			Invalid:     make([]bool, len(previewed.rows)),
+		})
+
+		tables = append(tables, previewed)
+	}
+
+	if err := render(stdout, tables); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func buildTasks(
+	ctx context.Context,
+	cfg config.Config,
+	refSvc *refresolver.Service,
+	closers *closer.Registry,
+) ([]model.Task, error) {
+	const fnName = "build tasks"
+
+	acceptors, err := registry.PrepareAcceptors(ctx, cfg, refSvc, closers, registry.WithDryRun())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	progressController := progress.NewController(terminal.New(io.Discard), 1)
+	closers.Add(closer.Fn(progressController.Close))
+
+	tasks, err := taskbuilder.Build(
+		ctx, cfg, acceptors, refSvc, progressController, closers,
+		taskbuilder.WithDryRun(func(table model.TableName, partitions []string) {
+			slog.Info("partitions would be created", slog.String("table", table.String()), slog.Any("partitions", partitions))
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tasks, nil
+}
+
+type table struct {
+	name    model.TableName
+	columns []string
+	rows    [][]any
+}
+
+// previewTask keeps rows generated in time.
+func previewTask(ctx context.Context, task model.Task, flags flags) table {
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+
+	columns := make([]string, len(task.DatasetSchema.Columns))
+	for i, col := range task.DatasetSchema.Columns {
+		columns[i] = col.SourceName.AsArgument()
+	}
+
+	rows, err := execution.Preview(ctx, task, flags.rows)
+	if err != nil {
+		slog.Warn(
+			"table is previewed partially, referenced tables might be empty",
+			slog.String("table", task.TableName()),
+			slog.Int("rows", len(rows)),
+			slog.Any("error", err),
+		)
+	}
+
+	return table{name: task.DatasetSchema.TableName, columns: columns, rows: rows}
+}
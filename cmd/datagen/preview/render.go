@@ -0,0 +1,98 @@
+package preview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jmozgit/datagen/internal/saver/encode"
+)
+
+// maxCellWidth keeps long texts from stretching the table.
+const maxCellWidth = 40
+
+func renderTable(w io.Writer, tables []table) error {
+	const fnName = "render table"
+
+	for i, t := range tables {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s\n", t.name.String())
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.columns, "\t"))
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for j, val := range row {
+				cell, err := textCell(val)
+				if err != nil {
+					return fmt.Errorf("%w: %s %s", err, t.columns[j], fnName)
+				}
+				cells[j] = cell
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
+	return nil
+}
+
+func textCell(val any) (string, error) {
+	if val == nil {
+		return "NULL", nil
+	}
+
+	text, err := encode.Text(val)
+	if err != nil {
+		return "", fmt.Errorf("%w: text cell", err)
+	}
+
+	text = strings.NewReplacer("\t", `\t`, "\n", `\n`).Replace(text)
+	if runes := []rune(text); len(runes) > maxCellWidth {
+		text = string(runes[:maxCellWidth-3]) + "..."
+	}
+
+	return text, nil
+}
+
+type jsonTable struct {
+	Table   string           `json:"table"`
+	Columns []string         `json:"columns"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+func renderJSON(w io.Writer, tables []table) error {
+	const fnName = "render json"
+
+	out := make([]jsonTable, len(tables))
+	for i, t := range tables {
+		rows := make([]map[string]any, len(t.rows))
+		for j, row := range t.rows {
+			rows[j] = make(map[string]any, len(row))
+			for k, val := range row {
+				cell, err := encode.JSON(val)
+				if err != nil {
+					return fmt.Errorf("%w: %s %s", err, t.columns[k], fnName)
+				}
+				rows[j][t.columns[k]] = cell
+			}
+		}
+
+		out[i] = jsonTable{Table: t.name.String(), Columns: t.columns, Rows: rows}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
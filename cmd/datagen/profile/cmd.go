@@ -0,0 +1,126 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/profile"
+	"github.com/jmozgit/datagen/internal/schema/postgres"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrPostgresqlOnly = errors.New("profile reads pg_stats, it needs a postgresql connection")
+
+type flags struct {
+	path   string
+	output string
+}
+
+func New() *cobra.Command {
+	var flags flags
+
+	//nolint:exhaustruct // it's okay for now
+	c := &cobra.Command{
+		Use:   "profile",
+		Short: "fill generators of table targets from statistics of the database",
+		Long: "profile reads pg_stats of every table target and writes the config back with generators " +
+			"reproducing null fractions, most common values, histograms and text lengths. " +
+			"Generators already in the config are kept, no rows are read.",
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return run(cobraCmd.Context(), flags, cobraCmd.OutOrStdout())
+		},
+	}
+
+	c.Flags().StringVarP(&flags.path, "config", "f", "config.yaml", "path to config file")
+	c.Flags().StringVarP(&flags.output, "output", "o", "", "path to write the profiled config to, stdout if empty")
+
+	return c
+}
+
+func run(ctx context.Context, flags flags, stdout io.Writer) error {
+	const fnName = "profile"
+
+	cfg, err := config.Load(flags.path)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if cfg.Connection.Type != config.PostgresqlConnection {
+		return fmt.Errorf("%w: %s %s", ErrPostgresqlOnly, cfg.Connection.Type, fnName)
+	}
+
+	inspector, err := postgres.NewInspector(cfg.Connection.Postgresql)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	for i, target := range cfg.Targets {
+		if target.Table == nil {
+			continue
+		}
+
+		table, err := profileTable(ctx, inspector, *target.Table)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+		cfg.Targets[i].Table = &table
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if flags.output == "" {
+		_, err = stdout.Write(data)
+	} else {
+		err = os.WriteFile(flags.output, data, 0o600)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func profileTable(ctx context.Context, inspector *postgres.Inspector, table config.Table) (config.Table, error) {
+	const fnName = "profile table"
+
+	name, err := inspector.TableIdentifier(ctx, &table)
+	if err != nil {
+		return config.Table{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	dataset, err := inspector.Table(ctx, name)
+	if err != nil {
+		return config.Table{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	stats, err := inspector.ColumnStats(ctx, name)
+	if err != nil {
+		return config.Table{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(stats) == 0 {
+		slog.Warn("table has no statistics, run ANALYZE on it first", slog.String("table", name.String()))
+	}
+
+	configured := lo.SliceToMap(table.Generators, func(gen config.Generator) (string, struct{}) {
+		return gen.Column, struct{}{}
+	})
+	for _, gen := range profile.Generators(dataset, stats) {
+		if _, ok := configured[gen.Column]; !ok {
+			table.Generators = append(table.Generators, gen)
+		}
+	}
+
+	return table, nil
+}
@@ -0,0 +1,423 @@
+package check
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+)
+
+var ErrUnsatisfiable = errors.New("check constraint can't be satisfied")
+
+// Acceptors narrow their default ranges with these helpers, a nil check leaves the ranges untouched.
+
+// Values parses the allowed values, ok is false if the check doesn't list them.
+func Values[T any](c *model.ColumnCheck, parse func(string) (T, error)) ([]T, bool, error) {
+	if c == nil || len(c.In) == 0 {
+		return nil, false, nil
+	}
+
+	values := make([]T, len(c.In))
+	for i, raw := range c.In {
+		val, err := parse(raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: values", err)
+		}
+		values[i] = val
+	}
+
+	return values, true, nil
+}
+
+// HasBounds reports whether the check limits values from either side.
+func HasBounds(c *model.ColumnCheck) bool {
+	return c != nil && (c.Min != nil || c.Max != nil)
+}
+
+func ParseText(raw string) (string, error) {
+	return raw, nil
+}
+
+func ParseFloat(raw string) (float64, error) {
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: parse float", err)
+	}
+
+	return val, nil
+}
+
+// ParseInt accepts fractional literals too, they are truncated.
+func ParseInt(raw string) (int64, error) {
+	if val, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return val, nil
+	}
+
+	val, err := ParseFloat(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: parse int", err)
+	}
+
+	return int64(val), nil
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02",
+}
+
+// ParseTime understands the literals databases print and "now", which is the reference time of the run
+// rather than the clock so seeded runs repeat.
+func ParseTime(raw string, now time.Time) (time.Time, error) {
+	if strings.EqualFold(raw, "now") {
+		return now, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if val, err := time.Parse(layout, raw); err == nil {
+			return val, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: parse time %q", strconv.ErrSyntax, raw)
+}
+
+// TimeParser binds now of ParseTime, for Values.
+func TimeParser(now time.Time) func(string) (time.Time, error) {
+	return func(raw string) (time.Time, error) {
+		return ParseTime(raw, now)
+	}
+}
+
+// IntRange narrows the inclusive range to the check bounds.
+func IntRange(c *model.ColumnCheck, minV, maxV int64) (int64, int64, error) {
+	const fnName = "int range"
+
+	if c == nil {
+		return minV, maxV, nil
+	}
+
+	if c.Min != nil {
+		bound, err := ParseFloat(c.Min.Value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		lower := math.Ceil(bound)
+		if lower == bound && !c.Min.Inclusive {
+			lower++
+		}
+		if lower > float64(minV) {
+			minV = intFromFloat(lower)
+		}
+	}
+
+	if c.Max != nil {
+		bound, err := ParseFloat(c.Max.Value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		upper := math.Floor(bound)
+		if upper == bound && !c.Max.Inclusive {
+			upper--
+		}
+		if upper < float64(maxV) {
+			maxV = intFromFloat(upper)
+		}
+	}
+
+	if minV > maxV {
+		return 0, 0, fmt.Errorf("%w: [%d, %d] %s", ErrUnsatisfiable, minV, maxV, fnName)
+	}
+
+	return minV, maxV, nil
+}
+
+func intFromFloat(v float64) int64 {
+	switch {
+	case v >= math.MaxInt64:
+		return math.MaxInt64
+	case v <= math.MinInt64:
+		return math.MinInt64
+	default:
+		return int64(v)
+	}
+}
+
+// Around returns the default [-width, width] range for unbounded types,
+// moved to keep width values next to a check bounding only one side.
+func Around(c *model.ColumnCheck, width float64) (float64, float64) {
+	lower, upper := -width, width
+	if c == nil {
+		return lower, upper
+	}
+
+	if c.Min != nil && c.Max == nil {
+		if bound, err := ParseFloat(c.Min.Value); err == nil {
+			upper = max(upper, bound+width)
+		}
+	}
+
+	if c.Max != nil && c.Min == nil {
+		if bound, err := ParseFloat(c.Max.Value); err == nil {
+			lower = min(lower, bound-width)
+		}
+	}
+
+	return lower, upper
+}
+
+// FloatRange narrows the inclusive range to the check bounds, exclusive bounds are moved to the next float.
+func FloatRange(c *model.ColumnCheck, minV, maxV float64) (float64, float64, error) {
+	const fnName = "float range"
+
+	if c == nil {
+		return minV, maxV, nil
+	}
+
+	if c.Min != nil {
+		bound, err := ParseFloat(c.Min.Value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if !c.Min.Inclusive {
+			bound = math.Nextafter(bound, math.Inf(1))
+		}
+		minV = max(minV, bound)
+	}
+
+	if c.Max != nil {
+		bound, err := ParseFloat(c.Max.Value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if !c.Max.Inclusive {
+			bound = math.Nextafter(bound, math.Inf(-1))
+		}
+		maxV = min(maxV, bound)
+	}
+
+	if minV > maxV {
+		return 0, 0, fmt.Errorf("%w: [%g, %g] %s", ErrUnsatisfiable, minV, maxV, fnName)
+	}
+
+	return minV, maxV, nil
+}
+
+// TimeRange narrows the [from, to) range to the check bounds, step is the precision of the column.
+// A range moved past one of the defaults keeps its width, now is what "now" of the check means.
+func TimeRange(
+	c *model.ColumnCheck, now, from, to time.Time, step time.Duration,
+) (time.Time, time.Time, error) {
+	const fnName = "time range"
+
+	if c == nil {
+		return from, to, nil
+	}
+
+	width := to.Sub(from)
+	minFixed, maxFixed := false, false
+
+	if c.Min != nil {
+		bound, err := ParseTime(c.Min.Value, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if !c.Min.Inclusive {
+			bound = bound.Add(step)
+		}
+		// generators truncate to the step, a bound in the middle of one is moved to the next
+		if truncated := bound.Truncate(step); truncated.Before(bound) {
+			bound = truncated.Add(step)
+		}
+		if bound.After(from) {
+			from, minFixed = bound, true
+		}
+	}
+
+	if c.Max != nil {
+		bound, err := ParseTime(c.Max.Value, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if c.Max.Inclusive {
+			bound = bound.Add(step)
+		}
+		if bound.Before(to) {
+			to, maxFixed = bound, true
+		}
+	}
+
+	if !from.Before(to) {
+		switch {
+		case minFixed && maxFixed:
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: [%s, %s) %s", ErrUnsatisfiable, from, to, fnName)
+		case minFixed:
+			to = from.Add(width)
+		default:
+			from = to.Add(-width)
+		}
+	}
+
+	return from, to, nil
+}
+
+// LengthRange narrows the inclusive range of text lengths to the check bounds.
+func LengthRange(c *model.ColumnCheck, from, to int) (int, int, error) {
+	const fnName = "length range"
+
+	if c == nil {
+		return from, to, nil
+	}
+
+	if c.MaxLength != nil {
+		to = min(to, *c.MaxLength)
+		from = min(from, to)
+	}
+
+	if c.MinLength != nil {
+		from = max(from, *c.MinLength)
+		to = max(to, from)
+	}
+
+	if from < 0 || (c.MaxLength != nil && to > *c.MaxLength) {
+		return 0, 0, fmt.Errorf("%w: [%d, %d] %s", ErrUnsatisfiable, from, to, fnName)
+	}
+
+	return from, to, nil
+}
+
+// Intersect returns the check values satisfying both a and b have to satisfy.
+// Literals compared neither as numbers nor as times can't be ordered, the bound of b is kept then.
+// Now is what "now" of the checks means.
+func Intersect(a, b *model.ColumnCheck, now time.Time) (*model.ColumnCheck, error) {
+	const fnName = "intersect"
+
+	switch {
+	case a == nil:
+		return b, nil
+	case b == nil:
+		return a, nil
+	}
+
+	out := &model.ColumnCheck{
+		Min:       tighterBound(a.Min, b.Min, 1, now),
+		Max:       tighterBound(a.Max, b.Max, -1, now),
+		In:        nil,
+		MinLength: maxPtr(a.MinLength, b.MinLength),
+		MaxLength: minPtr(a.MaxLength, b.MaxLength),
+	}
+
+	switch {
+	case len(a.In) > 0 && len(b.In) > 0:
+		out.In = lo.Filter(a.In, func(v string, _ int) bool {
+			return slices.ContainsFunc(b.In, func(o string) bool {
+				order, ok := compareLiterals(v, o, now)
+
+				return v == o || (ok && order == 0)
+			})
+		})
+	case len(a.In) > 0:
+		out.In = lo.Filter(a.In, func(v string, _ int) bool { return inBounds(v, out.Min, out.Max, now) })
+	case len(b.In) > 0:
+		out.In = lo.Filter(b.In, func(v string, _ int) bool { return inBounds(v, out.Min, out.Max, now) })
+	default:
+		return out, nil
+	}
+
+	if len(out.In) == 0 {
+		return nil, fmt.Errorf("%w: no listed value is left %s", ErrUnsatisfiable, fnName)
+	}
+
+	return out, nil
+}
+
+// tighterBound picks the bound leaving fewer values, sign is 1 for lower bounds and -1 for upper ones.
+func tighterBound(a, b *model.CheckBound, sign int, now time.Time) *model.CheckBound {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	order, ok := compareLiterals(a.Value, b.Value, now)
+	switch {
+	case !ok:
+		return b
+	case order*sign > 0:
+		return a
+	case order*sign < 0:
+		return b
+	case !a.Inclusive:
+		return a
+	default:
+		return b
+	}
+}
+
+func inBounds(value string, minB, maxB *model.CheckBound, now time.Time) bool {
+	if minB != nil {
+		if order, ok := compareLiterals(value, minB.Value, now); ok && (order < 0 || (order == 0 && !minB.Inclusive)) {
+			return false
+		}
+	}
+
+	if maxB != nil {
+		if order, ok := compareLiterals(value, maxB.Value, now); ok && (order > 0 || (order == 0 && !maxB.Inclusive)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareLiterals orders literals as numbers or as times, ok is false if they are neither.
+func compareLiterals(a, b string, now time.Time) (int, bool) {
+	if x, err := ParseFloat(a); err == nil {
+		if y, err := ParseFloat(b); err == nil {
+			return cmp.Compare(x, y), true
+		}
+	}
+
+	if x, err := ParseTime(a, now); err == nil {
+		if y, err := ParseTime(b, now); err == nil {
+			return x.Compare(y), true
+		}
+	}
+
+	return 0, false
+}
+
+func maxPtr(a, b *int) *int {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+
+	return a
+}
+
+func minPtr(a, b *int) *int {
+	if a == nil || (b != nil && *b < *a) {
+		return b
+	}
+
+	return a
+}
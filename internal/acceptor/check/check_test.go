@@ -0,0 +1,123 @@
+package check_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/acceptor/check"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheck(minB, maxB *model.CheckBound) *model.ColumnCheck {
+	return &model.ColumnCheck{Min: minB, Max: maxB, In: nil, MinLength: nil, MaxLength: nil}
+}
+
+func Test_IntRange(t *testing.T) {
+	t.Parallel()
+
+	minV, maxV, err := check.IntRange(nil, math.MinInt16, math.MaxInt16)
+	require.NoError(t, err)
+	require.Equal(t, [2]int64{math.MinInt16, math.MaxInt16}, [2]int64{minV, maxV})
+
+	minV, maxV, err = check.IntRange(newCheck(
+		&model.CheckBound{Value: "0", Inclusive: false},
+		&model.CheckBound{Value: "10.5", Inclusive: false},
+	), math.MinInt16, math.MaxInt16)
+	require.NoError(t, err)
+	require.Equal(t, [2]int64{1, 10}, [2]int64{minV, maxV})
+
+	_, _, err = check.IntRange(newCheck(&model.CheckBound{Value: "200", Inclusive: true}, nil), math.MinInt8, math.MaxInt8)
+	require.ErrorIs(t, err, check.ErrUnsatisfiable)
+}
+
+func Test_FloatRange(t *testing.T) {
+	t.Parallel()
+
+	c := newCheck(&model.CheckBound{Value: "5e9", Inclusive: false}, nil)
+	lower, upper := check.Around(c, 1e9)
+	minV, maxV, err := check.FloatRange(c, lower, upper)
+	require.NoError(t, err)
+	require.Greater(t, minV, 5e9)
+	require.InDelta(t, 6e9, maxV, 1e-9)
+
+	_, _, err = check.FloatRange(newCheck(nil, &model.CheckBound{Value: "-5", Inclusive: true}), 0, 100)
+	require.ErrorIs(t, err, check.ErrUnsatisfiable)
+}
+
+func Test_TimeRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 2, 0)
+
+	// the window keeps its width when the check moves it past the defaults
+	actualFrom, actualTo, err := check.TimeRange(
+		newCheck(&model.CheckBound{Value: "2030-01-01", Inclusive: false}, nil),
+		from, from, to, time.Hour*24,
+	)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC), actualFrom)
+	require.Equal(t, to.Sub(from), actualTo.Sub(actualFrom))
+
+	actualFrom, actualTo, err = check.TimeRange(
+		newCheck(nil, &model.CheckBound{Value: "2025-01-10 12:00:00", Inclusive: true}),
+		from, from, to, time.Second,
+	)
+	require.NoError(t, err)
+	require.Equal(t, from, actualFrom)
+	require.Equal(t, time.Date(2025, time.January, 10, 12, 0, 1, 0, time.UTC), actualTo)
+
+	// now is the reference time, not the clock
+	now := from.AddDate(0, 1, 0)
+	actualFrom, actualTo, err = check.TimeRange(
+		newCheck(nil, &model.CheckBound{Value: "now", Inclusive: false}),
+		now, from, to, time.Second,
+	)
+	require.NoError(t, err)
+	require.Equal(t, from, actualFrom)
+	require.Equal(t, now, actualTo)
+}
+
+func Test_LengthRange(t *testing.T) {
+	t.Parallel()
+
+	c := &model.ColumnCheck{Min: nil, Max: nil, In: nil, MinLength: lo.ToPtr(3), MaxLength: lo.ToPtr(8)}
+	from, to, err := check.LengthRange(c, 20, 100)
+	require.NoError(t, err)
+	require.Equal(t, [2]int{8, 8}, [2]int{from, to})
+
+	c.MaxLength = nil
+	from, to, err = check.LengthRange(c, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, [2]int{3, 3}, [2]int{from, to})
+}
+
+func Test_Intersect(t *testing.T) {
+	t.Parallel()
+
+	column := newCheck(&model.CheckBound{Value: "2024-01-15", Inclusive: true}, nil)
+	partition := newCheck(
+		&model.CheckBound{Value: "2024-01-01 00:00:00", Inclusive: true},
+		&model.CheckBound{Value: "2024-02-01 00:00:00", Inclusive: false},
+	)
+
+	out, err := check.Intersect(column, partition, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, newCheck(column.Min, partition.Max), out)
+
+	out, err = check.Intersect(nil, partition, time.Time{})
+	require.NoError(t, err)
+	require.Same(t, partition, out)
+
+	listed := &model.ColumnCheck{Min: nil, Max: nil, In: []string{"1", "5", "20"}, MinLength: nil, MaxLength: nil}
+	out, err = check.Intersect(listed, newCheck(&model.CheckBound{Value: "5", Inclusive: true}, nil), time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"5", "20"}, out.In)
+
+	_, err = check.Intersect(listed, newCheck(nil, &model.CheckBound{Value: "1", Inclusive: false}), time.Time{})
+	require.ErrorIs(t, err, check.ErrUnsatisfiable)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/generator/timestamp"
 	"github.com/jmozgit/datagen/internal/model"
 )
@@ -27,13 +29,34 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
-	nowY := time.Now().Year()
+	values, ok, err := check.Values(baseType.Check, check.TimeParser(req.Now))
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if ok {
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceReasonColumnType,
+			Generator:      oneof.NewGenerator(req.Rand, values),
+			ChooseCallback: nil,
+		}, nil
+	}
+
+	nowY := req.Now.Year()
+	from, to, err := check.TimeRange(
+		baseType.Check,
+		req.Now,
+		time.Date(nowY-2, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(nowY+2, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Hour*24,
+	)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
 	return model.AcceptanceDecision{
-		AcceptedBy: model.AcceptanceReasonColumnType,
-		Generator: timestamp.NewInRangeGenerator(
-			time.Date(nowY-2, time.January, 1, 0, 0, 0, 0, time.UTC),
-			time.Date(nowY+2, time.January, 1, 0, 0, 0, 0, time.UTC),
-		),
+		AcceptedBy:     model.AcceptanceReasonColumnType,
+		Generator:      timestamp.NewInRangeGenerator(req.Rand, from, to),
 		ChooseCallback: nil,
 	}, nil
 }
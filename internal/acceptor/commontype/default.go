@@ -17,6 +17,7 @@ import (
 func DefaultProviderGenerators(
 	registry contract.GeneratorRegistry,
 	setter contract.SetterOptionBasedGenerator,
+	fakerByColumnName bool,
 ) ([]contract.GeneratorProvider, error) {
 	gens := []contract.GeneratorProvider{
 		float.NewProvider(),
@@ -24,7 +25,7 @@ func DefaultProviderGenerators(
 		time.NewProvider(),
 		uuid.NewProvider(),
 		date.NewProvider(),
-		text.NewProvider(),
+		text.NewProvider(fakerByColumnName),
 		array.NewProvider(registry),
 	}
 
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/generator/float"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/model"
 )
 
@@ -34,22 +36,59 @@ func (p Provider) Accept(
 		float64Gen = 8
 	)
 
-	switch baseType.FixedSize {
-	case -1:
+	if baseType.FixedSize == -1 {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	if baseType.Check != nil {
+		gen, err := checkedGenerator(req, baseType.Check)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceReasonColumnType,
+			Generator:      gen,
+			ChooseCallback: nil,
+		}, nil
+	}
+
+	switch baseType.FixedSize {
 	case 0, float32Gen:
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      float.NewUnboundedFloat32Generator(),
+			Generator:      float.NewUnboundedFloat32Generator(req.Rand),
 			ChooseCallback: nil,
 		}, nil
 	case float64Gen:
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      float.NewUnboundedFloat64Generator(),
+			Generator:      float.NewUnboundedFloat64Generator(req.Rand),
 			ChooseCallback: nil,
 		}, nil
 	default:
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", ErrFloatSizeUnspecified, fnName)
 	}
 }
+
+// checkedGenerator keeps values finite, a side not bounded by the check is checkedBound away from the other one.
+func checkedGenerator(req contract.AcceptRequest, c *model.ColumnCheck) (model.Generator, error) {
+	const checkedBound = 1e9
+
+	values, ok, err := check.Values(c, check.ParseFloat)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checked generator", err)
+	}
+
+	if ok {
+		return oneof.NewGenerator(req.Rand, values), nil
+	}
+
+	lower, upper := check.Around(c, checkedBound)
+	minV, maxV, err := check.FloatRange(c, lower, upper)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checked generator", err)
+	}
+
+	return float.NewInRangeGenerator(req.Rand, minV, maxV), nil
+}
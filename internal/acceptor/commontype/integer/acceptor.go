@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/model"
 )
 
@@ -37,32 +39,41 @@ func (p Provider) Accept(
 		int64Gen = 8
 	)
 
+	var minV, maxV int64
 	switch baseType.FixedSize {
 	case 0, int32Gen:
-		return model.AcceptanceDecision{
-			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      integer.NewRandomInRangeGenerator(math.MinInt32, math.MaxInt32),
-			ChooseCallback: nil,
-		}, nil
+		minV, maxV = math.MinInt32, math.MaxInt32
 	case int8Gen:
-		return model.AcceptanceDecision{
-			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      integer.NewRandomInRangeGenerator(math.MinInt8, math.MaxInt8),
-			ChooseCallback: nil,
-		}, nil
+		minV, maxV = math.MinInt8, math.MaxInt8
 	case int16Gen:
-		return model.AcceptanceDecision{
-			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      integer.NewRandomInRangeGenerator(math.MinInt16, math.MaxInt16),
-			ChooseCallback: nil,
-		}, nil
+		minV, maxV = math.MinInt16, math.MaxInt16
 	case int64Gen:
+		minV, maxV = math.MinInt64, math.MaxInt64
+	default:
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", ErrUnknownByteSize, fnName)
+	}
+
+	values, ok, err := check.Values(baseType.Check, check.ParseInt)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if ok {
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceReasonColumnType,
-			Generator:      integer.NewRandomInRangeGenerator(math.MinInt64, math.MaxInt64),
+			Generator:      oneof.NewGenerator(req.Rand, values),
 			ChooseCallback: nil,
 		}, nil
-	default:
-		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", ErrUnknownByteSize, fnName)
 	}
+
+	minV, maxV, err = check.IntRange(baseType.Check, minV, maxV)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonColumnType,
+		Generator:      integer.NewRandomInRangeGenerator(req.Rand, minV, maxV),
+		ChooseCallback: nil,
+	}, nil
 }
@@ -33,7 +33,7 @@ func (p Provider) Accept(
 	}
 
 	return model.AcceptanceDecision{
-		Generator:      null.NewGenerator(settings.NullFraction, baseGen),
+		Generator:      null.NewGenerator(req.Rand, settings.NullFraction, baseGen),
 		ChooseCallback: nil,
 		AcceptedBy:     model.AcceptanceReasonColumnType,
 	}, nil
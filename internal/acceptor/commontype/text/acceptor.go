@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/generator/text"
 	"github.com/jmozgit/datagen/internal/model"
 )
 
-type Provider struct{}
+type Provider struct {
+	fakerByColumnName bool
+}
 
-func NewProvider() Provider {
-	return Provider{}
+func NewProvider(fakerByColumnName bool) Provider {
+	return Provider{fakerByColumnName: fakerByColumnName}
 }
 
 func (p Provider) Accept(
@@ -26,9 +31,32 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
+	if values, ok, _ := check.Values(baseType.Check, check.ParseText); ok {
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceReasonColumnType,
+			Generator:      oneof.NewGenerator(req.Rand, values),
+			ChooseCallback: nil,
+		}, nil
+	}
+
+	if p.fakerByColumnName && baseType.Check == nil {
+		if gen, ok := faker.ByColumnName(req.Rand, req.Now, baseType.SourceName.AsArgument(), 0); ok {
+			return model.AcceptanceDecision{
+				AcceptedBy:     model.AcceptanceReasonColumnType,
+				Generator:      gen,
+				ChooseCallback: nil,
+			}, nil
+		}
+	}
+
+	from, to, err := check.LengthRange(baseType.Check, 20, 100)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceReasonColumnType,
-		Generator:      text.NewInRangeSizeGenerator(20, 100),
+		Generator:      text.NewInRangeSizeGenerator(req.Rand, from, to),
 		ChooseCallback: nil,
 	}, nil
 }
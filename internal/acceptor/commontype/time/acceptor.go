@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/generator/timestamp"
 	"github.com/jmozgit/datagen/internal/model"
 )
@@ -27,12 +29,33 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
+	values, ok, err := check.Values(baseType.Check, check.TimeParser(req.Now))
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if ok {
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceReasonColumnType,
+			Generator:      oneof.NewGenerator(req.Rand, values),
+			ChooseCallback: nil,
+		}, nil
+	}
+
+	from, to, err := check.TimeRange(
+		baseType.Check,
+		req.Now,
+		req.Now.Add(-time.Hour*24*60),
+		req.Now.Add(time.Hour*24*60),
+		time.Second,
+	)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
 	return model.AcceptanceDecision{
-		AcceptedBy: model.AcceptanceReasonColumnType,
-		Generator: timestamp.NewInRangeGenerator(
-			time.Now().Add(-time.Hour*24*60),
-			time.Now().Add(time.Hour*24*60),
-		),
+		AcceptedBy:     model.AcceptanceReasonColumnType,
+		Generator:      timestamp.NewInRangeGenerator(req.Rand, from, to),
 		ChooseCallback: nil,
 	}, nil
 }
@@ -28,7 +28,7 @@ func (p Provider) Accept(
 
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceReasonColumnType,
-		Generator:      uuid.NewUUIDV4Generator(),
+		Generator:      uuid.NewUUIDV4Generator(req.Rand),
 		ChooseCallback: nil,
 	}, nil
 }
@@ -0,0 +1,86 @@
+package binary
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/bytea"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// maxGeneratedSize keeps blobs small, their column limits go up to gigabytes.
+const maxGeneratedSize = 2 * datasize.KB
+
+//nolint:gochecknoglobals // more convenient that constants here
+var binaryTypes = []string{"binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob"}
+
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+func (p *Provider) getMaxSize(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (datasize.ByteSize, error) {
+	const fnName = "get max size"
+
+	const query = `
+	SELECT
+		character_octet_length
+	FROM information_schema.columns
+	WHERE table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	`
+
+	var size sql.NullInt64
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&size); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return min(datasize.ByteSize(size.Int64), maxGeneratedSize), nil //nolint:gosec // sizes are positive
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql binary: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || !slices.Contains(binaryTypes, baseType.SourceType) {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	size, err := p.getMaxSize(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// binary(n) is padded up to n anyway, the others vary within their limit
+	gen := bytea.NewAroundByteaGenerator(req.Rand, size/2, size/2) //nolint:mnd // around the middle
+	if baseType.SourceType == "binary" {
+		gen = bytea.NewAroundByteaGenerator(req.Rand, size, 0)
+	}
+
+	return model.AcceptanceDecision{
+		ChooseCallback: nil,
+		Generator:      gen,
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/binary"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/enum"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/float"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/integer"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/reference"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/text"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reuse"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/refresolver"
+)
+
+func DefaultProviderGenerators(
+	conn db.Connect,
+	refResolver *refresolver.Service,
+	registry contract.GeneratorRegistry,
+	setter contract.SetterOptionBasedGenerator,
+	fakerByColumnName bool,
+) ([]contract.GeneratorProvider, error) {
+	if err := setter.SetReuseValuesGeneratorProvider(reuse.NewProvider(conn, reference.SampleQuery)); err != nil {
+		return nil, fmt.Errorf("%w: mysql default provider generator", err)
+	}
+
+	return []contract.GeneratorProvider{
+		integer.NewProvider(conn),
+		float.NewProvider(conn),
+		text.NewProvider(conn, registry, fakerByColumnName),
+		enum.NewProvider(conn),
+		binary.NewProvider(conn),
+		reference.NewProvider(conn, refResolver),
+	}, nil
+}
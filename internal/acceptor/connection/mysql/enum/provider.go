@@ -0,0 +1,138 @@
+package enum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/mysql/set"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+var ErrMalformedMembers = errors.New("malformed enum members")
+
+type Provider struct {
+	connect db.Connect
+}
+
+func NewProvider(connect db.Connect) *Provider {
+	return &Provider{
+		connect: connect,
+	}
+}
+
+func (p *Provider) columnType(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (string, error) {
+	const query = `
+	SELECT
+		column_type
+	FROM information_schema.columns
+	WHERE table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	`
+
+	var columnType string
+	if err := p.connect.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&columnType); err != nil {
+		return "", fmt.Errorf("%w: column type", err)
+	}
+
+	return columnType, nil
+}
+
+// ParseMembers extracts members of a column type like enum('a','b”c').
+func ParseMembers(columnType string) ([]string, error) {
+	const fnName = "parse members"
+
+	open, closing := strings.IndexByte(columnType, '('), strings.LastIndexByte(columnType, ')')
+	if open == -1 || closing < open {
+		return nil, fmt.Errorf("%w: %s %s", ErrMalformedMembers, columnType, fnName)
+	}
+
+	list := columnType[open+1 : closing]
+	members := make([]string, 0)
+	for len(list) > 0 {
+		if list[0] != '\'' {
+			return nil, fmt.Errorf("%w: %s %s", ErrMalformedMembers, columnType, fnName)
+		}
+
+		var (
+			member strings.Builder
+			i      = 1
+		)
+		for ; i < len(list); i++ {
+			if list[i] != '\'' {
+				member.WriteByte(list[i])
+
+				continue
+			}
+			if i+1 < len(list) && list[i+1] == '\'' {
+				member.WriteByte('\'')
+				i++
+
+				continue
+			}
+			// some servers don't double quotes, a quote ends the member only before the next one
+			if i+1 == len(list) || strings.HasPrefix(list[i+1:], ",'") {
+				break
+			}
+			member.WriteByte('\'')
+		}
+		if i >= len(list) {
+			return nil, fmt.Errorf("%w: %s %s", ErrMalformedMembers, columnType, fnName)
+		}
+
+		members = append(members, member.String())
+		list = strings.TrimPrefix(list[i+1:], ",")
+	}
+
+	return members, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql enum: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || (baseType.SourceType != "enum" && baseType.SourceType != "set") {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	columnType, err := p.columnType(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	members, err := ParseMembers(columnType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(members) == 0 {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	var gen model.Generator = oneof.NewGenerator(req.Rand, members)
+	if baseType.SourceType == "set" {
+		gen = set.NewGenerator(req.Rand, members)
+	}
+
+	return model.AcceptanceDecision{
+		Generator:      gen,
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		ChooseCallback: nil,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package enum_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		columnType string
+		members    []string
+	}{
+		{columnType: "enum('a','b')", members: []string{"a", "b"}},
+		{columnType: "set('x,y','it''s')", members: []string{"x,y", "it's"}},
+		{columnType: "enum('')", members: []string{""}},
+		{columnType: "enum('it's','ok')", members: []string{"it's", "ok"}},
+		{columnType: "enum()", members: []string{}},
+	}
+
+	for _, tc := range tests {
+		members, err := enum.ParseMembers(tc.columnType)
+		require.NoError(t, err, tc.columnType)
+		require.Equal(t, tc.members, members, tc.columnType)
+	}
+}
+
+func Test_ParseMembersMalformed(t *testing.T) {
+	t.Parallel()
+
+	for _, columnType := range []string{"enum", "enum('a", "enum(a)"} {
+		_, err := enum.ParseMembers(columnType)
+		require.ErrorIs(t, err, enum.ErrMalformedMembers, columnType)
+	}
+}
@@ -0,0 +1,99 @@
+package float
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/float"
+	"github.com/jmozgit/datagen/internal/generator/mysql/decimal"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// floatBound keeps generated floats readable, mysql rejects NaN and infinities anyway.
+const floatBound = 1e9
+
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+type numericInfo struct {
+	precision int
+	scale     int
+	unsigned  bool
+}
+
+func (p *Provider) getNumericInfo(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (numericInfo, error) {
+	const fnName = "get numeric info"
+
+	const query = `
+	SELECT
+		numeric_precision, numeric_scale, column_type
+	FROM information_schema.columns
+	WHERE table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	`
+
+	var (
+		precision, scale sql.NullInt64
+		columnType       string
+	)
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&precision, &scale, &columnType); err != nil {
+		return numericInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return numericInfo{
+		precision: int(precision.Int64),
+		scale:     int(scale.Int64),
+		unsigned:  strings.Contains(columnType, "unsigned"),
+	}, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql float: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Float {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	info, err := p.getNumericInfo(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	var gen model.Generator
+	switch {
+	case baseType.SourceType == "decimal":
+		gen = decimal.NewGenerator(req.Rand, info.precision, info.scale, info.unsigned)
+	case info.unsigned:
+		gen = float.NewInRangeGenerator(req.Rand, 0, floatBound)
+	default:
+		gen = float.NewInRangeGenerator(req.Rand, -floatBound, floatBound)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package integer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+type intRange struct {
+	signedMin, signedMax int64
+	unsignedMax          int64
+}
+
+// bigint unsigned is generated within int64, the generators don't go further.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var ranges = map[string]intRange{
+	"tinyint":   {signedMin: math.MinInt8, signedMax: math.MaxInt8, unsignedMax: math.MaxUint8},
+	"smallint":  {signedMin: math.MinInt16, signedMax: math.MaxInt16, unsignedMax: math.MaxUint16},
+	"mediumint": {signedMin: -1 << 23, signedMax: 1<<23 - 1, unsignedMax: 1<<24 - 1},
+	"int":       {signedMin: math.MinInt32, signedMax: math.MaxInt32, unsignedMax: math.MaxUint32},
+	"bigint":    {signedMin: math.MinInt64, signedMax: math.MaxInt64, unsignedMax: math.MaxInt64},
+}
+
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+type columnInfo struct {
+	unsigned      bool
+	autoIncrement bool
+}
+
+func (p *Provider) getColumnInfo(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (columnInfo, error) {
+	const fnName = "get column info"
+
+	const query = `
+	SELECT
+		column_type, extra
+	FROM information_schema.columns
+	WHERE table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	`
+
+	var columnType, extra string
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&columnType, &extra); err != nil {
+		return columnInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columnInfo{
+		unsigned:      strings.Contains(columnType, "unsigned"),
+		autoIncrement: strings.Contains(extra, "auto_increment"),
+	}, nil
+}
+
+func (p *Provider) nextAutoIncrement(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (int64, error) {
+	query := fmt.Sprintf(
+		"SELECT COALESCE(MAX(%s), 0) + 1 FROM %s",
+		baseType.SourceName.Quoted(), dataset.TableName.Quoted(),
+	)
+
+	var next int64
+	if err := p.conn.QueryRow(ctx, query).Scan(&next); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 1, nil
+		}
+
+		return 0, fmt.Errorf("%w: next auto increment", err)
+	}
+
+	return next, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql integer: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Integer {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	bounds, ok := ranges[baseType.SourceType]
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	info, err := p.getColumnInfo(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	var (
+		gen    model.Generator
+		choose model.ChooseCallback
+	)
+	switch {
+	case info.autoIncrement:
+		// auto_increment values are set explicitly, so that they are known to referencing tables
+		next, err := p.nextAutoIncrement(ctx, req.Dataset, baseType)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+		serial := integer.NewSerialIntegerGenerator(next)
+		gen, choose = serial, req.KeepState(serial)
+	case info.unsigned:
+		gen = integer.NewRandomInRangeGenerator(req.Rand, 0, bounds.unsignedMax)
+	default:
+		gen = integer.NewRandomInRangeGenerator(req.Rand, bounds.signedMin, bounds.signedMax)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: choose,
+	}, nil
+}
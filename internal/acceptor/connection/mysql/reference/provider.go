@@ -0,0 +1,122 @@
+package reference
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reader"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/reference"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+type Provider struct {
+	connect db.Connect
+	refsvc  model.ReferenceResolver
+}
+
+func NewProvider(
+	connect db.Connect,
+	refsvc model.ReferenceResolver,
+) *Provider {
+	return &Provider{
+		connect: connect,
+		refsvc:  refsvc,
+	}
+}
+
+type referenceInfo struct {
+	table  model.TableName
+	column model.Identifier
+}
+
+func (p *Provider) resolveReference(
+	ctx context.Context,
+	ds model.DatasetSchema,
+	baseType model.TargetType,
+) (referenceInfo, error) {
+	const fnName = "resolve reference"
+
+	const query = `
+	SELECT
+		referenced_table_schema, referenced_table_name, referenced_column_name
+	FROM information_schema.key_column_usage
+	WHERE
+		referenced_table_name IS NOT NULL
+		AND table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	LIMIT 1
+	`
+
+	var (
+		schema string
+		table  string
+		column string
+	)
+
+	err := p.connect.
+		QueryRow(
+			ctx, query,
+			ds.TableName.Schema.AsArgument(),
+			ds.TableName.Table.AsArgument(),
+			baseType.SourceName.AsArgument(),
+		).
+		Scan(&schema, &table, &column)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return referenceInfo{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+		}
+
+		return referenceInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return referenceInfo{
+		table: model.TableName{
+			Schema: model.MySQLIdentifier(schema),
+			Table:  model.MySQLIdentifier(table),
+		},
+		column: model.MySQLIdentifier(column),
+	}, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql reference: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	refInfo, err := p.resolveReference(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	reader := reader.NewConnection(SampleQuery, refInfo.table, refInfo.column, 150, p.connect)
+	generator, chooseCallback := reference.NewBufferedValuesGenerator(
+		req.Dataset, reader,
+		refInfo.table, refInfo.column, p.refsvc,
+		100,
+	)
+
+	return model.AcceptanceDecision{
+		Generator:      generator,
+		ChooseCallback: chooseCallback,
+		AcceptedBy:     model.AcceptanceReasonReference,
+	}, nil
+}
+
+func SampleQuery(table model.TableName, column model.Identifier, limit int) string {
+	// mysql has no TABLESAMPLE, sorting by RAND() scans the whole table
+	return fmt.Sprintf(
+		`SELECT %s FROM %s ORDER BY RAND() LIMIT %d`,
+		column.Quoted(), table.Quoted(), limit,
+	)
+}
@@ -0,0 +1,105 @@
+package text
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/acceptor/user/sized"
+	"github.com/jmozgit/datagen/internal/acceptor/user/template"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/generator/text"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// maxGeneratedLen keeps wide varchar columns clear of the row size limit.
+const maxGeneratedLen = 255
+
+type Provider struct {
+	conn              db.Connect
+	templates         *template.Provider
+	fakerByColumnName bool
+}
+
+func NewProvider(conn db.Connect, registry contract.GeneratorRegistry, fakerByColumnName bool) *Provider {
+	return &Provider{conn: conn, templates: template.NewProvider(registry), fakerByColumnName: fakerByColumnName}
+}
+
+func (s *Provider) getTextSize(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (int64, error) {
+	const fnName = "get text size"
+
+	const query = `
+	SELECT
+		character_maximum_length
+	FROM information_schema.columns
+	WHERE table_schema = ?
+		AND table_name = ?
+		AND column_name = ?
+	`
+
+	var size sql.NullInt64
+	if err := s.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&size); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return size.Int64, nil
+}
+
+func (s *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "mysql text: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Text {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	if sized.Requested(req) {
+		size, err := s.getTextSize(ctx, req.Dataset, baseType)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		decision, err := sized.Accept(ctx, s.templates, req, int(size))
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return decision, nil
+	}
+
+	if !slices.Contains([]string{"char", "varchar"}, baseType.SourceType) {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	size, err := s.getTextSize(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	gen := text.NewFixedSizedStringGenerator(req.Rand, int(min(size, maxGeneratedLen)))
+	if s.fakerByColumnName {
+		if fake, ok := faker.ByColumnName(req.Rand, req.Now, baseType.SourceName.AsArgument(), int(size)); ok {
+			gen = fake
+		}
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package binary
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/bytea"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// lobGeneratedSize is the average size of generated blobs, their limits go up to terabytes.
+const lobGeneratedSize = datasize.KB
+
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+func (p *Provider) getRawSize(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (datasize.ByteSize, error) {
+	const fnName = "get raw size"
+
+	const query = `
+	SELECT
+		data_length
+	FROM all_tab_columns
+	WHERE owner = :1
+		AND table_name = :2
+		AND column_name = :3
+	`
+
+	var size uint64
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&size); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return datasize.ByteSize(size), nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "oracle binary: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || !slices.Contains([]string{"raw", "blob", "long raw"}, baseType.SourceType) {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	gen := bytea.NewAroundByteaGenerator(req.Rand, lobGeneratedSize, lobGeneratedSize/2) //nolint:mnd // half
+	if baseType.SourceType == "raw" {
+		size, err := p.getRawSize(ctx, req.Dataset, baseType)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		gen = bytea.NewAroundByteaGenerator(req.Rand, size/2, size/2) //nolint:mnd // around the middle
+	}
+
+	return model.AcceptanceDecision{
+		ChooseCallback: nil,
+		Generator:      gen,
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+	}, nil
+}
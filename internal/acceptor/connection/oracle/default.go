@@ -0,0 +1,36 @@
+package oracle
+
+import (
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/binary"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/float"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/integer"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/reference"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/text"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reuse"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/refresolver"
+)
+
+func DefaultProviderGenerators(
+	conn db.Connect,
+	refResolver *refresolver.Service,
+	registry contract.GeneratorRegistry,
+	setter contract.SetterOptionBasedGenerator,
+	fakerByColumnName bool,
+	dryRun bool,
+) ([]contract.GeneratorProvider, error) {
+	if err := setter.SetReuseValuesGeneratorProvider(reuse.NewProvider(conn, reference.SampleQuery)); err != nil {
+		return nil, fmt.Errorf("%w: oracle default provider generator", err)
+	}
+
+	return []contract.GeneratorProvider{
+		integer.NewProvider(conn, dryRun),
+		float.NewProvider(conn),
+		text.NewProvider(conn, registry, fakerByColumnName),
+		binary.NewProvider(conn),
+		reference.NewProvider(conn, refResolver),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package float
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/float"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// floatBound keeps generated floats readable, it's used for numbers without a precision.
+const floatBound = 1e9
+
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+func (p *Provider) getNumericInfo(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (sql.NullInt64, sql.NullInt64, error) {
+	const fnName = "get numeric info"
+
+	const query = `
+	SELECT
+		data_precision, data_scale
+	FROM all_tab_columns
+	WHERE owner = :1
+		AND table_name = :2
+		AND column_name = :3
+	`
+
+	var precision, scale sql.NullInt64
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&precision, &scale); err != nil {
+		return sql.NullInt64{}, sql.NullInt64{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return precision, scale, nil
+}
+
+// NumberBound is the largest value NUMBER(precision, scale) holds, 999.99 for NUMBER(5,2).
+// Generated values are rounded to the scale by the database and stay within it.
+func NumberBound(precision, scale int) float64 {
+	return math.Pow10(precision-scale) - math.Pow10(-scale)
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "oracle float: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Float {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	bound := floatBound
+	if baseType.SourceType == "number" {
+		precision, scale, err := p.getNumericInfo(ctx, req.Dataset, baseType)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if precision.Valid && scale.Valid {
+			bound = min(NumberBound(int(precision.Int64), int(scale.Int64)), floatBound)
+		}
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      float.NewInRangeGenerator(req.Rand, -bound, bound),
+		ChooseCallback: nil,
+	}, nil
+}
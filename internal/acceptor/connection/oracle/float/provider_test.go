@@ -0,0 +1,17 @@
+package float_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/float"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NumberBound(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 999.99, float.NumberBound(5, 2), 1e-9)
+	require.InDelta(t, 0.099, float.NumberBound(2, 3), 1e-9)
+	require.InDelta(t, 9_999_900, float.NumberBound(5, -2), 1e-9)
+}
@@ -0,0 +1,150 @@
+package integer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/generator/oracle/sequence"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+const (
+	// maxExactPrecision is the widest NUMBER(p) whose bounds fit into int64,
+	// wider ones are left to the common type generator.
+	maxExactPrecision = 18
+	sequencePrefetch  = 100
+)
+
+type Provider struct {
+	conn   db.Connect
+	dryRun bool
+}
+
+// NewProvider with dryRun set counts from sequences instead of taking their values.
+func NewProvider(conn db.Connect, dryRun bool) *Provider {
+	return &Provider{conn: conn, dryRun: dryRun}
+}
+
+type columnInfo struct {
+	precision sql.NullInt64
+	sequence  sql.NullString
+	seqOwner  string
+}
+
+var nextvalDefault = regexp.MustCompile(`(?i)^\s*(?:("[^"]+"|[a-z][\w$#]*)\.)?("[^"]+"|[a-z][\w$#]*)\.nextval\s*$`)
+
+// ParseSequenceDefault reads the sequence out of a column default like "HR"."ORDERS_SEQ".NEXTVAL,
+// identity columns have such a default too. The owner is empty if it isn't qualified.
+func ParseSequenceDefault(columnDefault string) (string, string, bool) {
+	match := nextvalDefault.FindStringSubmatch(columnDefault)
+	if match == nil {
+		return "", "", false
+	}
+
+	return unquote(match[1]), unquote(match[2]), true
+}
+
+// unquote turns a name into the way the dictionary stores it.
+func unquote(name string) string {
+	if unquoted, ok := strings.CutPrefix(name, `"`); ok {
+		return strings.TrimSuffix(unquoted, `"`)
+	}
+
+	return strings.ToUpper(name)
+}
+
+func (p *Provider) getColumnInfo(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (columnInfo, error) {
+	const fnName = "get column info"
+
+	const query = `
+	SELECT
+		c.data_precision, c.data_default, i.sequence_name
+	FROM all_tab_columns c
+	LEFT JOIN all_tab_identity_cols i
+		ON i.owner = c.owner AND i.table_name = c.table_name AND i.column_name = c.column_name
+	WHERE c.owner = :1
+		AND c.table_name = :2
+		AND c.column_name = :3
+	`
+
+	var (
+		info = columnInfo{
+			precision: sql.NullInt64{Int64: 0, Valid: false},
+			sequence:  sql.NullString{String: "", Valid: false},
+			seqOwner:  dataset.TableName.Schema.AsArgument(),
+		}
+		columnDefault sql.NullString
+	)
+	if err := p.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Schema.AsArgument(), dataset.TableName.Table.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&info.precision, &columnDefault, &info.sequence); err != nil {
+		return columnInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if !info.sequence.Valid && columnDefault.Valid {
+		if owner, name, ok := ParseSequenceDefault(columnDefault.String); ok {
+			info.sequence = sql.NullString{String: name, Valid: true}
+			if owner != "" {
+				info.seqOwner = owner
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "oracle integer: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Integer {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	info, err := p.getColumnInfo(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	var gen model.Generator
+	switch {
+	case info.sequence.Valid && p.dryRun:
+		gen = sequence.NewPeekGenerator(p.conn, model.TableName{
+			Schema: model.OracleIdentifier(info.seqOwner),
+			Table:  model.OracleIdentifier(info.sequence.String),
+		})
+	case info.sequence.Valid:
+		// identity columns generated by default and columns defaulting to a sequence
+		gen = sequence.NewGenerator(p.conn, model.TableName{
+			Schema: model.OracleIdentifier(info.seqOwner),
+			Table:  model.OracleIdentifier(info.sequence.String),
+		}, sequencePrefetch)
+	case info.precision.Valid && info.precision.Int64 <= maxExactPrecision:
+		bound := int64(math.Pow10(int(info.precision.Int64))) - 1
+		gen = integer.NewRandomInRangeGenerator(req.Rand, -bound, bound)
+	default:
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
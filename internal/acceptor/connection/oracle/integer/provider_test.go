@@ -0,0 +1,38 @@
+package integer_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle/integer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSequenceDefault(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		columnDefault string
+		owner         string
+		name          string
+		ok            bool
+	}{
+		{columnDefault: `"HR"."ISEQ$$_73412".nextval`, owner: "HR", name: "ISEQ$$_73412", ok: true},
+		{columnDefault: "orders_seq.NEXTVAL", owner: "", name: "ORDERS_SEQ", ok: true},
+		{columnDefault: ` hr."Mixed_Seq".nextval `, owner: "HR", name: "Mixed_Seq", ok: true},
+		{columnDefault: "0", owner: "", name: "", ok: false},
+		{columnDefault: "SYSDATE", owner: "", name: "", ok: false},
+		{columnDefault: "orders_seq.currval", owner: "", name: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.columnDefault, func(t *testing.T) {
+			t.Parallel()
+
+			owner, name, ok := integer.ParseSequenceDefault(tc.columnDefault)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.owner, owner)
+			require.Equal(t, tc.name, name)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package reference
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reader"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/reference"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+type Provider struct {
+	connect db.Connect
+	refsvc  model.ReferenceResolver
+}
+
+func NewProvider(
+	connect db.Connect,
+	refsvc model.ReferenceResolver,
+) *Provider {
+	return &Provider{
+		connect: connect,
+		refsvc:  refsvc,
+	}
+}
+
+type referenceInfo struct {
+	table  model.TableName
+	column model.Identifier
+}
+
+func (p *Provider) resolveReference(
+	ctx context.Context,
+	ds model.DatasetSchema,
+	baseType model.TargetType,
+) (referenceInfo, error) {
+	const fnName = "resolve reference"
+
+	// columns of composite keys are matched by their position
+	const query = `
+	SELECT
+		pc.owner, pc.table_name, pcc.column_name
+	FROM all_constraints c
+	JOIN all_cons_columns cc
+		ON cc.owner = c.owner AND cc.constraint_name = c.constraint_name
+	JOIN all_constraints pc
+		ON pc.owner = c.r_owner AND pc.constraint_name = c.r_constraint_name
+	JOIN all_cons_columns pcc
+		ON pcc.owner = pc.owner AND pcc.constraint_name = pc.constraint_name AND pcc.position = cc.position
+	WHERE
+		c.constraint_type = 'R'
+		AND c.owner = :1
+		AND c.table_name = :2
+		AND cc.column_name = :3
+	FETCH FIRST 1 ROWS ONLY
+	`
+
+	var (
+		schema string
+		table  string
+		column string
+	)
+
+	err := p.connect.
+		QueryRow(
+			ctx, query,
+			ds.TableName.Schema.AsArgument(),
+			ds.TableName.Table.AsArgument(),
+			baseType.SourceName.AsArgument(),
+		).
+		Scan(&schema, &table, &column)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return referenceInfo{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+		}
+
+		return referenceInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return referenceInfo{
+		table: model.TableName{
+			Schema: model.OracleIdentifier(schema),
+			Table:  model.OracleIdentifier(table),
+		},
+		column: model.OracleIdentifier(column),
+	}, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "oracle reference: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	refInfo, err := p.resolveReference(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	reader := reader.NewConnection(SampleQuery, refInfo.table, refInfo.column, 150, p.connect)
+	generator, chooseCallback := reference.NewBufferedValuesGenerator(
+		req.Dataset, reader,
+		refInfo.table, refInfo.column, p.refsvc,
+		100,
+	)
+
+	return model.AcceptanceDecision{
+		Generator:      generator,
+		ChooseCallback: chooseCallback,
+		AcceptedBy:     model.AcceptanceReasonReference,
+	}, nil
+}
+
+func SampleQuery(table model.TableName, column model.Identifier, limit int) string {
+	// SAMPLE works by blocks and may return nothing for small tables, sorting scans the whole table
+	return fmt.Sprintf(
+		`SELECT %s FROM %s ORDER BY DBMS_RANDOM.VALUE FETCH FIRST %d ROWS ONLY`,
+		column.Quoted(), table.Quoted(), limit,
+	)
+}
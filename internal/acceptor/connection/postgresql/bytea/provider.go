@@ -33,7 +33,7 @@ func (p Provider) Accept(
 
 	return model.AcceptanceDecision{
 		ChooseCallback: nil,
-		Generator:      bytea.NewAroundByteaGenerator(dfltSize, diff),
+		Generator:      bytea.NewAroundByteaGenerator(req.Rand, dfltSize, diff),
 		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
 	}, nil
 }
@@ -0,0 +1,68 @@
+package composite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/postgresql/composite"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/mo"
+)
+
+type Provider struct {
+	fieldGens contract.GeneratorRegistry
+}
+
+func NewProvider(fieldGens contract.GeneratorRegistry) *Provider {
+	return &Provider{fieldGens: fieldGens}
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "postgresql composite: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Composite {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	// attributes are described like columns of a table named after the type
+	//nolint:exhaustruct // fields have no constraints besides their own checks
+	fieldsDataset := model.DatasetSchema{
+		TableName: baseType.Composite.Name,
+		Columns:   baseType.Composite.Fields,
+	}
+
+	gens := make([]model.Generator, 0, len(baseType.Composite.Fields))
+	for _, field := range baseType.Composite.Fields {
+		gen, err := p.fieldGens.GetGenerator(ctx, contract.AcceptRequest{
+			Dataset:       fieldsDataset,
+			UserSettings:  mo.None[config.Generator](),
+			BaseType:      mo.Some(field),
+			BaseGenerator: mo.None[model.Generator](),
+			Rand:          req.Rand,
+			Now:           req.Now,
+			States:        req.States,
+		})
+		if err != nil {
+			for _, created := range gens {
+				created.Close()
+			}
+
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s %s", err, field.SourceName.AsArgument(), fnName)
+		}
+
+		gens = append(gens, gen)
+	}
+
+	return model.AcceptanceDecision{
+		Generator:      composite.NewGenerator(gens),
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		ChooseCallback: nil,
+	}, nil
+}
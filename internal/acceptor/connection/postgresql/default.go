@@ -5,12 +5,15 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/bytea"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/composite"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/enum"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/geometry"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/interval"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/json"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/network"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/numeric"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/oid"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/rangetype"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/reference"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/reuse"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql/serial"
@@ -23,7 +26,11 @@ import (
 func DefaultProviderGenerators(
 	pool *pgxpool.Pool,
 	refResolver *refresolver.Service,
+	registry contract.GeneratorRegistry,
 	setter contract.SetterOptionBasedGenerator,
+	inlineLargeObjects bool,
+	fakerByColumnName bool,
+	dryRun bool,
 ) ([]contract.GeneratorProvider, error) {
 	conn := pgx.NewAdapterPool(pool)
 
@@ -33,14 +40,17 @@ func DefaultProviderGenerators(
 
 	return []contract.GeneratorProvider{
 		numeric.NewProvider(conn),
-		serial.NewProvider(conn),
+		serial.NewProvider(conn, dryRun),
 		enum.NewProvider(conn),
 		interval.NewProvider(),
 		reference.NewProvider(conn, refResolver),
 		geometry.NewProvider(),
 		network.NewProvider(),
-		text.NewProvider(conn),
-		oid.NewProvider(pool, refResolver),
+		text.NewProvider(conn, registry, fakerByColumnName),
+		oid.NewProvider(pool, refResolver, inlineLargeObjects),
 		bytea.NewProvider(),
+		composite.NewProvider(registry),
+		rangetype.NewProvider(registry),
+		json.NewProvider(),
 	}, nil
 }
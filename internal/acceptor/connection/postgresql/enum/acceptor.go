@@ -94,7 +94,7 @@ func (p *Provider) Accept(
 	}
 
 	return model.AcceptanceDecision{
-		Generator:      oneof.NewGenerator(enums),
+		Generator:      oneof.NewGenerator(req.Rand, enums),
 		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
 		ChooseCallback: nil,
 	}, nil
@@ -41,7 +41,7 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
-	gen, err := geometry.NewGenerator(baseType.SourceType)
+	gen, err := geometry.NewGenerator(req.Rand, baseType.SourceType)
 	if err != nil {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
@@ -28,7 +28,7 @@ func (p *Provider) Accept(
 
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
-		Generator:      interval.NewPostgresql(),
+		Generator:      interval.NewPostgresql(req.Rand),
 		ChooseCallback: nil,
 	}, nil
 }
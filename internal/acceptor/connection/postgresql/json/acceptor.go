@@ -0,0 +1,80 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/jsonschema"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrAmbiguousSchema = errors.New("json schema is set both inline and by path")
+
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Accept takes json and jsonb columns, and any column the user asks json documents for.
+func (p *Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "postgresql json: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	userSettings, configured := req.UserSettings.Get()
+	configured = configured && userSettings.Type == config.GeneratorTypeJSON
+	if !configured && baseType.SourceType != "json" && baseType.SourceType != "jsonb" {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	acceptedBy := model.AcceptanceReasonDriverAwareness
+
+	var schema map[string]any
+	if configured {
+		acceptedBy = model.AcceptanceUserSettings
+
+		var err error
+		if schema, err = userSchema(userSettings.JSON); err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
+	gen, err := jsonschema.NewGenerator(req.Rand, req.Now, schema)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     acceptedBy,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
+
+func userSchema(settings *config.JSON) (map[string]any, error) {
+	switch {
+	case settings == nil:
+		return nil, nil //nolint:nilnil // no schema makes random documents
+	case settings.Schema != nil && settings.Path != "":
+		return nil, ErrAmbiguousSchema
+	case settings.Path != "":
+		schema, err := jsonschema.Load(settings.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: user schema", err)
+		}
+
+		return schema, nil
+	default:
+		return settings.Schema, nil
+	}
+}
@@ -27,7 +27,7 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
-	gen, err := network.NewGenerator(baseType.SourceType)
+	gen, err := network.NewGenerator(req.Rand, baseType.SourceType)
 	if err != nil {
 		if errors.Is(err, network.ErrUnknownNetworkType) {
 			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
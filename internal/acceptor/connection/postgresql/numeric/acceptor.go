@@ -3,12 +3,16 @@ package numeric
 import (
 	"context"
 	"fmt"
+	"math"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/generator/postgresql/numeric"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/db"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 )
 
 type Provider struct {
@@ -33,6 +37,7 @@ func (p *Provider) getNumericTemplate(
 ) (numericTemplate, error) {
 	const fnName = "get numeric template"
 
+	// attributes of composite types are described like columns of a table named after the type
 	const query = `
 		SELECT 
 			numeric_precision, numeric_scale
@@ -40,6 +45,13 @@ func (p *Provider) getNumericTemplate(
 			information_schema.columns
 		WHERE
 			table_schema = $1 AND table_name = $2 AND column_name = $3
+		UNION ALL
+		SELECT
+			numeric_precision, numeric_scale
+		FROM
+			information_schema.attributes
+		WHERE
+			udt_schema = $1 AND udt_name = $2 AND attribute_name = $3
 		`
 	tableName := dataset.TableName
 
@@ -75,9 +87,45 @@ func (p *Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
 
+	gen := numeric.NewPostgresqlNumericGenerator(req.Rand, template.scale, template.precision)
+	if baseType.Check != nil {
+		gen, err = checkedGenerator(req, baseType.Check, template)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
-		Generator:      numeric.NewPostgresqlNumericGenerator(template.scale, template.precision),
+		Generator:      gen,
 		ChooseCallback: nil,
 	}, nil
 }
+
+// checkedGenerator keeps values inside the precision, unconstrained numerics are bounded by checkedBound.
+func checkedGenerator(req contract.AcceptRequest, c *model.ColumnCheck, template numericTemplate) (model.Generator, error) {
+	const checkedBound = 1e9
+
+	values, ok, err := check.Values(c, decimal.NewFromString)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checked generator", err)
+	}
+
+	if ok {
+		return oneof.NewGenerator(req.Rand, values), nil
+	}
+
+	lower, upper := check.Around(c, checkedBound)
+	scale := -1
+	if template.precision > 0 {
+		upper = math.Pow10(template.precision-template.scale) - math.Pow10(-template.scale)
+		lower, scale = -upper, template.scale
+	}
+
+	minV, maxV, err := check.FloatRange(c, lower, upper)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checked generator", err)
+	}
+
+	return numeric.NewInRangeGenerator(req.Rand, minV, maxV, scale), nil
+}
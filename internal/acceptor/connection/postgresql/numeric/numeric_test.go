@@ -11,6 +11,7 @@ import (
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/db/adapter/pgx"
 	testpg "github.com/jmozgit/datagen/internal/pkg/testconn/postgres"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 
 	"github.com/samber/mo"
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,7 @@ func Test_PositiveScale(t *testing.T) {
 						UniqueConstraints: nil,
 					},
 					UserSettings: mo.None[config.Generator](),
+					Rand:         xrand.Unseeded(),
 					//nolint:exhaustruct // ok
 					BaseType: mo.Some(model.TargetType{
 						SourceName: model.PGIdentifier("gen_col"),
@@ -90,6 +92,7 @@ func Test_PositiveScale(t *testing.T) {
 						UniqueConstraints: nil,
 					},
 					UserSettings: mo.None[config.Generator](),
+					Rand:         xrand.Unseeded(),
 					//nolint:exhaustruct // ok
 					BaseType: mo.Some(model.TargetType{
 						SourceName: model.PGIdentifier("rev_gen_col"),
@@ -144,6 +147,7 @@ func Test_NegativeScale(t *testing.T) {
 						UniqueConstraints: nil,
 					},
 					UserSettings: mo.None[config.Generator](),
+					Rand:         xrand.Unseeded(),
 					//nolint:exhaustruct // ok
 					BaseType: mo.Some(model.TargetType{SourceName: model.PGIdentifier("col"), Type: model.Float, SourceType: "numeric"}),
 				},
@@ -15,15 +15,19 @@ import (
 type Provider struct {
 	pool     *pgxpool.Pool
 	resolver model.ReferenceResolver
+	inline   bool
 }
 
+// NewProvider with inline set generates large object contents instead of creating them in the database.
 func NewProvider(
 	pool *pgxpool.Pool,
 	resolver model.ReferenceResolver,
+	inline bool,
 ) Provider {
 	return Provider{
 		pool:     pool,
 		resolver: resolver,
+		inline:   inline,
 	}
 }
 
@@ -49,7 +53,16 @@ func (s Provider) Accept(
 		}
 	}
 
+	if s.inline {
+		return model.AcceptanceDecision{
+			Generator:      oid.NewInlineGenerator(req.Rand, int64(defaultSize), int64(rangeValue)),
+			AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+			ChooseCallback: nil,
+		}, nil
+	}
+
 	loGen, choose := oid.NewApproximatelySizedGenerator(
+		req.Rand,
 		s.pool,
 		int64(defaultSize),
 		int64(rangeValue),
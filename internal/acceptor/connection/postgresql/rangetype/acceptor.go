@@ -0,0 +1,247 @@
+package rangetype
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/postgresql/rangetype"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+var (
+	ErrInvalidBounds        = errors.New("range bounds must be one of [), [], (], ()")
+	ErrInvalidWidth         = errors.New("invalid range width")
+	ErrInvalidEmptyFraction = errors.New("range empty fraction must be within [0, 100]")
+)
+
+// numericBound keeps numeric ranges finite, numeric has no infinity to draw bounds from.
+const numericBound = "1000000"
+
+type subtype struct {
+	elem         model.TargetType
+	newGenerator func(rnd *rand.Rand, gen model.Generator, opts rangetype.Options) model.Generator
+	parseWidth   func(s string) (float64, error)
+}
+
+func newSubtype[T any](tp model.CommonType, sourceType string, size int, arithmetic rangetype.Subtype[T]) subtype {
+	parseWidth := parseDuration
+	switch tp {
+	case model.Integer:
+		parseWidth = parseInteger
+	case model.Float:
+		parseWidth = parseFloat
+	case model.Date:
+		parseWidth = parseDays
+	default:
+	}
+
+	var check *model.ColumnCheck
+	if tp == model.Float {
+		check = &model.ColumnCheck{
+			Min:       &model.CheckBound{Value: "-" + numericBound, Inclusive: true},
+			Max:       &model.CheckBound{Value: numericBound, Inclusive: true},
+			In:        nil,
+			MinLength: nil,
+			MaxLength: nil,
+		}
+	}
+
+	//nolint:exhaustruct // only the type matters for the bound generator
+	return subtype{
+		elem: model.TargetType{Type: tp, SourceType: sourceType, FixedSize: size, Check: check},
+		newGenerator: func(rnd *rand.Rand, gen model.Generator, opts rangetype.Options) model.Generator {
+			return rangetype.NewGenerator(rnd, gen, arithmetic, opts)
+		},
+		parseWidth: parseWidth,
+	}
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var subtypes = map[string]subtype{
+	"int4range": newSubtype(model.Integer, "int4", 4, rangetype.NewIntegerSubtype(math.MinInt32, math.MaxInt32)),
+	"int8range": newSubtype(model.Integer, "int8", 8, rangetype.NewIntegerSubtype(math.MinInt64, math.MaxInt64)),
+	"numrange":  newSubtype(model.Float, "float8", 8, rangetype.NewFloatSubtype()),
+	"tsrange":   newSubtype(model.Timestamp, "timestamp", 8, rangetype.NewTimeSubtype(false)),
+	"tstzrange": newSubtype(model.Timestamp, "timestamptz", 8, rangetype.NewTimeSubtype(false)),
+	"daterange": newSubtype(model.Date, "date", 4, rangetype.NewTimeSubtype(true)),
+}
+
+// multiranges maps multirange types to the types of their ranges.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var multiranges = map[string]string{
+	"int4multirange": "int4range",
+	"int8multirange": "int8range",
+	"nummultirange":  "numrange",
+	"tsmultirange":   "tsrange",
+	"tstzmultirange": "tstzrange",
+	"datemultirange": "daterange",
+}
+
+type Provider struct {
+	boundGens contract.GeneratorRegistry
+}
+
+func NewProvider(boundGens contract.GeneratorRegistry) *Provider {
+	return &Provider{boundGens: boundGens}
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "postgresql range: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	rangeType, multi := multiranges[baseType.SourceType]
+	if !multi {
+		rangeType = baseType.SourceType
+	}
+
+	sub, ok := subtypes[rangeType]
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	acceptedBy := model.AcceptanceReasonDriverAwareness
+	settings := config.Range{Bounds: "", EmptyFraction: 0, MinWidth: "", MaxWidth: ""}
+	if userSettings, ok := req.UserSettings.Get(); ok && userSettings.Type == config.GeneratorTypeRange {
+		acceptedBy = model.AcceptanceUserSettings
+		settings = lo.FromPtrOr(userSettings.Range, settings)
+	}
+
+	opts, err := options(settings, sub)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+	opts.Multi = multi
+
+	gen, err := p.boundGens.GetGenerator(ctx, contract.AcceptRequest{
+		Dataset:       req.Dataset,
+		UserSettings:  mo.None[config.Generator](),
+		BaseType:      mo.Some(sub.elem),
+		BaseGenerator: mo.None[model.Generator](),
+		Rand:          req.Rand,
+		Now:           req.Now,
+		States:        req.States,
+	})
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     acceptedBy,
+		Generator:      sub.newGenerator(req.Rand, gen, opts),
+		ChooseCallback: nil,
+	}, nil
+}
+
+func options(settings config.Range, sub subtype) (rangetype.Options, error) {
+	const fnName = "range options"
+
+	lowerType, upperType, err := bounds(settings.Bounds)
+	if err != nil {
+		return rangetype.Options{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if settings.EmptyFraction < 0 || settings.EmptyFraction > 100 {
+		return rangetype.Options{}, fmt.Errorf("%w: %d %s", ErrInvalidEmptyFraction, settings.EmptyFraction, fnName)
+	}
+
+	var minWidth, maxWidth float64
+	if settings.MinWidth != "" {
+		if minWidth, err = sub.parseWidth(settings.MinWidth); err != nil {
+			return rangetype.Options{}, fmt.Errorf("%w: min width %s", err, fnName)
+		}
+	}
+	if settings.MaxWidth != "" {
+		if maxWidth, err = sub.parseWidth(settings.MaxWidth); err != nil {
+			return rangetype.Options{}, fmt.Errorf("%w: max width %s", err, fnName)
+		}
+	}
+
+	if minWidth < 0 || (settings.MaxWidth != "" && maxWidth < minWidth) {
+		return rangetype.Options{}, fmt.Errorf(
+			"%w: [%s, %s] %s", ErrInvalidWidth, settings.MinWidth, settings.MaxWidth, fnName,
+		)
+	}
+
+	return rangetype.Options{
+		LowerType:     lowerType,
+		UpperType:     upperType,
+		EmptyFraction: settings.EmptyFraction,
+		MinWidth:      minWidth,
+		MaxWidth:      maxWidth,
+		Multi:         false,
+	}, nil
+}
+
+// bounds parses the inclusivity of range bounds, ranges are [) like postgresql makes discrete ones by default.
+func bounds(s string) (pgtype.BoundType, pgtype.BoundType, error) {
+	switch s {
+	case "", "[)":
+		return pgtype.Inclusive, pgtype.Exclusive, nil
+	case "[]":
+		return pgtype.Inclusive, pgtype.Inclusive, nil
+	case "(]":
+		return pgtype.Exclusive, pgtype.Inclusive, nil
+	case "()":
+		return pgtype.Exclusive, pgtype.Exclusive, nil
+	default:
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidBounds, s)
+	}
+}
+
+func parseInteger(s string) (float64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidWidth, err)
+	}
+
+	return float64(v), nil
+}
+
+func parseFloat(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidWidth, s)
+	}
+
+	return v, nil
+}
+
+func parseDuration(s string) (float64, error) {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidWidth, err)
+	}
+
+	return float64(v), nil
+}
+
+// parseDays rounds durations up to whole days, dates don't have a finer step.
+func parseDays(s string) (float64, error) {
+	v, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	const day = float64(24 * time.Hour)
+
+	return math.Ceil(v/day) * day, nil
+}
@@ -49,7 +49,7 @@ func (p *Provider) Accept(
 		baseType.SourceName, 150, p.connect,
 	)
 
-	gen, err := reuse.NewGenerator(ctx, reader, settings.ReuseFraction, baseGen)
+	gen, err := reuse.NewGenerator(ctx, req.Rand, reader, settings.ReuseFraction, baseGen)
 	if err != nil {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
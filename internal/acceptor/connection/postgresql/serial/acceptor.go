@@ -13,11 +13,13 @@ import (
 )
 
 type Provider struct {
-	conn db.Connect
+	conn   db.Connect
+	dryRun bool
 }
 
-func NewProvider(conn db.Connect) *Provider {
-	return &Provider{conn: conn}
+// NewProvider with dryRun set counts from the sequence instead of taking its values.
+func NewProvider(conn db.Connect, dryRun bool) *Provider {
+	return &Provider{conn: conn, dryRun: dryRun}
 }
 
 func (s *Provider) getSeqName(
@@ -78,9 +80,14 @@ func (s *Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
 
+	gen := serial.NewSeqBasedGenerator(s.conn, seqName)
+	if s.dryRun {
+		gen = serial.NewSeqPeekGenerator(s.conn, seqName)
+	}
+
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
-		Generator:      serial.NewSeqBasedGenerator(s.conn, seqName),
+		Generator:      gen,
 		ChooseCallback: nil,
 	}, nil
 }
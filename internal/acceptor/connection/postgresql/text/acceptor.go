@@ -6,18 +6,25 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/jmozgit/datagen/internal/acceptor/check"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/acceptor/user/sized"
+	"github.com/jmozgit/datagen/internal/acceptor/user/template"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
 	"github.com/jmozgit/datagen/internal/generator/text"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/db"
 )
 
 type Provider struct {
-	conn db.Connect
+	conn              db.Connect
+	templates         *template.Provider
+	fakerByColumnName bool
 }
 
-func NewProvider(conn db.Connect) *Provider {
-	return &Provider{conn: conn}
+func NewProvider(conn db.Connect, registry contract.GeneratorRegistry, fakerByColumnName bool) *Provider {
+	return &Provider{conn: conn, templates: template.NewProvider(registry), fakerByColumnName: fakerByColumnName}
 }
 
 func (s *Provider) getTextSize(
@@ -29,6 +36,7 @@ func (s *Provider) getTextSize(
 
 	tableName := dataset.TableName
 
+	// attributes of composite types are described like columns of a table named after the type
 	const query = `
 	SELECT
     	character_maximum_length
@@ -36,6 +44,13 @@ func (s *Provider) getTextSize(
 	WHERE s.table_schema = $1
   		AND s.table_name = $2
   		AND s.column_name = $3
+	UNION ALL
+	SELECT
+		character_maximum_length
+	FROM information_schema.attributes a
+	WHERE a.udt_schema = $1
+		AND a.udt_name = $2
+		AND a.attribute_name = $3
 	`
 
 	var size sql.NullInt64
@@ -64,6 +79,20 @@ func (s *Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
+	if sized.Requested(req) {
+		size, err := s.getTextSize(ctx, req.Dataset, baseType)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		decision, err := sized.Accept(ctx, s.templates, req, int(size))
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return decision, nil
+	}
+
 	if !slices.Contains([]string{"bpchar", "varchar"}, baseType.SourceType) {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
@@ -73,11 +102,11 @@ func (s *Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
 
-	var gen model.Generator
-	if size == 0 {
-		gen = text.NewInRangeSizeGenerator(20, 120)
-	} else {
-		gen = text.NewFixedSizedStringGenerator(int(size))
+	gen, ok := s.fakerGenerator(req, baseType, int(size))
+	if !ok {
+		if gen, err = sizedGenerator(req, baseType.Check, int(size)); err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
 	}
 
 	return model.AcceptanceDecision{
@@ -86,3 +115,46 @@ func (s *Provider) Accept(
 		ChooseCallback: nil,
 	}, nil
 }
+
+// fakerGenerator makes realistic values cut to size for unconstrained columns with usual names.
+func (s *Provider) fakerGenerator(
+	req contract.AcceptRequest,
+	baseType model.TargetType,
+	size int,
+) (model.Generator, bool) {
+	if !s.fakerByColumnName || baseType.Check != nil {
+		return nil, false
+	}
+
+	return faker.ByColumnName(req.Rand, req.Now, baseType.SourceName.AsArgument(), size)
+}
+
+func sizedGenerator(req contract.AcceptRequest, c *model.ColumnCheck, size int) (model.Generator, error) {
+	if values, ok, _ := check.Values(c, check.ParseText); ok {
+		return oneof.NewGenerator(req.Rand, values), nil
+	}
+
+	if size == 0 {
+		from, to, err := check.LengthRange(c, 20, 120)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sized generator", err)
+		}
+
+		return text.NewInRangeSizeGenerator(req.Rand, from, to), nil
+	}
+
+	from, to, err := check.LengthRange(c, size, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sized generator", err)
+	}
+
+	if to > size {
+		return nil, fmt.Errorf("%w: longer than %d sized generator", check.ErrUnsatisfiable, size)
+	}
+
+	if from == to {
+		return text.NewFixedSizedStringGenerator(req.Rand, from), nil
+	}
+
+	return text.NewInRangeSizeGenerator(req.Rand, from, to), nil
+}
@@ -0,0 +1,56 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// SampleQuery selects up to limit random values of the column, every dialect samples its own way.
+type SampleQuery func(table model.TableName, column model.Identifier, limit int) string
+
+type Connection struct {
+	query string
+	db    db.Connect
+}
+
+func NewConnection(
+	sample SampleQuery,
+	tableName model.TableName,
+	column model.Identifier,
+	limit int,
+	db db.Connect,
+) *Connection {
+	return &Connection{
+		query: sample(tableName, column, limit),
+		db:    db,
+	}
+}
+
+func (c *Connection) ReadValues(ctx context.Context) ([]any, error) {
+	const fnName = "read values"
+
+	rows, err := c.db.Query(ctx, c.query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	values := make([]any, 0)
+	for rows.Next() {
+		var val any
+		if err := rows.Scan(&val); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		values = append(values, val)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,39 @@
+package binary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/bytea"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// generatedSize is the average size of generated blobs, sqlite has no limit to follow.
+const generatedSize = datasize.KB
+
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+func (p *Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite binary: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.SourceType != "blob" {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		ChooseCallback: nil,
+		Generator:      bytea.NewAroundByteaGenerator(req.Rand, generatedSize, generatedSize/2), //nolint:mnd // half
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package boolean
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/boolean"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+const truePercent = 50
+
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+func (p *Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite boolean: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || !slices.Contains([]string{"boolean", "bool"}, baseType.SourceType) {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		ChooseCallback: nil,
+		Generator:      boolean.NewBoolean(req.Rand, truePercent),
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reuse"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/binary"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/boolean"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/float"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/integer"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/reference"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/text"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/refresolver"
+)
+
+func DefaultProviderGenerators(
+	conn db.Connect,
+	refResolver *refresolver.Service,
+	registry contract.GeneratorRegistry,
+	setter contract.SetterOptionBasedGenerator,
+	fakerByColumnName bool,
+) ([]contract.GeneratorProvider, error) {
+	if err := setter.SetReuseValuesGeneratorProvider(reuse.NewProvider(conn, reference.SampleQuery)); err != nil {
+		return nil, fmt.Errorf("%w: sqlite default provider generator", err)
+	}
+
+	return []contract.GeneratorProvider{
+		integer.NewProvider(conn),
+		float.NewProvider(),
+		text.NewProvider(conn, registry, fakerByColumnName),
+		binary.NewProvider(),
+		boolean.NewProvider(),
+		reference.NewProvider(conn, refResolver),
+	}, nil
+}
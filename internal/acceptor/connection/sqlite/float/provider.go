@@ -0,0 +1,37 @@
+package float
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/float"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// floatBound keeps generated floats readable, sqlite stores NaN as NULL.
+const floatBound = 1e9
+
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+func (p *Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite float: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Float {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      float.NewInRangeGenerator(req.Rand, -floatBound, floatBound),
+		ChooseCallback: nil,
+	}, nil
+}
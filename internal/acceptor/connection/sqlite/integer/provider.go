@@ -0,0 +1,103 @@
+package integer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// Provider takes over the rowid alias only, other integer columns are left to common types.
+type Provider struct {
+	conn db.Connect
+}
+
+func NewProvider(conn db.Connect) *Provider {
+	return &Provider{conn: conn}
+}
+
+func (p *Provider) isRowID(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (bool, error) {
+	const fnName = "is rowid"
+
+	const query = `
+	SELECT
+		COUNT(*), COALESCE(MAX(CASE WHEN name = ? THEN type END), '')
+	FROM pragma_table_info(?, ?)
+	WHERE pk > 0
+	`
+
+	var (
+		pkColumns  int
+		columnType string
+	)
+	if err := p.conn.QueryRow(
+		ctx, query,
+		baseType.SourceName.AsArgument(),
+		dataset.TableName.Table.AsArgument(), dataset.TableName.Schema.AsArgument(),
+	).Scan(&pkColumns, &columnType); err != nil {
+		return false, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return pkColumns == 1 && strings.EqualFold(columnType, "integer"), nil
+}
+
+func (p *Provider) nextRowID(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (int64, error) {
+	query := fmt.Sprintf(
+		"SELECT COALESCE(MAX(%s), 0) + 1 FROM %s",
+		baseType.SourceName.Quoted(), dataset.TableName.Quoted(),
+	)
+
+	var next int64
+	if err := p.conn.QueryRow(ctx, query).Scan(&next); err != nil {
+		return 0, fmt.Errorf("%w: next rowid", err)
+	}
+
+	return next, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite integer: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Integer {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	rowID, err := p.isRowID(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if !rowID {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	// rowid aliases continue after the largest one, inserting them keeps them known to referencing tables
+	next, err := p.nextRowID(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	gen := integer.NewSerialIntegerGenerator(next)
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: req.KeepState(gen),
+	}, nil
+}
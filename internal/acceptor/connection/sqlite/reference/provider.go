@@ -0,0 +1,126 @@
+package reference
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqldb/reader"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/generator/reference"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+type Provider struct {
+	connect db.Connect
+	refsvc  model.ReferenceResolver
+}
+
+func NewProvider(
+	connect db.Connect,
+	refsvc model.ReferenceResolver,
+) *Provider {
+	return &Provider{
+		connect: connect,
+		refsvc:  refsvc,
+	}
+}
+
+type referenceInfo struct {
+	table  model.TableName
+	column model.Identifier
+}
+
+func (p *Provider) resolveReference(
+	ctx context.Context,
+	ds model.DatasetSchema,
+	baseType model.TargetType,
+) (referenceInfo, error) {
+	const fnName = "resolve reference"
+
+	// a foreign key without columns points to the primary key of the parent table,
+	// the parent always lives in the same schema
+	const query = `
+	SELECT
+		fk."table",
+		COALESCE(fk."to", (SELECT name FROM pragma_table_info(fk."table", ?) WHERE pk = fk.seq + 1))
+	FROM pragma_foreign_key_list(?, ?) fk
+	WHERE fk."from" = ?
+	LIMIT 1
+	`
+
+	var (
+		table  string
+		column sql.NullString
+	)
+
+	err := p.connect.
+		QueryRow(
+			ctx, query,
+			ds.TableName.Schema.AsArgument(),
+			ds.TableName.Table.AsArgument(),
+			ds.TableName.Schema.AsArgument(),
+			baseType.SourceName.AsArgument(),
+		).
+		Scan(&table, &column)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return referenceInfo{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+		}
+
+		return referenceInfo{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// the parent might reference its rowid implicitly, it can't be resolved to a column
+	if !column.Valid {
+		return referenceInfo{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	return referenceInfo{
+		table: model.TableName{
+			Schema: ds.TableName.Schema,
+			Table:  model.SQLiteIdentifier(table),
+		},
+		column: model.SQLiteIdentifier(column.String),
+	}, nil
+}
+
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite reference: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	refInfo, err := p.resolveReference(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	reader := reader.NewConnection(SampleQuery, refInfo.table, refInfo.column, 150, p.connect)
+	generator, chooseCallback := reference.NewBufferedValuesGenerator(
+		req.Dataset, reader,
+		refInfo.table, refInfo.column, p.refsvc,
+		100,
+	)
+
+	return model.AcceptanceDecision{
+		Generator:      generator,
+		ChooseCallback: chooseCallback,
+		AcceptedBy:     model.AcceptanceReasonReference,
+	}, nil
+}
+
+func SampleQuery(table model.TableName, column model.Identifier, limit int) string {
+	// sqlite has no TABLESAMPLE, sorting by RANDOM() scans the whole table
+	return fmt.Sprintf(
+		`SELECT %s FROM %s ORDER BY RANDOM() LIMIT %d`,
+		column.Quoted(), table.Quoted(), limit,
+	)
+}
@@ -0,0 +1,115 @@
+package text
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/acceptor/user/sized"
+	"github.com/jmozgit/datagen/internal/acceptor/user/template"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/generator/text"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// maxGeneratedLen keeps fixtures readable, sqlite doesn't enforce declared lengths at all.
+const maxGeneratedLen = 255
+
+type Provider struct {
+	conn              db.Connect
+	templates         *template.Provider
+	fakerByColumnName bool
+}
+
+func NewProvider(conn db.Connect, registry contract.GeneratorRegistry, fakerByColumnName bool) *Provider {
+	return &Provider{conn: conn, templates: template.NewProvider(registry), fakerByColumnName: fakerByColumnName}
+}
+
+func (s *Provider) getTextSize(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	baseType model.TargetType,
+) (int, bool, error) {
+	const fnName = "get text size"
+
+	const query = `SELECT type FROM pragma_table_info(?, ?) WHERE name = ?`
+
+	var declared string
+	if err := s.conn.QueryRow(
+		ctx, query,
+		dataset.TableName.Table.AsArgument(), dataset.TableName.Schema.AsArgument(),
+		baseType.SourceName.AsArgument(),
+	).Scan(&declared); err != nil {
+		return 0, false, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	size, ok := ParseLength(declared)
+
+	return size, ok, nil
+}
+
+// ParseLength reads n out of a declared type like VARCHAR(n).
+func ParseLength(declared string) (int, bool) {
+	_, modifier, ok := strings.Cut(declared, "(")
+	if !ok {
+		return 0, false
+	}
+
+	modifier, ok = strings.CutSuffix(strings.TrimSpace(modifier), ")")
+	if !ok {
+		return 0, false
+	}
+
+	size, err := strconv.Atoi(strings.TrimSpace(modifier))
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+
+	return size, true
+}
+
+func (s *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "sqlite text: accept"
+
+	baseType, ok := req.BaseType.Get()
+	if !ok || baseType.Type != model.Text {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	size, ok, err := s.getTextSize(ctx, req.Dataset, baseType)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if sized.Requested(req) {
+		decision, err := sized.Accept(ctx, s.templates, req, size)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return decision, nil
+	}
+
+	if !ok {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	gen := text.NewFixedSizedStringGenerator(req.Rand, min(size, maxGeneratedLen))
+	if s.fakerByColumnName {
+		if fake, ok := faker.ByColumnName(req.Rand, req.Now, baseType.SourceName.AsArgument(), size); ok {
+			gen = fake
+		}
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceReasonDriverAwareness,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite/text"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLength(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		declared string
+		size     int
+		ok       bool
+	}{
+		{declared: "VARCHAR(20)", size: 20, ok: true},
+		{declared: "nchar( 5 )", size: 5, ok: true},
+		{declared: "TEXT", size: 0, ok: false},
+		{declared: "varchar(abc)", size: 0, ok: false},
+		{declared: "varchar(0)", size: 0, ok: false},
+		{declared: "varchar(10", size: 0, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.declared, func(t *testing.T) {
+			t.Parallel()
+
+			size, ok := text.ParseLength(tc.declared)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.size, size)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package contract
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
+	"time"
 
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/model"
@@ -38,4 +40,20 @@ type AcceptRequest struct {
 	UserSettings  mo.Option[config.Generator]
 	BaseType      mo.Option[model.TargetType]
 	BaseGenerator mo.Option[model.Generator]
+	// Rand is the column's own random source, generators must not use any other.
+	Rand *rand.Rand
+	// Now is the reference time of the run, default time ranges are around it instead of the clock
+	// for seeded runs to repeat.
+	Now time.Time
+	// States collects states of the generators made for the column, nil if nobody keeps them.
+	States *[]model.GeneratorState
+}
+
+// KeepState is a choose callback adding the states to the request ones.
+func (r AcceptRequest) KeepState(states ...model.GeneratorState) model.ChooseCallback {
+	return func() {
+		if r.States != nil {
+			*r.States = append(*r.States, states...)
+		}
+	}
 }
@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// Candidate is a provider which accepted a column.
+type Candidate struct {
+	Provider string
+	Reason   model.AcceptanceReason
+}
+
+// Choice tells how the generator of a column was picked, Winner is empty if none was.
+// Depth is above zero for generators asked for by other providers, like the ones of array elements.
+type Choice struct {
+	Table         model.TableName
+	Column        model.TargetType
+	Depth         int
+	Candidates    []Candidate
+	Winner        string
+	NullFraction  int
+	ReuseFraction int
+	Err           error
+}
+
+// Record makes the registry keep a choice for every generator it gives.
+func (r *Acceptors) Record() {
+	r.recording = true
+}
+
+// Choices are in the order lookups started, nested ones follow the choice they belong to.
+func (r *Acceptors) Choices() []Choice {
+	return r.choices
+}
+
+func (r *Acceptors) recordOptions(table model.TableName, column model.Identifier, nullFraction, reuseFraction int) {
+	for i := len(r.choices) - 1; i >= 0; i-- {
+		c := &r.choices[i]
+		if c.Depth == 0 && c.Table == table && c.Column.SourceName == column {
+			c.NullFraction, c.ReuseFraction = nullFraction, reuseFraction
+
+			return
+		}
+	}
+}
+
+// providerName is the package of the provider under acceptor and its type, like user/integer.Provider.
+func providerName(provider any) string {
+	tp := reflect.TypeOf(provider)
+	for tp.Kind() == reflect.Pointer {
+		tp = tp.Elem()
+	}
+
+	const base = "/internal/acceptor/"
+
+	pkg := tp.PkgPath()
+	if idx := strings.Index(pkg, base); idx != -1 {
+		pkg = pkg[idx+len(base):]
+	}
+
+	return pkg + "." + tp.Name()
+}
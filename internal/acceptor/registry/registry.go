@@ -2,19 +2,30 @@ package registry
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jmozgit/datagen/internal/acceptor/commontype"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/mysql"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/oracle"
 	"github.com/jmozgit/datagen/internal/acceptor/connection/postgresql"
+	"github.com/jmozgit/datagen/internal/acceptor/connection/sqlite"
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/acceptor/user"
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/closer"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/goora"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/stdsql"
 	"github.com/jmozgit/datagen/internal/refresolver"
+	"github.com/samber/lo"
 	"github.com/samber/mo"
+
+	_ "github.com/go-sql-driver/mysql" // register driver
+	_ "modernc.org/sqlite"             // register driver
 )
 
 type Acceptors struct {
@@ -23,6 +34,26 @@ type Acceptors struct {
 	// options based generator provider
 	withNullGeneratorProvider   contract.GeneratorProvider
 	reuseValueGeneratorProvider contract.GeneratorProvider
+
+	// choices are kept while recording, depth tells lookups of nested generators apart
+	recording bool
+	depth     int
+	choices   []Choice
+}
+
+// Option changes how PrepareAcceptors picks generators.
+type Option func(opts *options)
+
+type options struct {
+	dryRun bool
+}
+
+// WithDryRun picks generators which leave the database as it is:
+// large objects are generated inline and sequences are counted from instead of taken.
+func WithDryRun() Option {
+	return func(opts *options) {
+		opts.dryRun = true
+	}
 }
 
 func PrepareAcceptors(
@@ -30,10 +61,16 @@ func PrepareAcceptors(
 	cfg config.Config,
 	refRegistry *refresolver.Service,
 	closerReg *closer.Registry,
+	opts ...Option,
 ) (*Acceptors, error) {
 	self := &Acceptors{}
 
-	commonGens, err := commontype.DefaultProviderGenerators(self, self)
+	var prepareOpts options
+	for _, opt := range opts {
+		opt(&prepareOpts)
+	}
+
+	commonGens, err := commontype.DefaultProviderGenerators(self, self, cfg.Options.FakerByColumnName)
 	if err != nil {
 		return nil, fmt.Errorf("%w: prepare acceptors", err)
 	}
@@ -51,14 +88,63 @@ func PrepareAcceptors(
 		}
 		closerReg.Add(closer.Fn(pool.Close))
 
+		// output files can't refer to large objects of this database, they have to carry their content
+		inlineLargeObjects := cfg.Output != nil || prepareOpts.dryRun
 		pgGens, err := postgresql.DefaultProviderGenerators(
-			pool, refRegistry, self,
+			pool, refRegistry, self, self, inlineLargeObjects, cfg.Options.FakerByColumnName, prepareOpts.dryRun,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%w: prepare acceptors", err)
 		}
 
 		generators = append(generators, pgGens...)
+	case config.MySQLConnection:
+		sqlDB, err := sql.Open("mysql", cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare registry", err)
+		}
+		conn := stdsql.NewAdapterDB(sqlDB)
+		closerReg.Add(conn)
+
+		mysqlGens, err := mysql.DefaultProviderGenerators(
+			conn, refRegistry, self, self, cfg.Options.FakerByColumnName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare acceptors", err)
+		}
+
+		generators = append(generators, mysqlGens...)
+	case config.SQLiteConnection:
+		sqlDB, err := sql.Open("sqlite", cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare registry", err)
+		}
+		conn := stdsql.NewAdapterDB(sqlDB)
+		closerReg.Add(conn)
+
+		sqliteGens, err := sqlite.DefaultProviderGenerators(
+			conn, refRegistry, self, self, cfg.Options.FakerByColumnName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare acceptors", err)
+		}
+
+		generators = append(generators, sqliteGens...)
+	case config.OracleConnection:
+		conn, err := goora.NewAdapterPool(cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare registry", err)
+		}
+		closerReg.Add(conn)
+
+		oracleGens, err := oracle.DefaultProviderGenerators(
+			conn, refRegistry, self, self, cfg.Options.FakerByColumnName, prepareOpts.dryRun,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: prepare acceptors", err)
+		}
+
+		generators = append(generators, oracleGens...)
 	default:
 	}
 
@@ -99,11 +185,46 @@ func (r *Acceptors) GetGenerator(
 ) (model.Generator, error) {
 	const fnName = "get generator"
 
-	matched := make(map[model.AcceptanceReason][]model.AcceptanceDecision)
+	//nolint:exhaustruct // filled while choosing
+	choice := Choice{Table: req.Dataset.TableName, Column: req.BaseType.OrEmpty(), Depth: r.depth}
+	idx := len(r.choices)
+	if r.recording {
+		r.choices = append(r.choices, choice)
+	}
+
+	r.depth++
+	gen, err := r.choose(ctx, req, &choice)
+	r.depth--
+
+	if r.recording {
+		choice.Err = err
+		r.choices[idx] = choice
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return gen, nil
+}
+
+func (r *Acceptors) choose(
+	ctx context.Context,
+	req contract.AcceptRequest,
+	choice *Choice,
+) (model.Generator, error) {
+	type accepted struct {
+		decision model.AcceptanceDecision
+		provider string
+	}
+
+	matched := make(map[model.AcceptanceReason][]accepted)
 	for _, provider := range r.providers {
 		decision, err := provider.Accept(ctx, req)
 		if err == nil {
-			matched[decision.AcceptedBy] = append(matched[decision.AcceptedBy], decision)
+			name := providerName(provider)
+			matched[decision.AcceptedBy] = append(matched[decision.AcceptedBy], accepted{decision, name})
+			choice.Candidates = append(choice.Candidates, Candidate{Provider: name, Reason: decision.AcceptedBy})
 
 			continue
 		}
@@ -112,9 +233,10 @@ func (r *Acceptors) GetGenerator(
 			continue
 		}
 
-		return nil, fmt.Errorf("%w: %s", err, fnName)
+		return nil, fmt.Errorf("%w: by %s", err, providerName(provider))
 	}
 
+	// references win over driver aware generators which know the column type too
 	priority := []model.AcceptanceReason{
 		model.AcceptanceUserSettings,
 		model.AcceptanceReasonReference,
@@ -125,19 +247,26 @@ func (r *Acceptors) GetGenerator(
 	for _, reason := range priority {
 		if decisions, ok := matched[reason]; ok {
 			if len(decisions) > 1 {
-				return nil, fmt.Errorf("%w: %s", contract.ErrTooManyGeneratorsAvailable, fnName)
+				names := lo.Map(decisions, func(a accepted, _ int) string { return a.provider })
+
+				return nil, fmt.Errorf(
+					"%w: %s all accept by %s", contract.ErrTooManyGeneratorsAvailable, strings.Join(names, ", "), reason,
+				)
 			}
 
 			d := decisions[0]
-			if d.ChooseCallback != nil {
-				d.ChooseCallback()
+			if d.decision.ChooseCallback != nil {
+				d.decision.ChooseCallback()
 			}
+			choice.Winner = d.provider
 
-			return d.Generator, nil
+			return d.decision.Generator, nil
 		}
 	}
 
-	return nil, fmt.Errorf("%w: %s", contract.ErrNoAvailableGenerators, fnName)
+	return nil, fmt.Errorf(
+		"%w: %s column of type %s", contract.ErrNoAvailableGenerators, choice.Column.Type, choice.Column.SourceType,
+	)
 }
 
 func (r *Acceptors) ApplyOptions(
@@ -156,6 +285,11 @@ func (r *Acceptors) ApplyOptions(
 		return baseGen, nil
 	}
 
+	if r.recording {
+		column := req.BaseType.OrEmpty().SourceName
+		r.recordOptions(req.Dataset.TableName, column, settings.NullFraction, settings.ReuseFraction)
+	}
+
 	if settings.ReuseFraction != 0 {
 		decision, err := r.reuseValueGeneratorProvider.Accept(ctx, req)
 		if err != nil {
@@ -39,6 +39,6 @@ func (p Provider) Accept(
 	return model.AcceptanceDecision{
 		ChooseCallback: nil,
 		AcceptedBy:     model.AcceptanceUserSettings,
-		Generator:      bytea.NewAroundByteaGenerator(dfltSize, diff),
+		Generator:      bytea.NewAroundByteaGenerator(req.Rand, dfltSize, diff),
 	}, nil
 }
@@ -4,10 +4,14 @@ import (
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/acceptor/user/array"
 	"github.com/jmozgit/datagen/internal/acceptor/user/bytea"
+	"github.com/jmozgit/datagen/internal/acceptor/user/derived"
+	"github.com/jmozgit/datagen/internal/acceptor/user/faker"
 	"github.com/jmozgit/datagen/internal/acceptor/user/float"
 	"github.com/jmozgit/datagen/internal/acceptor/user/integer"
 	"github.com/jmozgit/datagen/internal/acceptor/user/lua"
 	"github.com/jmozgit/datagen/internal/acceptor/user/plugin"
+	"github.com/jmozgit/datagen/internal/acceptor/user/probability"
+	"github.com/jmozgit/datagen/internal/acceptor/user/template"
 	"github.com/jmozgit/datagen/internal/acceptor/user/text"
 	"github.com/jmozgit/datagen/internal/acceptor/user/time"
 	"github.com/jmozgit/datagen/internal/acceptor/user/uuid"
@@ -24,5 +28,9 @@ func DefaultProviderGenerators(elemsGens contract.GeneratorRegistry) []contract.
 		bytea.NewProvider(),
 		array.NewProvider(elemsGens),
 		plugin.NewProvider(),
+		faker.NewProvider(),
+		probability.NewProvider(),
+		template.NewProvider(elemsGens),
+		derived.NewProvider(),
 	}
 }
@@ -0,0 +1,53 @@
+package derived
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/derived"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrExprRequired = errors.New("derived generator requires expr")
+
+type Provider struct{}
+
+func NewProvider() Provider {
+	return Provider{}
+}
+
+// Accept takes columns the user computes out of other columns of the row.
+func (p Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "user derived: accept"
+
+	userSettings, ok := req.UserSettings.Get()
+	if !ok || userSettings.Type != config.GeneratorTypeDerived {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	if userSettings.Derived == nil || userSettings.Derived.Expr == "" {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", ErrExprRequired, userSettings.Column, fnName)
+	}
+
+	target := model.DriverSpecified
+	if baseType, ok := req.BaseType.Get(); ok {
+		target = baseType.Type
+	}
+
+	gen, err := derived.NewGenerator(req.Rand, userSettings.Derived.Expr, target)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", err, userSettings.Column, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
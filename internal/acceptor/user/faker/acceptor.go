@@ -0,0 +1,55 @@
+package faker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrKindRequired = errors.New("faker kind is required, the column name doesn't tell it")
+
+type Provider struct{}
+
+func NewProvider() Provider {
+	return Provider{}
+}
+
+// Accept takes columns the user asks realistic values for, without a kind it is guessed by the column name.
+func (p Provider) Accept(
+	_ context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "user faker: accept"
+
+	userSettings, ok := req.UserSettings.Get()
+	if !ok || userSettings.Type != config.GeneratorTypeFaker {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	var kind string
+	if userSettings.Faker != nil {
+		kind = userSettings.Faker.Kind
+	}
+
+	if kind == "" {
+		if kind, ok = faker.KindByColumnName(userSettings.Column); !ok {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", ErrKindRequired, userSettings.Column, fnName)
+		}
+	}
+
+	gen, err := faker.NewGenerator(req.Rand, req.Now, kind, 0)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/distribution"
 	"github.com/jmozgit/datagen/internal/generator/float"
 	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
 )
 
 var (
@@ -50,20 +54,58 @@ func (p Provider) Accept(
 		float64Size  = 8
 	)
 
+	bounded := floatSettings != nil &&
+		(floatSettings.MinValue != nil || floatSettings.MaxValue != nil || floatSettings.Distribution != nil)
+
 	switch byteSize {
 	case floatDefault, float64Size:
+		if bounded {
+			return distributedDecision(req, floatSettings, math.MaxFloat64, false)
+		}
+
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceUserSettings,
-			Generator:      float.NewUnboundedFloat64Generator(),
+			Generator:      float.NewUnboundedFloat64Generator(req.Rand),
 			ChooseCallback: nil,
 		}, nil
 	case float32Size:
+		if bounded {
+			return distributedDecision(req, floatSettings, math.MaxFloat32, true)
+		}
+
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceUserSettings,
-			Generator:      float.NewUnboundedFloat32Generator(),
+			Generator:      float.NewUnboundedFloat32Generator(req.Rand),
 			ChooseCallback: nil,
 		}, nil
 	default:
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %d %s", ErrUnsupportedByteSize, byteSize, fnName)
 	}
 }
+
+// distributedDecision keeps values within the settings and the largest float of the size.
+func distributedDecision(
+	req contract.AcceptRequest,
+	settings *config.Float,
+	limit float64,
+	single bool,
+) (model.AcceptanceDecision, error) {
+	minV := max(lo.FromPtrOr(settings.MinValue, -limit), -limit)
+	maxV := min(lo.FromPtrOr(settings.MaxValue, limit), limit)
+
+	params := distribution.Params{Kind: distribution.KindUniform} //nolint:exhaustruct // uniform has no parameters
+	if settings.Distribution != nil {
+		params = distribution.Params(*settings.Distribution)
+	}
+
+	sampler, err := distribution.New(req.Rand, params, minV, maxV)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: distributed decision", err)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      float.NewDistributedGenerator(sampler, single),
+		ChooseCallback: nil,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/distribution"
 	"github.com/jmozgit/datagen/internal/generator/integer"
 	"github.com/jmozgit/datagen/internal/model"
 )
@@ -51,11 +52,7 @@ func (p Provider) Accept(
 	switch options.format {
 	case FormatRandom:
 		if options.byteSize == nil {
-			return model.AcceptanceDecision{
-				AcceptedBy:     model.AcceptanceUserSettings,
-				Generator:      integer.NewRandomInRangeGenerator(math.MinInt32, math.MaxInt32),
-				ChooseCallback: nil,
-			}, nil
+			return randomDecision(req, integerSettings, math.MinInt32, math.MaxInt32)
 		}
 		key := fmt.Sprint(*options.byteSize)
 		if *options.minValue >= 0 {
@@ -64,13 +61,7 @@ func (p Provider) Accept(
 
 		minV, maxV := minValidIntegerForSize[key], maxValidIntegerForSize[key]
 		if *options.minValue >= minV && *options.maxValue <= maxV {
-			return model.AcceptanceDecision{
-				AcceptedBy: model.AcceptanceUserSettings,
-				Generator: integer.NewRandomInRangeGenerator(
-					*options.minValue, *options.maxValue,
-				),
-				ChooseCallback: nil,
-			}, nil
+			return randomDecision(req, integerSettings, *options.minValue, *options.maxValue)
 		}
 
 		return model.AcceptanceDecision{}, fmt.Errorf(
@@ -79,20 +70,45 @@ func (p Provider) Accept(
 			*options.byteSize, minV, maxV, *options.minValue, *options.maxValue,
 		)
 	case FormatSerial:
-		if options.minValue == nil {
-			return model.AcceptanceDecision{
-				AcceptedBy:     model.AcceptanceUserSettings,
-				Generator:      integer.NewSerialIntegerGenerator(0),
-				ChooseCallback: nil,
-			}, nil
+		var start int64
+		if options.minValue != nil {
+			start = *options.minValue
 		}
+		gen := integer.NewSerialIntegerGenerator(start)
 
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceUserSettings,
-			Generator:      integer.NewSerialIntegerGenerator(*options.minValue),
-			ChooseCallback: nil,
+			Generator:      gen,
+			ChooseCallback: req.KeepState(gen),
 		}, nil
 	default:
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s %s", ErrUnknownFormat, options.format, fnName)
 	}
 }
+
+func randomDecision(
+	req contract.AcceptRequest,
+	settings *config.Integer,
+	minV, maxV int64,
+) (model.AcceptanceDecision, error) {
+	if settings == nil || settings.Distribution == nil {
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceUserSettings,
+			Generator:      integer.NewRandomInRangeGenerator(req.Rand, minV, maxV),
+			ChooseCallback: nil,
+		}, nil
+	}
+
+	sampler, err := distribution.New(
+		req.Rand, distribution.Params(*settings.Distribution), float64(minV), float64(maxV),
+	)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: random decision", err)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      integer.NewDistributedGenerator(sampler),
+		ChooseCallback: nil,
+	}, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/generator/lua"
 	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
 )
 
 type Provider struct{}
@@ -28,19 +30,25 @@ func (p Provider) Accept(
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
 	}
 
-	path := ""
-	if userSettings.Lua != nil {
-		path = userSettings.Lua.Path
+	settings := lo.FromPtr(userSettings.Lua)
+
+	_, err := os.Stat(settings.Path)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: filename %s %s", err, settings.Path, fnName)
 	}
 
-	_, err := os.Stat(path)
+	gen, err := lua.NewScriptExecutor(req.Rand, settings.Path, lua.Options{
+		Args:    settings.Args,
+		Columns: settings.Columns,
+		Target:  req.BaseType.OrEmpty(),
+	})
 	if err != nil {
-		return model.AcceptanceDecision{}, fmt.Errorf("%w: filename %s %s", err, path, fnName)
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
 
 	return model.AcceptanceDecision{
-		Generator:      lua.NewScriptExecutor(path),
+		Generator:      gen,
 		AcceptedBy:     model.AcceptanceUserSettings,
-		ChooseCallback: nil,
+		ChooseCallback: req.KeepState(gen),
 	}, nil
 }
@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/pattern"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrRegexRequired = errors.New("pattern generator requires regex")
+
+// Requested tells whether the user asks strings matching a pattern for the column.
+func Requested(req contract.AcceptRequest) bool {
+	userSettings, ok := req.UserSettings.Get()
+
+	return ok && userSettings.Type == config.GeneratorTypePattern
+}
+
+// Accept makes strings matching the user pattern, maxLen is the column length or zero if it's unlimited.
+// Drivers call it from their text providers, they know the length.
+func Accept(req contract.AcceptRequest, maxLen int) (model.AcceptanceDecision, error) {
+	const fnName = "user pattern: accept"
+
+	userSettings, ok := req.UserSettings.Get()
+	if !ok || userSettings.Type != config.GeneratorTypePattern {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	if userSettings.Pattern == nil || userSettings.Pattern.Regex == "" {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", ErrRegexRequired, userSettings.Column, fnName)
+	}
+
+	gen, err := pattern.NewGenerator(req.Rand, userSettings.Pattern.Regex, maxLen)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", err, userSettings.Column, fnName)
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      gen,
+		ChooseCallback: nil,
+	}, nil
+}
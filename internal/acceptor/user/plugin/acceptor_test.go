@@ -7,6 +7,7 @@ import (
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 	"github.com/samber/mo"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +36,7 @@ func Test_PluginAcceptor(t *testing.T) {
 		}),
 		BaseType:      mo.None[model.TargetType](),
 		BaseGenerator: mo.None[model.Generator](),
+		Rand:          xrand.Unseeded(),
 	}
 
 	provider := NewProvider()
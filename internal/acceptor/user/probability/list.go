@@ -36,7 +36,7 @@ func (p Provider) Accept(
 
 	ls := userSettings.ListProbability
 
-	generator, err := probability.NewList(ls.Distribution, ls.Values)
+	generator, err := probability.NewList(req.Rand, ls.Distribution, ls.Values)
 	if err != nil {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
 	}
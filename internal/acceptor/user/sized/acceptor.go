@@ -0,0 +1,43 @@
+package sized
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/acceptor/user/pattern"
+	"github.com/jmozgit/datagen/internal/acceptor/user/template"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// Requested tells whether the user asks a generator which has to know the column length.
+func Requested(req contract.AcceptRequest) bool {
+	return pattern.Requested(req) || template.Requested(req)
+}
+
+// Accept accepts user generators which have to know the column length, zero if it's unlimited.
+// Text providers of drivers call it once they know the length.
+func Accept(
+	ctx context.Context,
+	templates *template.Provider,
+	req contract.AcceptRequest,
+	size int,
+) (model.AcceptanceDecision, error) {
+	const fnName = "user sized: accept"
+
+	if template.Requested(req) {
+		decision, err := templates.AcceptSized(ctx, req, size)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return decision, nil
+	}
+
+	decision, err := pattern.Accept(req, size)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,187 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
+	"github.com/jmozgit/datagen/internal/generator/pattern"
+	"github.com/jmozgit/datagen/internal/generator/template"
+	"github.com/jmozgit/datagen/internal/generator/uuid"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/samber/mo"
+)
+
+var (
+	ErrTemplateRequired   = errors.New("template generator requires text")
+	ErrUnknownPlaceholder = errors.New("unknown template placeholder")
+	ErrInvalidArguments   = errors.New("invalid placeholder arguments")
+)
+
+type Provider struct {
+	registry contract.GeneratorRegistry
+}
+
+func NewProvider(registry contract.GeneratorRegistry) *Provider {
+	return &Provider{
+		registry: registry,
+	}
+}
+
+// Requested tells whether the user asks a template for the column.
+func Requested(req contract.AcceptRequest) bool {
+	userSettings, ok := req.UserSettings.Get()
+
+	return ok && userSettings.Type == config.GeneratorTypeTemplate
+}
+
+// Accept renders the user template, its placeholders are built-in generators or named generators of the settings.
+// Text columns are left to drivers, they know the length and call AcceptSized.
+func (p *Provider) Accept(
+	ctx context.Context,
+	req contract.AcceptRequest,
+) (model.AcceptanceDecision, error) {
+	const fnName = "user template: accept"
+
+	if baseType, ok := req.BaseType.Get(); ok && baseType.Type == model.Text {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	decision, err := p.AcceptSized(ctx, req, 0)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return decision, nil
+}
+
+// AcceptSized renders the user template cut to maxLen characters, zero if the column length is unlimited.
+func (p *Provider) AcceptSized(
+	ctx context.Context,
+	req contract.AcceptRequest,
+	maxLen int,
+) (model.AcceptanceDecision, error) {
+	const fnName = "user template: accept sized"
+
+	userSettings, ok := req.UserSettings.Get()
+	if !ok || userSettings.Type != config.GeneratorTypeTemplate {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", contract.ErrGeneratorDeclined, fnName)
+	}
+
+	settings := userSettings.Template
+	if settings == nil || settings.Text == "" {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s: %s", ErrTemplateRequired, userSettings.Column, fnName)
+	}
+
+	segments, err := template.Parse(settings.Text)
+	if err != nil {
+		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	parts := make([]template.Part, 0, len(segments))
+	var states []model.GeneratorState
+	for _, segment := range segments {
+		if segment.Action == nil {
+			parts = append(parts, template.Part{Literal: segment.Literal, Generator: nil})
+
+			continue
+		}
+
+		gen, err := p.placeholder(ctx, req, settings, segment.Action)
+		if err != nil {
+			template.NewGenerator(parts, 0).Close()
+
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+		parts = append(parts, template.Part{Literal: "", Generator: gen})
+
+		// named generators keep their states when the registry picks them
+		if _, named := settings.Generators[segment.Action[0]]; !named {
+			if state, ok := gen.(model.GeneratorState); ok {
+				states = append(states, state)
+			}
+		}
+	}
+
+	return model.AcceptanceDecision{
+		AcceptedBy:     model.AcceptanceUserSettings,
+		Generator:      template.NewGenerator(parts, maxLen),
+		ChooseCallback: req.KeepState(states...),
+	}, nil
+}
+
+// placeholder makes the generator of {{name args...}}, named generators of the settings come first.
+//
+//nolint:cyclop // a case per placeholder
+func (p *Provider) placeholder(
+	ctx context.Context,
+	req contract.AcceptRequest,
+	settings *config.Template,
+	action []string,
+) (model.Generator, error) {
+	name, args := action[0], action[1:]
+
+	if named, ok := settings.Generators[name]; ok {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("%w: %s takes no arguments", ErrInvalidArguments, name)
+		}
+
+		gen, err := p.registry.GetGenerator(ctx, contract.AcceptRequest{
+			Dataset:       req.Dataset,
+			UserSettings:  mo.Some(named),
+			BaseType:      mo.None[model.TargetType](),
+			BaseGenerator: mo.None[model.Generator](),
+			Rand:          req.Rand,
+			Now:           req.Now,
+			States:        req.States,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: placeholder %s", err, name)
+		}
+
+		return gen, nil
+	}
+
+	switch {
+	case name == "seq" && len(args) == 0:
+		return integer.NewSerialIntegerGenerator(1), nil
+	case name == "uuid" && len(args) == 0:
+		return uuid.NewUUIDV4Generator(req.Rand), nil
+	case strings.HasPrefix(name, "faker.") && len(args) == 0:
+		gen, err := faker.NewGenerator(req.Rand, req.Now, strings.TrimPrefix(name, "faker."), 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: placeholder %s", err, name)
+		}
+
+		return gen, nil
+	case name == "oneof" && len(args) > 0:
+		return oneof.NewGenerator(req.Rand, args), nil
+	case name == "pattern" && len(args) == 1:
+		gen, err := pattern.NewGenerator(req.Rand, args[0], 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: placeholder %s", err, name)
+		}
+
+		return gen, nil
+	case name == "integer" && len(args) == 2:
+		minV, errMin := strconv.ParseInt(args[0], 10, 64)
+		maxV, errMax := strconv.ParseInt(args[1], 10, 64)
+		if errMin != nil || errMax != nil || minV > maxV {
+			return nil, fmt.Errorf("%w: %s %v", ErrInvalidArguments, name, args)
+		}
+
+		return integer.NewRandomInRangeGenerator(req.Rand, minV, maxV), nil
+	case name == "seq", name == "uuid", name == "oneof", name == "pattern", name == "integer",
+		strings.HasPrefix(name, "faker."):
+		return nil, fmt.Errorf("%w: %s %v", ErrInvalidArguments, name, args)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPlaceholder, name)
+	}
+}
@@ -39,9 +39,9 @@ func (p Provider) Accept(
 
 	var gen model.Generator
 	if from == to {
-		gen = text.NewFixedSizedStringGenerator(from)
+		gen = text.NewFixedSizedStringGenerator(req.Rand, from)
 	} else {
-		gen = text.NewInRangeSizeGenerator(from, to)
+		gen = text.NewInRangeSizeGenerator(req.Rand, from, to)
 	}
 
 	return model.AcceptanceDecision{
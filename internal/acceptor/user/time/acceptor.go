@@ -7,6 +7,7 @@ import (
 
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/distribution"
 	"github.com/jmozgit/datagen/internal/generator/timestamp"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/samber/lo"
@@ -31,10 +32,10 @@ func (p Provider) Accept(
 	timestampSetting := userSettings.Timestamp
 
 	if timestampSetting == nil {
-		from, to := time.Now().AddDate(0, -1, 0), time.Now().AddDate(0, 1, 0)
+		from, to := req.Now.AddDate(0, -1, 0), req.Now.AddDate(0, 1, 0)
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceUserSettings,
-			Generator:      timestamp.NewInRangeGenerator(from, to),
+			Generator:      timestamp.NewInRangeGenerator(req.Rand, from, to),
 			ChooseCallback: nil,
 		}, nil
 	}
@@ -42,23 +43,40 @@ func (p Provider) Accept(
 	if timestampSetting.OnlyNow {
 		return model.AcceptanceDecision{
 			AcceptedBy:     model.AcceptanceUserSettings,
-			Generator:      timestamp.NewAlwaysNowGenerator(),
+			Generator:      timestamp.NewAlwaysNowGenerator(req.Now),
 			ChooseCallback: nil,
 		}, nil
 	}
 
 	const days60 = time.Hour * 24 * 60
 
-	from := lo.FromPtrOr(timestampSetting.From, time.Now().Add(-days60))
-	to := lo.FromPtrOr(timestampSetting.To, time.Now().Add(days60))
+	from := lo.FromPtrOr(timestampSetting.From, req.Now.Add(-days60))
+	to := lo.FromPtrOr(timestampSetting.To, req.Now.Add(days60))
 
 	if to.Before(from) {
 		to = from.Add(days60)
 	}
 
+	if timestampSetting.Distribution != nil {
+		const day = 24 * time.Hour
+
+		sampler, err := distribution.New(
+			req.Rand, distribution.Params(*timestampSetting.Distribution), 0, float64(to.Sub(from))/float64(day),
+		)
+		if err != nil {
+			return model.AcceptanceDecision{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return model.AcceptanceDecision{
+			AcceptedBy:     model.AcceptanceUserSettings,
+			Generator:      timestamp.NewDistributedGenerator(sampler, from),
+			ChooseCallback: nil,
+		}, nil
+	}
+
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceUserSettings,
-		Generator:      timestamp.NewInRangeGenerator(from, to),
+		Generator:      timestamp.NewInRangeGenerator(req.Rand, from, to),
 		ChooseCallback: nil,
 	}, nil
 }
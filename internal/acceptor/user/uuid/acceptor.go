@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
@@ -19,13 +21,14 @@ func NewProvider() Provider {
 	return Provider{}
 }
 
-var generatorsByVersion = map[string]model.Generator{
-	"v1": uuid.NewUUIDV1Generator(),
-	"v3": uuid.NewUUIDV3Generator(),
-	"v4": uuid.NewUUIDV4Generator(),
-	"v5": uuid.NewUUIDV5Generator(),
-	"v6": uuid.NewUUIDV6Generator(),
-	"v7": uuid.NewUUIDV7Generator(),
+// time based versions are made around the reference time of the run.
+var generatorsByVersion = map[string]func(rnd *rand.Rand, now time.Time) model.Generator{
+	"v1": uuid.NewUUIDV1Generator,
+	"v3": func(rnd *rand.Rand, _ time.Time) model.Generator { return uuid.NewUUIDV3Generator(rnd) },
+	"v4": func(rnd *rand.Rand, _ time.Time) model.Generator { return uuid.NewUUIDV4Generator(rnd) },
+	"v5": func(rnd *rand.Rand, _ time.Time) model.Generator { return uuid.NewUUIDV5Generator(rnd) },
+	"v6": uuid.NewUUIDV6Generator,
+	"v7": uuid.NewUUIDV7Generator,
 }
 
 func (p Provider) Accept(
@@ -45,14 +48,14 @@ func (p Provider) Accept(
 		version = *uuidSettings.Version
 	}
 
-	gen, ok := generatorsByVersion[version]
+	newGen, ok := generatorsByVersion[version]
 	if !ok {
 		return model.AcceptanceDecision{}, fmt.Errorf("%w: %s %s", ErrUnknownVersion, version, fnName)
 	}
 
 	return model.AcceptanceDecision{
 		AcceptedBy:     model.AcceptanceUserSettings,
-		Generator:      gen,
+		Generator:      newGen(req.Rand, req.Now),
 		ChooseCallback: nil,
 	}, nil
 }
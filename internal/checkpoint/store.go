@@ -0,0 +1,207 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var (
+	ErrSeedMismatch          = errors.New("seed differs from the checkpoint one")
+	ErrReferenceTimeMismatch = errors.New("reference time differs from the checkpoint one")
+	ErrRandStateMismatch     = errors.New("checkpoint doesn't match table columns")
+)
+
+type Table struct {
+	Rows            int64    `json:"rows"`
+	InitSize        uint64   `json:"initSize"`
+	ExtraSize       uint64   `json:"extraSize"`
+	RandStates      [][]byte `json:"randStates"`
+	GeneratorStates [][]byte `json:"generatorStates,omitempty"`
+}
+
+type file struct {
+	Seed uint64 `json:"seed"`
+	// ReferenceTime is missing in checkpoints of older versions.
+	ReferenceTime *time.Time       `json:"referenceTime,omitempty"`
+	Tables        map[string]Table `json:"tables"`
+}
+
+// Store keeps per table progress in a json file, so an interrupted run can be resumed.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	file file
+}
+
+// New starts an empty checkpoint for a run with the given seed and reference time.
+func New(path string, seed uint64, referenceTime time.Time) *Store {
+	return &Store{
+		path: path,
+		file: file{
+			Seed:          seed,
+			ReferenceTime: &referenceTime,
+			Tables:        make(map[string]Table),
+		},
+	}
+}
+
+// Load reads the checkpoint of an interrupted run.
+func Load(path string) (*Store, error) {
+	const fnName = "checkpoint: load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%w: %s %s", err, path, fnName)
+	}
+	if f.Tables == nil {
+		f.Tables = make(map[string]Table)
+	}
+
+	return &Store{path: path, file: f}, nil
+}
+
+func (s *Store) Seed() uint64 {
+	return s.file.Seed
+}
+
+// ReferenceTime is the reference time of the interrupted run, false if the checkpoint doesn't have it.
+func (s *Store) ReferenceTime() (time.Time, bool) {
+	if s.file.ReferenceTime == nil {
+		return time.Time{}, false
+	}
+
+	return *s.file.ReferenceTime, true
+}
+
+// Restore moves the task to the point where the interrupted run saved its last batch.
+// Tasks unknown to the checkpoint are left as they are. Values reused from tables are read again,
+// so they may differ from the ones of a run that wasn't interrupted.
+func (s *Store) Restore(task model.Task) error {
+	const fnName = "checkpoint: restore"
+
+	s.mu.Lock()
+	table, ok := s.file.Tables[task.TableName()]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	if len(table.RandStates) != len(task.RandStates) || len(table.GeneratorStates) != len(task.GeneratorStates) {
+		return fmt.Errorf("%w: %s %s", ErrRandStateMismatch, task.TableName(), fnName)
+	}
+
+	for i, state := range table.RandStates {
+		if err := task.RandStates[i].UnmarshalBinary(state); err != nil {
+			return fmt.Errorf("%w: %s %s", err, task.TableName(), fnName)
+		}
+	}
+
+	for i, state := range table.GeneratorStates {
+		if err := task.GeneratorStates[i].UnmarshalBinary(state); err != nil {
+			return fmt.Errorf("%w: %s %s", err, task.TableName(), fnName)
+		}
+	}
+
+	if limiter, ok := task.Limiter.(model.ResumableLimiter); ok {
+		limiter.Restore(model.LimitProgress{
+			Rows:      table.Rows,
+			InitSize:  table.InitSize,
+			ExtraSize: table.ExtraSize,
+		})
+	}
+
+	return nil
+}
+
+// Save records the task state and rewrites the checkpoint file.
+func (s *Store) Save(task model.Task) error {
+	const fnName = "checkpoint: save"
+
+	table := Table{
+		Rows:            0,
+		InitSize:        0,
+		ExtraSize:       0,
+		RandStates:      make([][]byte, len(task.RandStates)),
+		GeneratorStates: make([][]byte, len(task.GeneratorStates)),
+	}
+	if limiter, ok := task.Limiter.(model.ResumableLimiter); ok {
+		progress := limiter.Progress()
+		table.Rows = progress.Rows
+		table.InitSize = progress.InitSize
+		table.ExtraSize = progress.ExtraSize
+	}
+
+	for i, state := range task.RandStates {
+		data, err := state.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		table.RandStates[i] = data
+	}
+
+	for i, state := range task.GeneratorStates {
+		data, err := state.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		table.GeneratorStates[i] = data
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.file.Tables[task.TableName()] = table
+
+	if err := s.flush(); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func (s *Store) flush() error {
+	const fnName = "flush"
+
+	data, err := json.Marshal(s.file)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// rename is atomic, so a kill in the middle never leaves a broken checkpoint
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
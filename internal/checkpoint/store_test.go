@@ -0,0 +1,108 @@
+package checkpoint_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/checkpoint"
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/limit/rows"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/stretchr/testify/require"
+)
+
+type noopCollector struct{}
+
+func (noopCollector) Collect(context.Context, model.ProgressState) {}
+
+func newTask(seed uint64) (model.Task, *rand.Rand) {
+	src := xrand.NewSource(seed, "public.t", "id")
+
+	return model.Task{
+		DatasetSchema: model.DatasetSchema{
+			TableName: model.TableName{
+				Schema: model.PGIdentifier("public"),
+				Table:  model.PGIdentifier("t"),
+			},
+		},
+		Limiter:    rows.NewStopper(100, "t", noopCollector{}),
+		RandStates: []model.RandState{src},
+	}, rand.New(src) //nolint:gosec // test
+}
+
+func Test_SaveLoadRestore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	task, rnd := newTask(7)
+	_ = rnd.Uint64()
+	task.Limiter.Collect(t.Context(), model.SaveReport{RowsSaved: 40})
+
+	referenceTime := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	store := checkpoint.New(path, 7, referenceTime)
+	require.NoError(t, store.Save(task))
+	expected := rnd.Uint64()
+
+	loaded, err := checkpoint.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), loaded.Seed())
+	loadedTime, ok := loaded.ReferenceTime()
+	require.True(t, ok)
+	require.True(t, referenceTime.Equal(loadedTime))
+
+	resumed, resumedRnd := newTask(7)
+	require.NoError(t, loaded.Restore(resumed))
+	require.Equal(t, expected, resumedRnd.Uint64())
+
+	ticket, err := resumed.Limiter.NextTicket(t.Context(), 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(60), ticket.AllowedRows)
+}
+
+func Test_RestoreColumnsMismatch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	task, _ := newTask(1)
+	require.NoError(t, checkpoint.New(path, 1, time.Time{}).Save(task))
+
+	loaded, err := checkpoint.Load(path)
+	require.NoError(t, err)
+
+	task.RandStates = append(task.RandStates, xrand.NewSource(1, "public.t", "name"))
+	require.ErrorIs(t, loaded.Restore(task), checkpoint.ErrRandStateMismatch)
+}
+
+func Test_RestoreGeneratorStates(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	task, _ := newTask(1)
+	serial := integer.NewSerialIntegerGenerator(1)
+	task.GeneratorStates = []model.GeneratorState{serial}
+	for range 3 {
+		_, err := serial.Gen(t.Context())
+		require.NoError(t, err)
+	}
+	require.NoError(t, checkpoint.New(path, 1, time.Time{}).Save(task))
+
+	loaded, err := checkpoint.Load(path)
+	require.NoError(t, err)
+
+	resumed, _ := newTask(1)
+	resumedSerial := integer.NewSerialIntegerGenerator(1)
+	resumed.GeneratorStates = []model.GeneratorState{resumedSerial}
+	require.NoError(t, loaded.Restore(resumed))
+
+	val, err := resumedSerial.Gen(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), val)
+}
@@ -11,98 +11,257 @@ type ConnectionType string
 
 const (
 	PostgresqlConnection ConnectionType = "postgresql"
+	MySQLConnection      ConnectionType = "mysql"
+	SQLiteConnection     ConnectionType = "sqlite"
+	OracleConnection     ConnectionType = "oracle"
 )
 
 type Config struct {
-	Version    int        `yaml:"version"`
-	Connection Connection `yaml:"connection"`
-	Targets    []Target   `yaml:"targets"`
-	Options    Options    `yaml:"options"`
+	Version    int        `yaml:"version,omitempty"`
+	Connection Connection `yaml:"connection,omitempty"`
+	Targets    []Target   `yaml:"targets,omitempty"`
+	Options    Options    `yaml:"options,omitempty"`
+	// Output replaces the connection as the destination of rows, the connection is still used for the schema.
+	Output *Output `yaml:"output,omitempty"`
+}
+
+type OutputType string
+
+const (
+	FileOutputType    OutputType = "file"
+	ParquetOutputType OutputType = "parquet"
+	SQLOutputType     OutputType = "sql"
+)
+
+type Output struct {
+	Type    OutputType     `yaml:"type"`
+	File    *FileOutput    `yaml:"file"`
+	Parquet *ParquetOutput `yaml:"parquet"`
+	SQL     *SQLOutput     `yaml:"sql"`
+}
+
+type FileFormat string
+
+const (
+	FileFormatCSV   FileFormat = "csv"
+	FileFormatJSONL FileFormat = "jsonl"
+)
+
+type CSVQuote string
+
+const (
+	CSVQuoteMinimal CSVQuote = "minimal"
+	CSVQuoteAll     CSVQuote = "all"
+)
+
+// FileOutput writes every table to its own <schema>.<table>.<format> file in Dir.
+// Delimiter, Header, Quote and Null only matter for csv.
+type FileOutput struct {
+	Dir       string     `yaml:"dir"`
+	Format    FileFormat `yaml:"format"`
+	Delimiter string     `yaml:"delimiter"`
+	Header    *bool      `yaml:"header"`
+	Quote     CSVQuote   `yaml:"quote"`
+	Null      string     `yaml:"null"`
+}
+
+// ParquetOutput writes every table to <schema>.<table>.parquet in Dir, one row group per batch.
+// With MaxFileSize set a table is split into <schema>.<table>.<part>.parquet files of about that size.
+type ParquetOutput struct {
+	Dir         string            `yaml:"dir"`
+	MaxFileSize datasize.ByteSize `yaml:"maxFileSize"`
+	Compression string            `yaml:"compression"`
+}
+
+type SQLDumpMode string
+
+const (
+	SQLDumpCopy   SQLDumpMode = "copy"
+	SQLDumpInsert SQLDumpMode = "insert"
+)
+
+// SQLOutput writes a script replaying the generated rows, tables follow their reference order.
+// Tables with large objects are always written as inserts calling lo_from_bytea.
+type SQLOutput struct {
+	Path string      `yaml:"path"`
+	Mode SQLDumpMode `yaml:"mode"`
 }
 
 type Connection struct {
-	Type       ConnectionType `yaml:"type"`
-	Postgresql *SQLConnection `yaml:"postgresql"`
+	Type       ConnectionType  `yaml:"type,omitempty"`
+	Postgresql *SQLConnection  `yaml:"postgresql,omitempty"`
+	MySQL      *SQLConnection  `yaml:"mysql,omitempty"`
+	SQLite     *SQLiteDatabase `yaml:"sqlite,omitempty"`
+	Oracle     *SQLConnection  `yaml:"oracle,omitempty"`
 }
 
 func (c Connection) ConnString() string {
 	switch c.Type {
 	case PostgresqlConnection:
 		return c.Postgresql.ConnString("postgresql")
+	case MySQLConnection:
+		return c.MySQL.MySQLDSN()
+	case SQLiteConnection:
+		return c.SQLite.DSN()
+	case OracleConnection:
+		// DBName is the service name
+		return c.Oracle.ConnString("oracle")
 	default:
 		panic(fmt.Sprintf("unknown connection type %s", c.Type))
 	}
 }
 
 type Target struct {
-	Table *Table `yaml:"table"`
+	Table  *Table  `yaml:"table,omitempty"`
+	Schema *Schema `yaml:"schema,omitempty"`
+}
+
+// Schema targets every base table of the schema, tables listed in their own table target keep those settings.
+// Include and Exclude are case-insensitive globs matched against table names, an empty Include matches all.
+type Schema struct {
+	Name       string            `yaml:"name"`
+	Include    []string          `yaml:"include"`
+	Exclude    []string          `yaml:"exclude"`
+	LimitRows  uint64            `yaml:"limitRows"`
+	LimitBytes datasize.ByteSize `yaml:"limitBytes"`
 }
 
 type Options struct {
-	BatchSize          int           `yaml:"batchSize"`
-	CheckSizeDuration  time.Duration `yaml:"checkSizeDuration"`
-	NoProgressAttempts int           `yaml:"noProgressAttempts"`
+	BatchSize          int           `yaml:"batchSize,omitempty"`
+	CheckSizeDuration  time.Duration `yaml:"checkSizeDuration,omitempty"`
+	NoProgressAttempts int           `yaml:"noProgressAttempts,omitempty"`
+	Seed               *uint64       `yaml:"seed,omitempty"`
+	// FakerByColumnName fills unconstrained text columns named like email, phone or created_at with realistic values.
+	FakerByColumnName bool `yaml:"fakerByColumnName,omitempty"`
+	// ReferenceTime is what default time ranges and "now" of checks are around, the start of the run if unset.
+	ReferenceTime *time.Time `yaml:"referenceTime,omitempty"`
+}
+
+// Now is the reference time of the run, the clock if it isn't fixed yet.
+func (o Options) Now() time.Time {
+	if o.ReferenceTime != nil {
+		return *o.ReferenceTime
+	}
+
+	return time.Now().UTC()
+}
+
+// FixReferenceTime pins the reference time to the clock if it's unset, so all tables of the run share it
+// and the run can be repeated with the logged value.
+func (o *Options) FixReferenceTime() time.Time {
+	if o.ReferenceTime == nil {
+		now := time.Now().UTC().Truncate(time.Second)
+		o.ReferenceTime = &now
+	}
+
+	return *o.ReferenceTime
 }
 
 type Table struct {
-	Schema     string            `yaml:"schema"`
-	Table      string            `yaml:"table"`
-	LimitRows  uint64            `yaml:"limitRows"`
-	LimitBytes datasize.ByteSize `yaml:"limitBytes"`
-	Generators []Generator       `yaml:"generators"`
+	Schema     string            `yaml:"schema,omitempty"`
+	Table      string            `yaml:"table,omitempty"`
+	LimitRows  uint64            `yaml:"limitRows,omitempty"`
+	LimitBytes datasize.ByteSize `yaml:"limitBytes,omitempty"`
+	Generators []Generator       `yaml:"generators,omitempty"`
+	// CreatePartitions makes datagen create missing partitions of a partitioned table before the run.
+	CreatePartitions *CreatePartitions `yaml:"createPartitions,omitempty"`
+}
+
+// CreatePartitions describes partitions to create. Range partitioned tables get partitions of Step width
+// covering [From, To), Step is an interval like "1 month" for time keys and a number for numeric ones;
+// ranges overlapping existing partitions are skipped. Hash partitioned tables get a partition for every
+// missing remainder, Step is the modulus if the table has no partitions yet. List partitions aren't created.
+type CreatePartitions struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+	Step string `yaml:"step"`
 }
 
 type Generator struct {
-	Column          string           `yaml:"column"`
-	Type            GeneratorType    `yaml:"type"`
-	Integer         *Integer         `yaml:"integer"`
-	Float           *Float           `yaml:"float"`
-	Timestamp       *Timestamp       `yaml:"timestamp"`
-	UUID            *UUID            `yaml:"uuid"`
-	Lua             *Lua             `yaml:"lua"`
-	ListProbability *ListProbability `yaml:"listProbability"`
-	Text            *Text            `yaml:"text"`
-	LO              *LO              `yaml:"lo"`
-	Bytea           *LO              `yaml:"bytea"`
-	Array           *Array           `yaml:"array"`
-	Plugin          *Plugin          `yaml:"plugin"`
-	NullFraction    int              `yaml:"nullFraction"`
-	ReuseFraction   int              `yaml:"reuseFraction"`
+	Column          string           `yaml:"column,omitempty"`
+	Type            GeneratorType    `yaml:"type,omitempty"`
+	Integer         *Integer         `yaml:"integer,omitempty"`
+	Float           *Float           `yaml:"float,omitempty"`
+	Timestamp       *Timestamp       `yaml:"timestamp,omitempty"`
+	UUID            *UUID            `yaml:"uuid,omitempty"`
+	Lua             *Lua             `yaml:"lua,omitempty"`
+	ListProbability *ListProbability `yaml:"listProbability,omitempty"`
+	Text            *Text            `yaml:"text,omitempty"`
+	LO              *LO              `yaml:"lo,omitempty"`
+	Bytea           *LO              `yaml:"bytea,omitempty"`
+	Array           *Array           `yaml:"array,omitempty"`
+	Range           *Range           `yaml:"range,omitempty"`
+	JSON            *JSON            `yaml:"json,omitempty"`
+	Faker           *Faker           `yaml:"faker,omitempty"`
+	Pattern         *Pattern         `yaml:"pattern,omitempty"`
+	Template        *Template        `yaml:"template,omitempty"`
+	Derived         *Derived         `yaml:"derived,omitempty"`
+	Plugin          *Plugin          `yaml:"plugin,omitempty"`
+	NullFraction    int              `yaml:"nullFraction,omitempty"`
+	ReuseFraction   int              `yaml:"reuseFraction,omitempty"`
 }
 
 type Integer struct {
-	Format   *string `yaml:"format"`
-	ByteSize *int8   `yaml:"byteSize"`
-	MinValue *int64  `yaml:"minValue"`
-	MaxValue *int64  `yaml:"maxValue"`
+	Format       *string       `yaml:"format,omitempty"`
+	ByteSize     *int8         `yaml:"byteSize,omitempty"`
+	MinValue     *int64        `yaml:"minValue,omitempty"`
+	MaxValue     *int64        `yaml:"maxValue,omitempty"`
+	Distribution *Distribution `yaml:"distribution,omitempty"`
 }
 
+// Float values are unbounded unless MinValue, MaxValue or Distribution is set.
 type Float struct {
-	ByteSize *int8 `yaml:"byteSize"`
+	ByteSize     *int8         `yaml:"byteSize,omitempty"`
+	MinValue     *float64      `yaml:"minValue,omitempty"`
+	MaxValue     *float64      `yaml:"maxValue,omitempty"`
+	Distribution *Distribution `yaml:"distribution,omitempty"`
 }
 
 type Timestamp struct {
-	OnlyNow bool       `yaml:"onlyNow"`
-	From    *time.Time `yaml:"from"`
-	To      *time.Time `yaml:"to"`
+	OnlyNow      bool          `yaml:"onlyNow,omitempty"`
+	From         *time.Time    `yaml:"from,omitempty"`
+	To           *time.Time    `yaml:"to,omitempty"`
+	Distribution *Distribution `yaml:"distribution,omitempty"`
+}
+
+// Distribution skews random values, Kind is one of uniform, normal, lognormal, exponential, zipf, pareto
+// or histogram. Parameters are in values of the column, days after from for timestamps. Lognormal, exponential,
+// zipf and pareto count from the minimum, values outside of the range are clamped.
+type Distribution struct {
+	Kind string `yaml:"kind,omitempty"`
+	// Mean and StdDev of normal, of the logarithm for lognormal.
+	Mean   *float64 `yaml:"mean,omitempty"`
+	StdDev *float64 `yaml:"stddev,omitempty"`
+	Rate   *float64 `yaml:"rate,omitempty"`
+	// S is the exponent of zipf, above 1.
+	S     *float64 `yaml:"s,omitempty"`
+	Alpha *float64 `yaml:"alpha,omitempty"`
+	Scale *float64 `yaml:"scale,omitempty"`
+	// Bounds of histogram buckets picked with Weights, there is one more bound than weights.
+	Bounds  []float64 `yaml:"bounds,omitempty"`
+	Weights []int     `yaml:"weights,omitempty"`
 }
 
 type UUID struct {
-	Version *string `yaml:"version"`
+	Version *string `yaml:"version,omitempty"`
 }
 
+// Lua runs the script at Path. A script defining gen(row, ctx) gets Args as ctx.args and
+// the already generated Columns of the row as row.
 type Lua struct {
-	Path string `yaml:"path"`
+	Path    string         `yaml:"path"`
+	Args    map[string]any `yaml:"args"`
+	Columns []string       `yaml:"columns"`
 }
 
 type ListProbability struct {
-	Values       []any `yaml:"values"`
-	Distribution []int `yaml:"distribution"`
+	Values       []any `yaml:"values,omitempty"`
+	Distribution []int `yaml:"distribution,omitempty"`
 }
 
 type Text struct {
-	CharLenFrom int `yaml:"charToFrom"`
-	CharLenTo   int `yaml:"charLenTo"`
+	CharLenFrom int `yaml:"charToFrom,omitempty"`
+	CharLenTo   int `yaml:"charLenTo,omitempty"`
 }
 
 type LO struct {
@@ -116,6 +275,43 @@ type Array struct {
 	ElemType *Generator `yaml:"elemType"`
 }
 
+// Range widths are numbers for numeric subtypes and durations like 36h for time ones.
+type Range struct {
+	// Bounds is one of [), [], (], ()
+	Bounds        string `yaml:"bounds"`
+	EmptyFraction int    `yaml:"emptyFraction"`
+	MinWidth      string `yaml:"minWidth"`
+	MaxWidth      string `yaml:"maxWidth"`
+}
+
+// JSON documents follow an inline JSON Schema or one read from Path, without either they are random.
+type JSON struct {
+	Schema map[string]any `yaml:"schema"`
+	Path   string         `yaml:"path"`
+}
+
+// Faker makes realistic values of Kind like email, full_name or iban.
+type Faker struct {
+	Kind string `yaml:"kind"`
+}
+
+// Pattern makes strings matching Regex, a regular expression in the syntax of go regexp.
+type Pattern struct {
+	Regex string `yaml:"regex"`
+}
+
+// Template renders Text per value, its placeholders are {{seq}}, {{uuid}}, {{faker.kind}}, {{oneof "a" "b"}},
+// {{pattern "regex"}}, {{integer min max}} or {{name}} of one of Generators.
+type Template struct {
+	Text       string               `yaml:"text"`
+	Generators map[string]Generator `yaml:"generators"`
+}
+
+// Derived computes Expr over other columns of the same row, like qty * price or first || ' ' || last.
+type Derived struct {
+	Expr string `yaml:"expr"`
+}
+
 type Plugin struct {
 	Path string `yaml:"path"`
 }
@@ -6,12 +6,12 @@ import (
 )
 
 type SQLConnection struct {
-	Host     string   `yaml:"host" koanf:"host"`
-	Port     int      `yaml:"port" koanf:"port"`
-	User     string   `yaml:"user" koanf:"user"`
-	Password string   `yaml:"password" koanf:"password"`
-	DBName   string   `yaml:"dbName" koanf:"dbName"`
-	Options  []string `yaml:"options" koanf:"options"`
+	Host     string   `yaml:"host,omitempty" koanf:"host"`
+	Port     int      `yaml:"port,omitempty" koanf:"port"`
+	User     string   `yaml:"user,omitempty" koanf:"user"`
+	Password string   `yaml:"password,omitempty" koanf:"password"`
+	DBName   string   `yaml:"dbName,omitempty" koanf:"dbName"`
+	Options  []string `yaml:"options,omitempty" koanf:"options"`
 }
 
 func (s SQLConnection) ConnString(protocol string) string {
@@ -37,3 +37,39 @@ func (s SQLConnection) ConnString(protocol string) string {
 
 	return builder.String()
 }
+
+// MySQLDSN builds a go-sql-driver/mysql data source name, Options are passed as its params.
+func (s SQLConnection) MySQLDSN() string {
+	var builder strings.Builder
+
+	builder.WriteString(s.User)
+	if s.Password != "" {
+		builder.WriteRune(':')
+		builder.WriteString(s.Password)
+	}
+	builder.WriteString("@tcp(")
+	builder.WriteString(s.Host)
+	builder.WriteByte(':')
+	builder.WriteString(fmt.Sprint(s.Port))
+	builder.WriteString(")/")
+	builder.WriteString(s.DBName)
+	if len(s.Options) > 0 {
+		builder.WriteByte('?')
+		builder.WriteString(strings.Join(s.Options, "&"))
+	}
+
+	return builder.String()
+}
+
+// SQLiteDatabase points to a database file, it's created if it doesn't exist.
+type SQLiteDatabase struct {
+	Path    string   `yaml:"path" koanf:"path"`
+	Options []string `yaml:"options" koanf:"options"`
+}
+
+// DSN enables foreign keys, sqlite doesn't check them by default.
+func (s SQLiteDatabase) DSN() string {
+	params := append([]string{"_pragma=foreign_keys(1)", "_pragma=busy_timeout(5000)"}, s.Options...)
+
+	return "file:" + s.Path + "?" + strings.Join(params, "&")
+}
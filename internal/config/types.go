@@ -14,4 +14,12 @@ const (
 	GeneratorTypeBytea           GeneratorType = "bytea"
 	GeneratorTypeArray           GeneratorType = "array"
 	GeneratorTypePlugin          GeneratorType = "plugin"
+	GeneratorTypeRange           GeneratorType = "range"
+	GeneratorTypeJSON            GeneratorType = "json"
+	GeneratorTypeFaker           GeneratorType = "faker"
+	GeneratorTypePattern         GeneratorType = "pattern"
+	GeneratorTypeTemplate        GeneratorType = "template"
+	GeneratorTypeDerived         GeneratorType = "derived"
+	// GeneratorTypeDefault leaves the column out of inserts, so the database fills it.
+	GeneratorTypeDefault GeneratorType = "default"
 )
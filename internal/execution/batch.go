@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/saver/factory"
@@ -11,13 +12,22 @@ import (
 
 var ErrNoProgressHappens = errors.New("no progress happens")
 
+// uncheckedNoProgressAttempts bounds retries of tables with unsupported checks when no limit is set,
+// rows rejected by such a check would be regenerated forever otherwise.
+const uncheckedNoProgressAttempts = 10
+
 type refNotifier interface {
 	OnProcessed(batch model.SaveBatch)
 }
 
+type checkpointer interface {
+	Save(task model.Task) error
+}
+
 type BatchExecutor struct {
 	saver              factory.Saver
 	refNotifier        refNotifier
+	checkpointer       checkpointer
 	batchSize          int
 	noProgressAttempts int
 }
@@ -31,11 +41,19 @@ func NewBatchExecutor(
 	return &BatchExecutor{
 		saver:              saver,
 		refNotifier:        refNotifier,
+		checkpointer:       nil,
 		batchSize:          batchSize,
 		noProgressAttempts: noProgressAttempts,
 	}
 }
 
+// WithCheckpointer makes the executor save the task state after every saved batch.
+func (b *BatchExecutor) WithCheckpointer(c checkpointer) *BatchExecutor {
+	b.checkpointer = c
+
+	return b
+}
+
 func (b *BatchExecutor) Execute(ctx context.Context, task model.Task) error {
 	defer func() {
 		for i := range task.Generators {
@@ -48,6 +66,15 @@ func (b *BatchExecutor) Execute(ctx context.Context, task model.Task) error {
 		batch[i] = make([]any, len(task.Generators))
 	}
 
+	noProgressAttempts := b.noProgressAttempts
+	if noProgressAttempts == 0 && len(task.DatasetSchema.UnsupportedChecks) > 0 {
+		noProgressAttempts = uncheckedNoProgressAttempts
+	}
+
+	orders := resolveOrders(task)
+	rowGen := newRowGenerator(task)
+	rowCtx := model.WithRow(ctx, rowGen.row)
+
 	noProgressLoop := 0
 	for {
 		nextTicket, err := task.Limiter.NextTicket(ctx, int64(b.batchSize))
@@ -62,13 +89,8 @@ func (b *BatchExecutor) Execute(ctx context.Context, task model.Task) error {
 
 		batchID := 0
 		for rows > int64(0) {
-			for i, gen := range task.Generators {
-				cell, err := gen.Gen(ctx)
-				if err != nil {
-					return fmt.Errorf("%w: execute %s", err, task.DatasetSchema.Columns[i].SourceName.AsArgument())
-				}
-
-				batch[batchID][i] = cell
+			if err := genOrdered(rowCtx, rowGen, batch[batchID], orders); err != nil {
+				return fmt.Errorf("%w: execute", err)
 			}
 			rows--
 			batchID++
@@ -93,10 +115,23 @@ func (b *BatchExecutor) Execute(ctx context.Context, task model.Task) error {
 			noProgressLoop = 0
 		}
 
-		if b.noProgressAttempts != 0 && b.noProgressAttempts == noProgressLoop {
+		if noProgressAttempts != 0 && noProgressAttempts == noProgressLoop {
+			if checks := task.DatasetSchema.UnsupportedChecks; len(checks) > 0 {
+				return fmt.Errorf(
+					"%w: execute %s, rows may violate %s",
+					ErrNoProgressHappens, task.DatasetSchema.TableName.Quoted(), strings.Join(checks, ", "),
+				)
+			}
+
 			return fmt.Errorf("%w: execute", ErrNoProgressHappens)
 		}
 
 		task.Limiter.Collect(ctx, saved.Stat)
+
+		if b.checkpointer != nil {
+			if err := b.checkpointer.Save(task); err != nil {
+				return fmt.Errorf("%w: execute %s", err, task.DatasetSchema.TableName.Quoted())
+			}
+		}
 	}
 }
@@ -0,0 +1,143 @@
+package execution
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrColumnOrderUnsatisfied = errors.New("column order isn't satisfied")
+
+// orderAttempts bounds how many times a row is generated anew to satisfy column orders.
+const orderAttempts = 64
+
+type orderIdx struct {
+	lesser, greater int
+	strict          bool
+	// swappable is set when both columns are generated independently with the same bounds,
+	// so swapping them can't break their own bounds, user settings or derived values.
+	swappable bool
+}
+
+func resolveOrders(task model.Task) []orderIdx {
+	schema := task.DatasetSchema
+	indexOf := func(id model.Identifier) int {
+		return slices.IndexFunc(schema.Columns, func(c model.TargetType) bool { return c.SourceName == id })
+	}
+
+	// derived columns are user configured, the ones they read keep their values too
+	fixed := make(map[int]bool, len(task.RowColumns))
+	for _, idx := range task.RowColumns {
+		fixed[idx] = true
+	}
+	for idx, configured := range task.Configured {
+		fixed[idx] = fixed[idx] || configured
+	}
+
+	orders := make([]orderIdx, 0, len(schema.ColumnOrders))
+	for _, order := range schema.ColumnOrders {
+		lesser, greater := indexOf(order.Lesser), indexOf(order.Greater)
+		if lesser != -1 && greater != -1 {
+			orders = append(orders, orderIdx{
+				lesser:  lesser,
+				greater: greater,
+				strict:  order.Strict,
+				swappable: !fixed[lesser] && !fixed[greater] &&
+					sameBounds(schema.Columns[lesser], schema.Columns[greater]),
+			})
+		}
+	}
+
+	return orders
+}
+
+func sameBounds(a, b model.TargetType) bool {
+	return a.FixedSize == b.FixedSize &&
+		reflect.DeepEqual(a.Check, b.Check) &&
+		reflect.DeepEqual(a.Partitions, b.Partitions)
+}
+
+// genOrdered generates the row until it satisfies column orders, it fails once attempts run out.
+func genOrdered(ctx context.Context, rowGen rowGenerator, row []any, orders []orderIdx) error {
+	const fnName = "gen ordered"
+
+	for range orderAttempts {
+		if err := rowGen.gen(ctx, row); err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if applyOrders(row, orders) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %d attempts %s", ErrColumnOrderUnsatisfied, orderAttempts, fnName)
+}
+
+// applyOrders swaps independently generated values of the row violating column orders where it's safe
+// and reports whether the row satisfies all of them. Equal values of a strict order can't be fixed by a swap.
+func applyOrders(row []any, orders []orderIdx) bool {
+	for _, order := range orders {
+		if c, ok := compareCells(row[order.lesser], row[order.greater]); ok && c > 0 && order.swappable {
+			row[order.lesser], row[order.greater] = row[order.greater], row[order.lesser]
+		}
+	}
+
+	for _, order := range orders {
+		c, ok := compareCells(row[order.lesser], row[order.greater])
+		if ok && (c > 0 || (c == 0 && order.strict)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareCells compares values of the same type, ok is false for nulls and unknown types.
+func compareCells(a, b any) (int, bool) {
+	switch av := a.(type) {
+	case int64:
+		return compareOrdered(av, b)
+	case int32:
+		return compareOrdered(av, b)
+	case int16:
+		return compareOrdered(av, b)
+	case int8:
+		return compareOrdered(av, b)
+	case int:
+		return compareOrdered(av, b)
+	case float64:
+		return compareOrdered(av, b)
+	case float32:
+		return compareOrdered(av, b)
+	case string:
+		return compareOrdered(av, b)
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv), true
+		}
+	case decimal.Decimal:
+		if bv, ok := b.(decimal.Decimal); ok {
+			return av.Cmp(bv), true
+		}
+	}
+
+	return 0, false
+}
+
+func compareOrdered[T cmp.Ordered](a T, b any) (int, bool) {
+	bv, ok := b.(T)
+	if !ok {
+		return 0, false
+	}
+
+	return cmp.Compare(a, bv), true
+}
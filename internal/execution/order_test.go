@@ -0,0 +1,64 @@
+package execution //nolint:testpackage // orders aren't exported
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyOrders(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	schema := model.DatasetSchema{
+		Columns: []model.TargetType{
+			{SourceName: model.PGIdentifier("ends_at")},
+			{SourceName: model.PGIdentifier("starts_at")},
+			{SourceName: model.PGIdentifier("lo")},
+			{SourceName: model.PGIdentifier("hi")},
+		},
+		ColumnOrders: []model.ColumnOrder{
+			{Lesser: model.PGIdentifier("starts_at"), Greater: model.PGIdentifier("ends_at"), Strict: true},
+			{Lesser: model.PGIdentifier("lo"), Greater: model.PGIdentifier("hi"), Strict: false},
+			{Lesser: model.PGIdentifier("lo"), Greater: model.PGIdentifier("unknown"), Strict: false},
+		},
+	}
+	orders := resolveOrders(model.Task{DatasetSchema: schema})
+	require.Len(t, orders, 2)
+
+	now := time.Now()
+	row := []any{now, now.Add(time.Hour), int64(5), int64(3)}
+	require.True(t, applyOrders(row, orders))
+	require.Equal(t, []any{now.Add(time.Hour), now, int64(3), int64(5)}, row)
+
+	// a swap can't make equal values strictly ordered
+	row = []any{now, now, int64(3), int64(3)}
+	require.False(t, applyOrders(row, orders))
+
+	// nulls and mismatched types are left as they are
+	row = []any{nil, now, int64(5), int32(3)}
+	require.True(t, applyOrders(row, orders))
+	require.Equal(t, []any{nil, now, int64(5), int32(3)}, row)
+
+	// values of user configured columns and of columns derived ones read aren't swapped
+	orders = resolveOrders(model.Task{
+		DatasetSchema: schema,
+		Configured:    []bool{true, false, false, false},
+		RowColumns:    map[string]int{"hi": 3},
+	})
+
+	row = []any{now, now.Add(time.Hour), int64(5), int64(3)}
+	require.False(t, applyOrders(row, orders))
+	require.Equal(t, []any{now, now.Add(time.Hour), int64(5), int64(3)}, row)
+
+	// values aren't swapped between columns with different bounds
+	schema.Columns[3].Check = &model.ColumnCheck{Max: &model.CheckBound{Value: "10", Inclusive: true}}
+	orders = resolveOrders(model.Task{DatasetSchema: schema})
+
+	row = []any{now.Add(time.Hour), now, int64(20), int64(3)}
+	require.False(t, applyOrders(row, orders))
+	require.Equal(t, []any{now.Add(time.Hour), now, int64(20), int64(3)}, row)
+}
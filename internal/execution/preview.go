@@ -0,0 +1,35 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// Preview generates rows of the task the way Execute does, but nothing is saved and the limiter
+// isn't asked. Generators of the task write what they write themselves, so tasks to preview are built
+// with acceptors of a dry run. Rows generated before a failure are returned along with the error.
+func Preview(ctx context.Context, task model.Task, rows int) ([][]any, error) {
+	defer func() {
+		for i := range task.Generators {
+			task.Generators[i].Close()
+		}
+	}()
+
+	orders := resolveOrders(task)
+	rowGen := newRowGenerator(task)
+	rowCtx := model.WithRow(ctx, rowGen.row)
+
+	out := make([][]any, 0, rows)
+	for range rows {
+		row := make([]any, len(task.Generators))
+		if err := genOrdered(rowCtx, rowGen, row, orders); err != nil {
+			return out, fmt.Errorf("%w: preview %s", err, task.DatasetSchema.TableName.Quoted())
+		}
+
+		out = append(out, row)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,119 @@
+package execution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/execution"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errExhausted = errors.New("exhausted")
+
+type countGenerator struct {
+	n, limit int
+	closed   bool
+}
+
+func (c *countGenerator) Gen(_ context.Context) (any, error) {
+	if c.n == c.limit {
+		return nil, errExhausted
+	}
+	c.n++
+
+	return c.n, nil
+}
+
+func (c *countGenerator) Close() {
+	c.closed = true
+}
+
+func Test_Preview(t *testing.T) {
+	t.Parallel()
+
+	id, code := &countGenerator{n: 0, limit: 10, closed: false}, &countGenerator{n: 0, limit: 2, closed: false}
+
+	//nolint:exhaustruct // ok for tests
+	task := model.Task{
+		DatasetSchema: model.DatasetSchema{
+			Columns: []model.TargetType{
+				{SourceName: model.PGIdentifier("id")},
+				{SourceName: model.PGIdentifier("code")},
+			},
+		},
+		Generators: []model.Generator{id, code},
+	}
+
+	rows, err := execution.Preview(t.Context(), task, 2)
+	require.NoError(t, err)
+	require.Equal(t, [][]any{{1, 1}, {2, 2}}, rows)
+	require.True(t, id.closed)
+	require.True(t, code.closed)
+
+	id.n, code.n = 0, 0
+	rows, err = execution.Preview(t.Context(), task, 5)
+	require.ErrorIs(t, err, errExhausted)
+	require.Len(t, rows, 2)
+}
+
+type constGenerator struct {
+	val any
+}
+
+func (c constGenerator) Gen(_ context.Context) (any, error) {
+	return c.val, nil
+}
+
+func (constGenerator) Close() {}
+
+func Test_PreviewRegeneratesOrders(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	task := model.Task{
+		DatasetSchema: model.DatasetSchema{
+			Columns: []model.TargetType{
+				{
+					SourceName: model.PGIdentifier("lo"),
+					Check:      &model.ColumnCheck{Min: &model.CheckBound{Value: "3", Inclusive: true}},
+				},
+				{SourceName: model.PGIdentifier("hi")},
+			},
+			ColumnOrders: []model.ColumnOrder{
+				{Lesser: model.PGIdentifier("lo"), Greater: model.PGIdentifier("hi"), Strict: true},
+			},
+		},
+		// lo has its own bounds, so lesser values of hi are generated anew rather than swapped
+		Generators: []model.Generator{constGenerator{val: 3}, &countGenerator{n: 0, limit: 10, closed: false}},
+	}
+
+	rows, err := execution.Preview(t.Context(), task, 2)
+	require.NoError(t, err)
+	require.Equal(t, [][]any{{3, 4}, {3, 5}}, rows)
+}
+
+func Test_PreviewFailsUnsatisfiedOrders(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	task := model.Task{
+		DatasetSchema: model.DatasetSchema{
+			Columns: []model.TargetType{
+				{SourceName: model.PGIdentifier("lo")},
+				{SourceName: model.PGIdentifier("hi")},
+			},
+			ColumnOrders: []model.ColumnOrder{
+				{Lesser: model.PGIdentifier("lo"), Greater: model.PGIdentifier("hi"), Strict: false},
+			},
+		},
+		Generators: []model.Generator{constGenerator{val: 5}, constGenerator{val: 3}},
+		// user configured values are generated anew rather than swapped
+		Configured: []bool{true, true},
+	}
+
+	_, err := execution.Preview(t.Context(), task, 1)
+	require.ErrorIs(t, err, execution.ErrColumnOrderUnsatisfied)
+}
@@ -0,0 +1,50 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// rowGenerator fills rows column by column in the generation order of the task,
+// derived generators read values generated before them out of the row.
+type rowGenerator struct {
+	gens    []model.Generator
+	columns []model.TargetType
+	order   []int
+	row     *model.Row
+}
+
+func newRowGenerator(task model.Task) rowGenerator {
+	order := task.GenOrder
+	if order == nil {
+		order = make([]int, len(task.Generators))
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	return rowGenerator{
+		gens:    task.Generators,
+		columns: task.DatasetSchema.Columns,
+		order:   order,
+		row:     model.NewRow(task.RowColumns),
+	}
+}
+
+// gen fills values, ctx has to carry the row of the generator.
+func (r rowGenerator) gen(ctx context.Context, values []any) error {
+	r.row.Reset(values)
+
+	for _, i := range r.order {
+		cell, err := r.gens[i].Gen(ctx)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, r.columns[i].SourceName.AsArgument())
+		}
+
+		values[i] = cell
+	}
+
+	return nil
+}
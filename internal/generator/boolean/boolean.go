@@ -8,15 +8,16 @@ import (
 )
 
 type generator struct {
+	rnd         *rand.Rand
 	truePercent int
 }
 
-func NewBoolean(truePercent int) model.Generator {
-	return generator{truePercent: truePercent}
+func NewBoolean(rnd *rand.Rand, truePercent int) model.Generator {
+	return generator{rnd: rnd, truePercent: truePercent}
 }
 
 func (g generator) Gen(_ context.Context) (any, error) {
-	return rand.IntN(100) <= g.truePercent, nil
+	return g.rnd.IntN(100) <= g.truePercent, nil
 }
 
 func (g generator) Close() {}
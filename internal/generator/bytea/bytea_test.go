@@ -4,12 +4,12 @@ import (
 	"testing"
 
 	"github.com/c2h5oh/datasize"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 	"github.com/stretchr/testify/require"
 )
 
 func Test(t *testing.T) {
-	gen := NewAroundByteaGenerator(datasize.KB*10, datasize.B*100)
-
+	gen := NewAroundByteaGenerator(xrand.Unseeded(), datasize.KB*10, datasize.B*100)
 	_, err := gen.Gen(t.Context())
 	require.NoError(t, err)
 }
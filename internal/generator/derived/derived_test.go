@@ -0,0 +1,91 @@
+package derived_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/generator/derived"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Eval(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := map[string]any{
+		"qty":        int32(3),
+		"price":      2.5,
+		"first":      "Ada",
+		"last":       []byte("Lovelace"),
+		"created_at": created,
+		"note":       nil,
+		"Weird Name": int64(7),
+	}
+	value := func(column string) (any, bool) {
+		val, ok := row[column]
+
+		return val, ok
+	}
+
+	for _, tC := range []struct {
+		expr     string
+		expected any
+	}{
+		{expr: "qty * price", expected: 7.5},
+		{expr: "qty / 2 + qty % 2", expected: int64(2)},
+		{expr: "-(qty - 5) * 2", expected: int64(4)},
+		{expr: "first || ' ' || upper(last)", expected: "Ada LOVELACE"},
+		{expr: "created_at + days(1) + hours(2)", expected: created.Add(26 * time.Hour)},
+		{expr: "created_at + hours(1) - created_at", expected: time.Hour},
+		{expr: "note || 'x'", expected: nil},
+		{expr: "coalesce(note, 'none')", expected: "none"},
+		{expr: "qty > 2 and not price >= 3", expected: true},
+		{expr: "note = 1 or true", expected: true},
+		{expr: "if(qty <> 3, 'a', 'b')", expected: "b"},
+		{expr: "round(price * 1.111, 2)", expected: 2.78},
+		{expr: `"Weird Name" + length('abc')`, expected: int64(10)},
+		{expr: "greatest(qty, 1, 10) - least(qty, price)", expected: 7.5},
+		{expr: "'it''s'", expected: "it's"},
+	} {
+		expr, err := derived.Parse(tC.expr)
+		require.NoError(t, err, tC.expr)
+
+		val, err := expr.Eval(value, xrand.New(1))
+		require.NoError(t, err, tC.expr)
+		require.Equal(t, tC.expected, val, tC.expr)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	expr, err := derived.Parse(`first || "Last Name" || first || upper(city)`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "Last Name", "city"}, expr.Columns())
+
+	for _, src := range []string{"qty *", "(qty", "nope(qty)", "upper()", "'open", "qty $ 2"} {
+		_, err := derived.Parse(src)
+		require.ErrorIs(t, err, derived.ErrInvalidExpression, src)
+	}
+}
+
+func Test_Generator(t *testing.T) {
+	t.Parallel()
+
+	gen, err := derived.NewGenerator(xrand.New(1), "qty * 1.5 + rand_int(0, 0)", model.Integer)
+	require.NoError(t, err)
+	require.Equal(t, []string{"qty"}, gen.DependsOn())
+
+	_, err = gen.Gen(t.Context())
+	require.ErrorIs(t, err, derived.ErrNoRow)
+
+	row := model.NewRow(map[string]int{"qty": 1})
+	row.Reset([]any{nil, int64(3)})
+
+	val, err := gen.Gen(model.WithRow(t.Context(), row))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), val)
+}
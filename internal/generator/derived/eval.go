@@ -0,0 +1,363 @@
+package derived
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrUnknownColumn  = errors.New("column isn't in the row")
+	ErrMismatchedType = errors.New("mismatched types")
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+type env struct {
+	value func(column string) (any, bool)
+	rnd   *rand.Rand
+}
+
+// Eval computes the expression for a row, value reads columns of it.
+func (e *Expr) Eval(value func(column string) (any, bool), rnd *rand.Rand) (any, error) {
+	val, err := e.root.eval(&env{value: value, rnd: rnd})
+	if err != nil {
+		return nil, fmt.Errorf("%w: eval", err)
+	}
+
+	return val, nil
+}
+
+func (l literal) eval(_ *env) (any, error) {
+	return l.val, nil
+}
+
+func (c column) eval(env *env) (any, error) {
+	val, ok := env.value(c.name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, c.name)
+	}
+
+	return normalize(val), nil
+}
+
+func (u unary) eval(env *env) (any, error) {
+	x, err := u.x.eval(env)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	switch v := x.(type) {
+	case bool:
+		if u.op == "not" {
+			return !v, nil
+		}
+	case int64:
+		if u.op == "-" {
+			return -v, nil
+		}
+	case float64:
+		if u.op == "-" {
+			return -v, nil
+		}
+	case time.Duration:
+		if u.op == "-" {
+			return -v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %T", ErrMismatchedType, u.op, x)
+}
+
+func (b binary) eval(env *env) (any, error) {
+	l, err := b.l.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := b.r.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.op {
+	case "and", "or":
+		return logical(b.op, l, r)
+	case "||":
+		if l == nil || r == nil {
+			return nil, nil //nolint:nilnil // null like in sql
+		}
+
+		return text(l) + text(r), nil
+	case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+		return comparison(b.op, l, r)
+	default:
+		return arithmetic(b.op, l, r)
+	}
+}
+
+func (c call) eval(env *env) (any, error) {
+	args := make([]any, len(c.args))
+	for i, arg := range c.args {
+		val, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = val
+	}
+
+	val, err := c.fn.call(env, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, c.name)
+	}
+
+	return val, nil
+}
+
+// normalize brings column values to the few types expressions work with.
+func normalize(val any) any {
+	switch v := val.(type) {
+	case nil, bool, string, int64, float64, time.Time, time.Duration:
+		return v
+	case float32:
+		return float64(v)
+	case decimal.Decimal:
+		return v.InexactFloat64()
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() { //nolint:exhaustive // the rest is formatted
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()) //nolint:gosec // generated values fit
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return normalize(rv.Elem().Interface())
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// text formats values for concatenation.
+func text(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// logical follows sql: unknown only decides the result when the other side doesn't.
+func logical(op string, l, r any) (any, error) {
+	lb, lok := l.(bool)
+	rb, rok := r.(bool)
+	if l != nil && !lok || r != nil && !rok {
+		return nil, fmt.Errorf("%w: %T %s %T", ErrMismatchedType, l, op, r)
+	}
+
+	decisive := op == "or"
+	switch {
+	case lok && lb == decisive, rok && rb == decisive:
+		return decisive, nil
+	case l == nil || r == nil:
+		return nil, nil //nolint:nilnil // null like in sql
+	default:
+		return !decisive, nil
+	}
+}
+
+func comparison(op string, l, r any) (any, error) {
+	if l == nil || r == nil {
+		return nil, nil //nolint:nilnil // null like in sql
+	}
+
+	var c int
+	lb, lbool := l.(bool)
+	rb, rbool := r.(bool)
+	switch {
+	case lbool && rbool && lb == rb:
+		c = 0
+	case lbool && rbool && op != "<" && op != ">" && op != "<=" && op != ">=":
+		c = 1
+	default:
+		var err error
+		if c, err = compare(l, r); err != nil {
+			return nil, err
+		}
+	}
+
+	switch op {
+	case "=", "==":
+		return c == 0, nil
+	case "!=", "<>":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+func compare(l, r any) (int, error) {
+	if lf, rf, ok := numbers(l, r); ok {
+		return cmpOrdered(lf, rf), nil
+	}
+
+	switch lv := l.(type) {
+	case string:
+		if rv, ok := r.(string); ok {
+			return strings.Compare(lv, rv), nil
+		}
+	case time.Time:
+		if rv, ok := r.(time.Time); ok {
+			return lv.Compare(rv), nil
+		}
+	case time.Duration:
+		if rv, ok := r.(time.Duration); ok {
+			return cmpOrdered(lv, rv), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: can't compare %T and %T", ErrMismatchedType, l, r)
+}
+
+func cmpOrdered[T int64 | float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// numbers gives both values as floats if they are numbers.
+func numbers(l, r any) (float64, float64, bool) {
+	lf, lok := number64(l)
+	rf, rok := number64(r)
+
+	return lf, rf, lok && rok
+}
+
+func number64(val any) (float64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+//nolint:cyclop // a case per pair of types
+func arithmetic(op string, l, r any) (any, error) {
+	if l == nil || r == nil {
+		return nil, nil //nolint:nilnil // null like in sql
+	}
+
+	li, lint := l.(int64)
+	ri, rint := r.(int64)
+	if lint && rint {
+		return integers(op, li, ri)
+	}
+
+	if lf, rf, ok := numbers(l, r); ok {
+		return floats(op, lf, rf)
+	}
+
+	lt, ltime := l.(time.Time)
+	rt, rtime := r.(time.Time)
+	ld, ldur := l.(time.Duration)
+	rd, rdur := r.(time.Duration)
+
+	switch {
+	case ltime && rdur && op == "+":
+		return lt.Add(rd), nil
+	case ldur && rtime && op == "+":
+		return rt.Add(ld), nil
+	case ltime && rdur && op == "-":
+		return lt.Add(-rd), nil
+	case ltime && rtime && op == "-":
+		return lt.Sub(rt), nil
+	case ldur && rdur && op == "+":
+		return ld + rd, nil
+	case ldur && rdur && op == "-":
+		return ld - rd, nil
+	case ldur && rint && op == "*":
+		return ld * time.Duration(ri), nil
+	case lint && rdur && op == "*":
+		return time.Duration(li) * rd, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T %s %T", ErrMismatchedType, l, op, r)
+}
+
+// integers divide like sql does, dropping the fraction.
+func integers(op string, l, r int64) (any, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	}
+
+	if r == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	if op == "/" {
+		return l / r, nil
+	}
+
+	return l % r, nil
+}
+
+func floats(op string, l, r float64) (any, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	}
+
+	if r == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	if op == "/" {
+		return l / r, nil
+	}
+
+	return math.Mod(l, r), nil
+}
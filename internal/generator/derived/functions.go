@@ -0,0 +1,199 @@
+package derived
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type function struct {
+	minArgs, maxArgs int
+	call             func(env *env, args []any) (any, error)
+}
+
+func (f function) arity() string {
+	switch {
+	case f.maxArgs == -1:
+		return fmt.Sprintf("%d or more", f.minArgs)
+	case f.minArgs == f.maxArgs:
+		return strconv.Itoa(f.minArgs)
+	default:
+		return fmt.Sprintf("%d to %d", f.minArgs, f.maxArgs)
+	}
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var functions = map[string]function{
+	"coalesce": {minArgs: 1, maxArgs: -1, call: func(_ *env, args []any) (any, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+
+		return nil, nil //nolint:nilnil // null like in sql
+	}},
+	"if": {minArgs: 3, maxArgs: 3, call: func(_ *env, args []any) (any, error) {
+		if cond, ok := args[0].(bool); ok && cond {
+			return args[1], nil
+		}
+
+		return args[2], nil
+	}},
+	"least":    {minArgs: 1, maxArgs: -1, call: extremum(-1)},
+	"greatest": {minArgs: 1, maxArgs: -1, call: extremum(1)},
+	"upper":    {minArgs: 1, maxArgs: 1, call: stringFn(strings.ToUpper)},
+	"lower":    {minArgs: 1, maxArgs: 1, call: stringFn(strings.ToLower)},
+	"trim":     {minArgs: 1, maxArgs: 1, call: stringFn(strings.TrimSpace)},
+	"length": {minArgs: 1, maxArgs: 1, call: func(_ *env, args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil //nolint:nilnil // null like in sql
+		}
+
+		return int64(utf8.RuneCountInString(text(args[0]))), nil
+	}},
+	"abs": {minArgs: 1, maxArgs: 1, call: func(_ *env, args []any) (any, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return nil, nil //nolint:nilnil // null like in sql
+		case int64:
+			return max(v, -v), nil
+		case float64:
+			return math.Abs(v), nil
+		}
+
+		return nil, fmt.Errorf("%w: abs of %T", ErrMismatchedType, args[0])
+	}},
+	"round": {minArgs: 1, maxArgs: 2, call: round},
+	"floor": {minArgs: 1, maxArgs: 1, call: floatFn(math.Floor)},
+	"ceil":  {minArgs: 1, maxArgs: 1, call: floatFn(math.Ceil)},
+	"text": {minArgs: 1, maxArgs: 1, call: func(_ *env, args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil //nolint:nilnil // null like in sql
+		}
+
+		return text(args[0]), nil
+	}},
+	"seconds":    {minArgs: 1, maxArgs: 1, call: duration(time.Second)},
+	"minutes":    {minArgs: 1, maxArgs: 1, call: duration(time.Minute)},
+	"hours":      {minArgs: 1, maxArgs: 1, call: duration(time.Hour)},
+	"days":       {minArgs: 1, maxArgs: 1, call: duration(24 * time.Hour)},
+	"rand_int":   {minArgs: 2, maxArgs: 2, call: randInt},
+	"rand_float": {minArgs: 2, maxArgs: 2, call: randFloat},
+}
+
+func extremum(sign int) func(_ *env, args []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		var best any
+		for _, arg := range args {
+			if arg == nil {
+				continue
+			}
+
+			if best == nil {
+				best = arg
+
+				continue
+			}
+
+			c, err := compare(arg, best)
+			if err != nil {
+				return nil, err
+			}
+
+			if c*sign > 0 {
+				best = arg
+			}
+		}
+
+		return best, nil
+	}
+}
+
+func stringFn(fn func(string) string) func(_ *env, args []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		if args[0] == nil {
+			return nil, nil //nolint:nilnil // null like in sql
+		}
+
+		return fn(text(args[0])), nil
+	}
+}
+
+func floatFn(fn func(float64) float64) func(_ *env, args []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return nil, nil //nolint:nilnil // null like in sql
+		case int64:
+			return v, nil
+		case float64:
+			return fn(v), nil
+		}
+
+		return nil, fmt.Errorf("%w: %T isn't a number", ErrMismatchedType, args[0])
+	}
+}
+
+// round rounds half away from zero to a number of decimal digits, zero by default.
+func round(_ *env, args []any) (any, error) {
+	digits := int64(0)
+	if len(args) == 2 {
+		d, ok := args[1].(int64)
+		if !ok {
+			return nil, fmt.Errorf("%w: digits are %T", ErrMismatchedType, args[1])
+		}
+		digits = d
+	}
+
+	switch v := args[0].(type) {
+	case nil:
+		return nil, nil //nolint:nilnil // null like in sql
+	case int64:
+		return v, nil
+	case float64:
+		scale := math.Pow10(int(digits))
+
+		return math.Round(v*scale) / scale, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T isn't a number", ErrMismatchedType, args[0])
+}
+
+func duration(unit time.Duration) func(_ *env, args []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return nil, nil //nolint:nilnil // null like in sql
+		case int64:
+			return time.Duration(v) * unit, nil
+		case float64:
+			return time.Duration(v * float64(unit)), nil
+		}
+
+		return nil, fmt.Errorf("%w: %T isn't a number", ErrMismatchedType, args[0])
+	}
+}
+
+// randInt draws from the inclusive range with the column's random source.
+func randInt(env *env, args []any) (any, error) {
+	lo, lok := args[0].(int64)
+	hi, hok := args[1].(int64)
+	if !lok || !hok || lo > hi {
+		return nil, fmt.Errorf("%w: rand_int(%v, %v)", ErrMismatchedType, args[0], args[1])
+	}
+
+	return lo + env.rnd.Int64N(hi-lo+1), nil
+}
+
+func randFloat(env *env, args []any) (any, error) {
+	lo, hi, ok := numbers(args[0], args[1])
+	if !ok || lo > hi {
+		return nil, fmt.Errorf("%w: rand_float(%v, %v)", ErrMismatchedType, args[0], args[1])
+	}
+
+	return lo + env.rnd.Float64()*(hi-lo), nil
+}
@@ -0,0 +1,101 @@
+package derived
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrNoRow = errors.New("derived values need the row they belong to")
+
+type Generator struct {
+	expr   *Expr
+	rnd    *rand.Rand
+	target model.CommonType
+}
+
+// NewGenerator evaluates src over the row being generated, results are converted to text,
+// integer and float columns, other columns take them as they are.
+func NewGenerator(rnd *rand.Rand, src string, target model.CommonType) (*Generator, error) {
+	expr, err := Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new derived generator", err)
+	}
+
+	return &Generator{expr: expr, rnd: rnd, target: target}, nil
+}
+
+func (g *Generator) DependsOn() []string {
+	return g.expr.Columns()
+}
+
+func (g *Generator) Gen(ctx context.Context) (any, error) {
+	const fnName = "derived gen"
+
+	row, ok := model.RowFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRow, fnName)
+	}
+
+	val, err := g.expr.Eval(row.Value, g.rnd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	val, err = convert(val, g.target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return val, nil
+}
+
+func (g *Generator) Close() {}
+
+func convert(val any, target model.CommonType) (any, error) {
+	if val == nil {
+		return nil, nil //nolint:nilnil // null
+	}
+
+	switch target { //nolint:exhaustive // the rest is taken as it is
+	case model.Text:
+		return text(val), nil
+	case model.Integer:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(math.Round(v)), nil
+		case string:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q isn't an integer", ErrMismatchedType, v)
+			}
+
+			return n, nil
+		}
+	case model.Float:
+		switch v := val.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q isn't a number", ErrMismatchedType, v)
+			}
+
+			return f, nil
+		}
+	default:
+		return val, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T for %s column", ErrMismatchedType, val, target)
+}
@@ -0,0 +1,113 @@
+package derived
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	pos    int
+	quoted bool
+}
+
+// operators are matched longest first.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var operators = []string{"||", "<=", ">=", "<>", "!=", "==", "+", "-", "*", "/", "%", "=", "<", ">", "(", ")", ","}
+
+// lex splits an expression into tokens, strings are 'single quoted' and quoted identifiers are "double quoted".
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(src); {
+		r := rune(src[pos])
+
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+		case r == '\'' || r == '"':
+			text, end, err := quoted(src, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			kind := tokenString
+			if r == '"' {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos, quoted: true})
+			pos = end
+		case unicode.IsDigit(r) || r == '.' && pos+1 < len(src) && unicode.IsDigit(rune(src[pos+1])):
+			end := pos
+			for end < len(src) && (unicode.IsDigit(rune(src[end])) || src[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[pos:end], pos: pos, quoted: false})
+			pos = end
+		case unicode.IsLetter(r) || r == '_':
+			end := pos
+			for end < len(src) && (unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end])) || src[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos, quoted: false})
+			pos = end
+		default:
+			op, ok := operator(src[pos:])
+			if !ok {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidExpression, r, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos, quoted: false})
+			pos += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, text: "", pos: len(src), quoted: false}), nil
+}
+
+// quoted reads a quoted string at pos, the quote is escaped by doubling it like in sql.
+func quoted(src string, pos int) (string, int, error) {
+	quote := src[pos]
+
+	var builder strings.Builder
+	for i := pos + 1; i < len(src); i++ {
+		if src[i] != quote {
+			builder.WriteByte(src[i])
+
+			continue
+		}
+
+		if i+1 < len(src) && src[i+1] == quote {
+			builder.WriteByte(quote)
+			i++
+
+			continue
+		}
+
+		return builder.String(), i + 1, nil
+	}
+
+	return "", 0, fmt.Errorf("%w: unclosed quote at %d", ErrInvalidExpression, pos)
+}
+
+func operator(src string) (string, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(src, op) {
+			return op, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,297 @@
+package derived
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidExpression = errors.New("invalid expression")
+
+type node interface {
+	eval(env *env) (any, error)
+}
+
+type (
+	literal struct{ val any }
+	column  struct{ name string }
+	unary   struct {
+		op string
+		x  node
+	}
+	binary struct {
+		op   string
+		l, r node
+	}
+	call struct {
+		name string
+		fn   function
+		args []node
+	}
+)
+
+// Expr is a parsed expression over columns of the row.
+type Expr struct {
+	root    node
+	columns []string
+}
+
+// Parse reads an sql-like expression: columns, 'strings', numbers, null, true, false, arithmetic,
+// || concatenation, comparisons, and, or, not and function calls.
+func Parse(src string) (*Expr, error) {
+	const fnName = "parse expression"
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	p := &parser{tokens: tokens, pos: 0, columns: nil}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at %d: %s", ErrInvalidExpression, tok.text, tok.pos, fnName)
+	}
+
+	return &Expr{root: root, columns: p.columns}, nil
+}
+
+// Columns are the columns the expression reads, in the order they first appear.
+func (e *Expr) Columns() []string {
+	return e.columns
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	columns []string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) keyword(word string) bool {
+	// a quoted "and" is a column, not the operator
+	tok := p.peek()
+	if tok.kind == tokenIdent && !tok.quoted && strings.EqualFold(tok.text, word) {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) operator(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind == tokenOperator && slices.Contains(ops, tok.text) {
+		p.pos++
+
+		return tok.text, true
+	}
+
+	return "", false
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLeft(p.parseAnd, func() (string, bool) {
+		return "or", p.keyword("or")
+	})
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLeft(p.parseNot, func() (string, bool) {
+		return "and", p.keyword("and")
+	})
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.keyword("not") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return unary{op: "not", x: x}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	return p.parseLeft(p.parseConcat, func() (string, bool) {
+		return p.operator("=", "==", "!=", "<>", "<", "<=", ">", ">=")
+	})
+}
+
+func (p *parser) parseConcat() (node, error) {
+	return p.parseLeft(p.parseAdditive, func() (string, bool) {
+		return p.operator("||")
+	})
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseLeft(p.parseMultiplicative, func() (string, bool) {
+		return p.operator("+", "-")
+	})
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseLeft(p.parseUnary, func() (string, bool) {
+		return p.operator("*", "/", "%")
+	})
+}
+
+// parseLeft parses left associative chains of operands joined by operators.
+func (p *parser) parseLeft(operand func() (node, error), op func() (string, bool)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		name, ok := op()
+		if !ok {
+			return left, nil
+		}
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{op: name, l: left, r: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if _, ok := p.operator("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return unary{op: "-", x: x}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		return number(tok)
+	case tokenString:
+		return literal{val: tok.text}, nil
+	case tokenOperator:
+		if tok.text != "(" {
+			break
+		}
+
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := p.operator(")"); !ok {
+			return nil, fmt.Errorf("%w: ( at %d isn't closed", ErrInvalidExpression, tok.pos)
+		}
+
+		return x, nil
+	case tokenIdent:
+		return p.parseIdent(tok)
+	case tokenEOF:
+	}
+
+	return nil, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidExpression, tok.text, tok.pos)
+}
+
+func (p *parser) parseIdent(tok token) (node, error) {
+	if !tok.quoted {
+		switch strings.ToLower(tok.text) {
+		case "null":
+			return literal{val: nil}, nil
+		case "true":
+			return literal{val: true}, nil
+		case "false":
+			return literal{val: false}, nil
+		}
+
+		if _, ok := p.operator("("); ok {
+			return p.parseCall(tok)
+		}
+	}
+
+	if !slices.Contains(p.columns, tok.text) {
+		p.columns = append(p.columns, tok.text)
+	}
+
+	return column{name: tok.text}, nil
+}
+
+func (p *parser) parseCall(tok token) (node, error) {
+	name := strings.ToLower(tok.text)
+
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %s at %d", ErrInvalidExpression, tok.text, tok.pos)
+	}
+
+	var args []node
+	if _, ok := p.operator(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if _, ok := p.operator(")"); ok {
+				break
+			}
+
+			if _, ok := p.operator(","); !ok {
+				return nil, fmt.Errorf("%w: %s at %d expects , or )", ErrInvalidExpression, tok.text, tok.pos)
+			}
+		}
+	}
+
+	if len(args) < fn.minArgs || fn.maxArgs != -1 && len(args) > fn.maxArgs {
+		return nil, fmt.Errorf("%w: %s takes %s arguments, got %d", ErrInvalidExpression, name, fn.arity(), len(args))
+	}
+
+	return call{name: name, fn: fn, args: args}, nil
+}
+
+func number(tok token) (node, error) {
+	if !strings.Contains(tok.text, ".") {
+		if v, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+			return literal{val: v}, nil
+		}
+	}
+
+	v, err := strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: number %q at %d", ErrInvalidExpression, tok.text, tok.pos)
+	}
+
+	return literal{val: v}, nil
+}
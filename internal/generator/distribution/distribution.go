@@ -0,0 +1,187 @@
+package distribution
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+var (
+	ErrUnknownKind       = errors.New("distribution kind is unknown")
+	ErrInvalidParameters = errors.New("distribution parameters are invalid")
+)
+
+const (
+	KindUniform     = "uniform"
+	KindNormal      = "normal"
+	KindLogNormal   = "lognormal"
+	KindExponential = "exponential"
+	KindZipf        = "zipf"
+	KindPareto      = "pareto"
+	KindHistogram   = "histogram"
+)
+
+// Params mirror config.Distribution. Unset parameters take defaults fitted to the range.
+type Params struct {
+	Kind string
+	// Mean and StdDev are of the value for normal and of its logarithm for lognormal.
+	Mean   *float64
+	StdDev *float64
+	// Rate is lambda of exponential.
+	Rate *float64
+	// S is the exponent of zipf, it must be above 1.
+	S *float64
+	// Alpha and Scale shape pareto.
+	Alpha *float64
+	Scale *float64
+	// Bounds split the range into buckets picked with Weights for histogram.
+	Bounds  []float64
+	Weights []int
+}
+
+// Sampler draws positions within [min, max], the ones falling outside are clamped.
+// Kinds other than normal, uniform and histogram count positions from min.
+type Sampler struct {
+	draw     func() float64
+	min, max float64
+}
+
+func New(rnd *rand.Rand, params Params, minV, maxV float64) (*Sampler, error) {
+	const fnName = "new distribution"
+
+	if minV > maxV {
+		return nil, fmt.Errorf("%w: min %v is above max %v %s", ErrInvalidParameters, minV, maxV, fnName)
+	}
+
+	// half of the span doesn't overflow for the whole float64 range
+	halfSpan := maxV/2 - minV/2
+
+	draw, err := newDraw(rnd, params, minV, halfSpan)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s", err, params.Kind, fnName)
+	}
+
+	return &Sampler{draw: draw, min: minV, max: maxV}, nil
+}
+
+func (s *Sampler) Sample() float64 {
+	v := s.draw()
+	if math.IsNaN(v) {
+		return s.min
+	}
+
+	return min(max(v, s.min), s.max)
+}
+
+//nolint:cyclop // a case per kind
+func newDraw(rnd *rand.Rand, params Params, minV, halfSpan float64) (func() float64, error) {
+	switch params.Kind {
+	case "", KindUniform:
+		return func() float64 { return minV + 2*rnd.Float64()*halfSpan }, nil
+	case KindNormal:
+		mean, stdDev := orDefault(params.Mean, minV+halfSpan), orDefault(params.StdDev, halfSpan/3)
+		if stdDev < 0 {
+			return nil, fmt.Errorf("%w: stddev %v", ErrInvalidParameters, stdDev)
+		}
+
+		return func() float64 { return mean + rnd.NormFloat64()*stdDev }, nil
+	case KindLogNormal:
+		mu, sigma := orDefault(params.Mean, 0), orDefault(params.StdDev, 1)
+		if sigma < 0 {
+			return nil, fmt.Errorf("%w: stddev %v", ErrInvalidParameters, sigma)
+		}
+
+		return func() float64 { return minV + math.Exp(mu+rnd.NormFloat64()*sigma) }, nil
+	case KindExponential:
+		// by default a tenth of the range is the mean
+		rate := orDefault(params.Rate, 5/halfSpan)
+		if rate <= 0 {
+			return nil, fmt.Errorf("%w: rate %v", ErrInvalidParameters, rate)
+		}
+
+		return func() float64 { return minV + rnd.ExpFloat64()/rate }, nil
+	case KindZipf:
+		const defaultS = 1.1
+
+		s := orDefault(params.S, defaultS)
+		if s <= 1 {
+			return nil, fmt.Errorf("%w: s %v must be above 1", ErrInvalidParameters, s)
+		}
+
+		zipf := rand.NewZipf(rnd, s, 1, zipfMax(2*halfSpan))
+
+		return func() float64 { return minV + float64(zipf.Uint64()) }, nil
+	case KindPareto:
+		// 1.16 is the shape of the 80-20 rule
+		const defaultAlpha = 1.16
+
+		alpha, scale := orDefault(params.Alpha, defaultAlpha), orDefault(params.Scale, 1)
+		if alpha <= 0 || scale <= 0 {
+			return nil, fmt.Errorf("%w: alpha %v scale %v", ErrInvalidParameters, alpha, scale)
+		}
+
+		return func() float64 {
+			return minV + scale*(math.Pow(1-rnd.Float64(), -1/alpha)-1)
+		}, nil
+	case KindHistogram:
+		return histogram(rnd, params.Bounds, params.Weights)
+	default:
+		return nil, ErrUnknownKind
+	}
+}
+
+// zipfMax is the largest position of zipf, spans uint64 can't hold are clamped.
+func zipfMax(span float64) uint64 {
+	const limit = math.MaxUint64 - 1
+
+	// 2^64 is exact in float64, smaller spans convert without overflow
+	if span >= 1<<64 || math.IsNaN(span) {
+		return limit
+	}
+
+	return min(uint64(span), limit)
+}
+
+// histogram picks a bucket by its weight and a uniform position inside of it.
+func histogram(rnd *rand.Rand, bounds []float64, weights []int) (func() float64, error) {
+	if len(weights) == 0 || len(bounds) != len(weights)+1 {
+		return nil, fmt.Errorf(
+			"%w: %d bounds for %d weights, there must be one more", ErrInvalidParameters, len(bounds), len(weights),
+		)
+	}
+
+	if !slices.IsSorted(bounds) {
+		return nil, fmt.Errorf("%w: bounds %v aren't ascending", ErrInvalidParameters, bounds)
+	}
+
+	prefixSum := make([]int, len(weights))
+	total := 0
+	for i, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("%w: negative weight %d", ErrInvalidParameters, w)
+		}
+		total += w
+		prefixSum[i] = total
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("%w: weights sum up to zero", ErrInvalidParameters)
+	}
+
+	return func() float64 {
+		sector := rnd.IntN(total)
+		idx, _ := slices.BinarySearch(prefixSum, sector+1)
+
+		return bounds[idx] + rnd.Float64()*(bounds[idx+1]-bounds[idx])
+	}, nil
+}
+
+func orDefault(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+
+	return *v
+}
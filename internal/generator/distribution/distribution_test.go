@@ -0,0 +1,180 @@
+package distribution_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/generator/distribution"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func sample(t *testing.T, params distribution.Params, minV, maxV float64, n int) []float64 {
+	t.Helper()
+
+	sampler, err := distribution.New(rand.New(rand.NewPCG(1, 2)), params, minV, maxV)
+	require.NoError(t, err)
+
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = sampler.Sample()
+		require.GreaterOrEqual(t, out[i], minV)
+		require.LessOrEqual(t, out[i], maxV)
+	}
+
+	return out
+}
+
+func mean(vals []float64) float64 {
+	sum := 0.0
+	for _, v := range vals {
+		sum += v
+	}
+
+	return sum / float64(len(vals))
+}
+
+func Test_Kinds(t *testing.T) {
+	const n = 20000
+
+	testCases := []struct {
+		name     string
+		params   distribution.Params
+		min, max float64
+		mean     float64
+		delta    float64
+	}{
+		{
+			name:   "uniform",
+			params: distribution.Params{Kind: distribution.KindUniform},
+			min:    0, max: 100, mean: 50, delta: 1,
+		},
+		{
+			name:   "normal",
+			params: distribution.Params{Kind: distribution.KindNormal, Mean: lo.ToPtr(30.0), StdDev: lo.ToPtr(5.0)},
+			min:    0, max: 100, mean: 30, delta: 0.5,
+		},
+		{
+			name:   "lognormal",
+			params: distribution.Params{Kind: distribution.KindLogNormal, Mean: lo.ToPtr(1.0), StdDev: lo.ToPtr(0.5)},
+			min:    10, max: 1000, mean: 10 + math.Exp(1+0.5*0.5/2), delta: 0.2,
+		},
+		{
+			name:   "exponential",
+			params: distribution.Params{Kind: distribution.KindExponential, Rate: lo.ToPtr(0.1)},
+			min:    0, max: 1e6, mean: 10, delta: 0.5,
+		},
+		{
+			name:   "pareto",
+			params: distribution.Params{Kind: distribution.KindPareto, Alpha: lo.ToPtr(3.0), Scale: lo.ToPtr(2.0)},
+			min:    0, max: 1e6, mean: 1, delta: 0.1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vals := sample(t, tc.params, tc.min, tc.max, n)
+			require.InDelta(t, tc.mean, mean(vals), tc.delta)
+		})
+	}
+}
+
+func Test_ZipfSkewsToMin(t *testing.T) {
+	vals := sample(t, distribution.Params{Kind: distribution.KindZipf, S: lo.ToPtr(2.0)}, 5, 1000, 10000)
+
+	counts := make(map[float64]int)
+	for _, v := range vals {
+		require.Equal(t, math.Trunc(v), v)
+		counts[v]++
+	}
+
+	require.Greater(t, counts[5], counts[6])
+	require.Greater(t, counts[6], counts[7])
+	require.Greater(t, counts[5], len(vals)/2)
+}
+
+func Test_ZipfWideRange(t *testing.T) {
+	vals := sample(t, distribution.Params{Kind: distribution.KindZipf, S: lo.ToPtr(2.0)}, 0, math.MaxFloat64, 1000)
+
+	counts := make(map[float64]int)
+	for _, v := range vals {
+		counts[v]++
+	}
+
+	require.Greater(t, counts[0], len(vals)/2)
+}
+
+func Test_Histogram(t *testing.T) {
+	params := distribution.Params{
+		Kind:    distribution.KindHistogram,
+		Bounds:  []float64{0, 10, 20, 100},
+		Weights: []int{3, 0, 1},
+	}
+	vals := sample(t, params, 0, 100, 10000)
+
+	low := 0
+	for _, v := range vals {
+		require.False(t, v >= 10 && v < 20, "bucket of zero weight is drawn: %v", v)
+		if v < 10 {
+			low++
+		}
+	}
+
+	require.InDelta(t, 0.75, float64(low)/float64(len(vals)), 0.02)
+}
+
+// an explicit zero mean isn't the default one, which is the middle of the range
+func Test_ZeroMean(t *testing.T) {
+	params := distribution.Params{Kind: distribution.KindNormal, Mean: lo.ToPtr(0.0), StdDev: lo.ToPtr(1.0)}
+	vals := sample(t, params, -10, 100, 10000)
+	require.InDelta(t, 0, mean(vals), 0.05)
+}
+
+func Test_Clamped(t *testing.T) {
+	params := distribution.Params{Kind: distribution.KindNormal, Mean: lo.ToPtr(0.0), StdDev: lo.ToPtr(100.0)}
+	vals := sample(t, params, -1, 1, 1000)
+
+	clamped := 0
+	for _, v := range vals {
+		if v == -1 || v == 1 {
+			clamped++
+		}
+	}
+
+	require.Greater(t, clamped, 900)
+}
+
+func Test_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params distribution.Params
+		err    error
+	}{
+		{"unknown", distribution.Params{Kind: "gamma"}, distribution.ErrUnknownKind},
+		{"zipf", distribution.Params{Kind: distribution.KindZipf, S: lo.ToPtr(0.5)}, distribution.ErrInvalidParameters},
+		{
+			"normal",
+			distribution.Params{Kind: distribution.KindNormal, StdDev: lo.ToPtr(-1.0)},
+			distribution.ErrInvalidParameters,
+		},
+		{
+			"histogram bounds",
+			distribution.Params{Kind: distribution.KindHistogram, Bounds: []float64{0, 1}, Weights: []int{1, 2}},
+			distribution.ErrInvalidParameters,
+		},
+		{
+			"histogram order",
+			distribution.Params{Kind: distribution.KindHistogram, Bounds: []float64{0, 2, 1}, Weights: []int{1, 2}},
+			distribution.ErrInvalidParameters,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := distribution.New(rand.New(rand.NewPCG(1, 2)), tc.params, 0, 10)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
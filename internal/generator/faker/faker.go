@@ -0,0 +1,252 @@
+// Package faker makes realistic values out of its own word lists and the column's random source.
+// go-faker takes randomness from one package level source shared by every column and table,
+// concurrent tables would interleave on it and seeded runs wouldn't repeat, so it isn't used.
+package faker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrUnknownKind = errors.New("unknown faker kind")
+
+const (
+	KindFirstName     = "first_name"
+	KindLastName      = "last_name"
+	KindFullName      = "full_name"
+	KindEmail         = "email"
+	KindPhone         = "phone"
+	KindStreetAddress = "street_address"
+	KindCity          = "city"
+	KindCountryCode   = "country_code"
+	KindCompany       = "company"
+	KindURL           = "url"
+	KindUsername      = "username"
+	KindCreditCard    = "credit_card"
+	KindIBAN          = "iban"
+	KindSentence      = "sentence"
+	// KindDateTime is a moment of the past year as text, for text columns like created_at.
+	KindDateTime = "date_time"
+)
+
+// fakeFn makes a value, now is the reference time of the run.
+type fakeFn func(rnd *rand.Rand, now time.Time) string
+
+//nolint:gochecknoglobals // more convenient that constants here
+var kinds = map[string]fakeFn{
+	KindFirstName: func(rnd *rand.Rand, _ time.Time) string { return pick(rnd, firstNames) },
+	KindLastName:  func(rnd *rand.Rand, _ time.Time) string { return pick(rnd, lastNames) },
+	KindFullName: func(rnd *rand.Rand, _ time.Time) string {
+		return pick(rnd, firstNames) + " " + pick(rnd, lastNames)
+	},
+	KindEmail: func(rnd *rand.Rand, _ time.Time) string {
+		return strings.ToLower(pick(rnd, firstNames)+"."+pick(rnd, lastNames)) +
+			strconv.Itoa(rnd.IntN(100)) + "@" + pick(rnd, domains)
+	},
+	KindPhone: func(rnd *rand.Rand, _ time.Time) string {
+		return fmt.Sprintf("+1-%03d-555-%04d", 200+rnd.IntN(800), rnd.IntN(10000))
+	},
+	KindStreetAddress: func(rnd *rand.Rand, _ time.Time) string {
+		return strconv.Itoa(1+rnd.IntN(9999)) + " " + pick(rnd, streetNames) + " " + pick(rnd, streetSuffixes)
+	},
+	KindCity:        func(rnd *rand.Rand, _ time.Time) string { return pick(rnd, cities) },
+	KindCountryCode: func(rnd *rand.Rand, _ time.Time) string { return pick(rnd, countryCodes) },
+	KindCompany: func(rnd *rand.Rand, _ time.Time) string {
+		return pick(rnd, companyWords) + " " + pick(rnd, companySuffixes)
+	},
+	KindURL: func(rnd *rand.Rand, _ time.Time) string {
+		return "https://" + slug(pick(rnd, companyWords)) + "." + pick(rnd, domains) + "/" + pick(rnd, loremWords)
+	},
+	KindUsername: func(rnd *rand.Rand, _ time.Time) string {
+		return strings.ToLower(pick(rnd, firstNames)+"_"+pick(rnd, lastNames)) + strconv.Itoa(rnd.IntN(1000))
+	},
+	KindCreditCard: creditCard,
+	KindIBAN:       iban,
+	KindSentence:   sentence,
+	KindDateTime: func(rnd *rand.Rand, now time.Time) string {
+		const year = 365 * 24 * time.Hour
+
+		return now.UTC().Add(-time.Duration(rnd.Int64N(int64(year)))).Format(time.DateTime)
+	},
+}
+
+type generator struct {
+	rnd    *rand.Rand
+	now    time.Time
+	fake   fakeFn
+	maxLen int
+}
+
+// NewGenerator makes realistic values of kind, values longer than a positive maxLen characters are cut.
+// Moments are in the year before now.
+func NewGenerator(rnd *rand.Rand, now time.Time, kind string, maxLen int) (model.Generator, error) {
+	fake, ok := kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+
+	return generator{rnd: rnd, now: now, fake: fake, maxLen: maxLen}, nil
+}
+
+func (g generator) Gen(_ context.Context) (any, error) {
+	val := g.fake(g.rnd, g.now)
+	if g.maxLen > 0 && utf8.RuneCountInString(val) > g.maxLen {
+		val = string([]rune(val)[:g.maxLen])
+	}
+
+	return val, nil
+}
+
+func (g generator) Close() {}
+
+func pick(rnd *rand.Rand, words []string) string {
+	return words[rnd.IntN(len(words))]
+}
+
+func slug(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, " ", "-"))
+}
+
+func digits(rnd *rand.Rand, n int) string {
+	var builder strings.Builder
+	for range n {
+		builder.WriteByte(byte('0' + rnd.IntN(10)))
+	}
+
+	return builder.String()
+}
+
+func letters(rnd *rand.Rand, n int) string {
+	var builder strings.Builder
+	for range n {
+		builder.WriteByte(byte('A' + rnd.IntN(26)))
+	}
+
+	return builder.String()
+}
+
+// creditCard makes a 16 digit visa number passing the luhn check.
+func creditCard(rnd *rand.Rand, _ time.Time) string {
+	payload := "4" + digits(rnd, 14)
+
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		// digits at odd positions from the right, the check digit being at zero, are doubled
+		if (len(payload)-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return payload + strconv.Itoa((10-sum%10)%10)
+}
+
+// iban makes an iban of one of a few countries with valid check digits.
+func iban(rnd *rand.Rand, _ time.Time) string {
+	var country, bban string
+	switch rnd.IntN(3) {
+	case 0:
+		country, bban = "DE", digits(rnd, 18)
+	case 1:
+		country, bban = "GB", letters(rnd, 4)+digits(rnd, 14)
+	default:
+		country, bban = "NL", letters(rnd, 4)+digits(rnd, 10)
+	}
+
+	// the check digits make bban + country + check, with letters as 10..35, equal 1 mod 97
+	var numeric strings.Builder
+	for _, r := range bban + country + "00" {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(numeric.String(), 10)
+	check := 98 - new(big.Int).Mod(n, big.NewInt(97)).Int64()
+
+	return fmt.Sprintf("%s%02d%s", country, check, bban)
+}
+
+func sentence(rnd *rand.Rand, _ time.Time) string {
+	words := make([]string, 6+rnd.IntN(7))
+	for i := range words {
+		words[i] = pick(rnd, loremWords)
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+
+	return strings.Join(words, " ") + "."
+}
+
+// ByColumnName makes a generator for a text column with a usual name like email or created_at.
+func ByColumnName(rnd *rand.Rand, now time.Time, column string, maxLen int) (model.Generator, bool) {
+	kind, ok := KindByColumnName(column)
+	if !ok {
+		return nil, false
+	}
+
+	return generator{rnd: rnd, now: now, fake: kinds[kind], maxLen: maxLen}, true
+}
+
+// KindByColumnName guesses the kind of text columns by their usual names.
+func KindByColumnName(column string) (string, bool) {
+	name := strings.ToLower(column)
+
+	if kind, ok := columnNames[name]; ok {
+		return kind, true
+	}
+
+	for _, suffix := range columnSuffixes {
+		if strings.HasSuffix(name, suffix.suffix) {
+			return suffix.kind, true
+		}
+	}
+
+	return "", false
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var columnNames = map[string]string{
+	"first_name": KindFirstName, "firstname": KindFirstName, "given_name": KindFirstName,
+	"last_name": KindLastName, "lastname": KindLastName, "surname": KindLastName, "family_name": KindLastName,
+	"full_name": KindFullName, "fullname": KindFullName, "display_name": KindFullName,
+	"email": KindEmail, "mail": KindEmail, "e_mail": KindEmail,
+	"phone": KindPhone, "phone_number": KindPhone, "mobile": KindPhone, "telephone": KindPhone,
+	"address": KindStreetAddress, "street": KindStreetAddress, "street_address": KindStreetAddress,
+	"city": KindCity, "town": KindCity,
+	"country": KindCountryCode, "country_code": KindCountryCode,
+	"company": KindCompany, "company_name": KindCompany, "organization": KindCompany,
+	"url": KindURL, "website": KindURL, "homepage": KindURL,
+	"username": KindUsername, "user_name": KindUsername, "login": KindUsername,
+	"credit_card": KindCreditCard, "card_number": KindCreditCard, "iban": KindIBAN,
+	"description": KindSentence, "comment": KindSentence, "bio": KindSentence, "notes": KindSentence,
+}
+
+// columnSuffixes catch prefixed names like billing_email, checked in order.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var columnSuffixes = []struct {
+	suffix string
+	kind   string
+}{
+	{suffix: "_email", kind: KindEmail},
+	{suffix: "_phone", kind: KindPhone},
+	{suffix: "_url", kind: KindURL},
+	{suffix: "_city", kind: KindCity},
+	{suffix: "_iban", kind: KindIBAN},
+	{suffix: "_at", kind: KindDateTime},
+}
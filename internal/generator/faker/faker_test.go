@@ -0,0 +1,151 @@
+package faker_test
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jmozgit/datagen/internal/generator/faker"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // ok for test
+var now = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func gen(t *testing.T, kind string, maxLen int) string {
+	t.Helper()
+
+	g, err := faker.NewGenerator(xrand.New(1, kind), now, kind, maxLen)
+	require.NoError(t, err)
+
+	val, err := g.Gen(t.Context())
+	require.NoError(t, err)
+
+	str, ok := val.(string)
+	require.True(t, ok)
+
+	return str
+}
+
+func Test_Kinds(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []string{
+		faker.KindFirstName, faker.KindLastName, faker.KindFullName, faker.KindEmail, faker.KindPhone,
+		faker.KindStreetAddress, faker.KindCity, faker.KindCountryCode, faker.KindCompany, faker.KindURL,
+		faker.KindUsername, faker.KindCreditCard, faker.KindIBAN, faker.KindSentence, faker.KindDateTime,
+	} {
+		require.NotEmpty(t, gen(t, kind, 0), kind)
+	}
+
+	require.Contains(t, gen(t, faker.KindEmail, 0), "@")
+	require.Len(t, gen(t, faker.KindCountryCode, 0), 2)
+	require.True(t, strings.HasPrefix(gen(t, faker.KindURL, 0), "https://"))
+
+	_, err := faker.NewGenerator(xrand.New(1), now, "zodiac", 0)
+	require.ErrorIs(t, err, faker.ErrUnknownKind)
+}
+
+func Test_CreditCardLuhn(t *testing.T) {
+	t.Parallel()
+
+	g, err := faker.NewGenerator(xrand.New(2), now, faker.KindCreditCard, 0)
+	require.NoError(t, err)
+
+	for range 100 {
+		val, err := g.Gen(t.Context())
+		require.NoError(t, err)
+
+		number, ok := val.(string)
+		require.True(t, ok)
+		require.Len(t, number, 16)
+
+		sum := 0
+		for i := range number {
+			d := int(number[len(number)-1-i] - '0')
+			if i%2 == 1 {
+				d *= 2
+				if d > 9 {
+					d -= 9
+				}
+			}
+			sum += d
+		}
+		require.Zero(t, sum%10, number)
+	}
+}
+
+func Test_IBANChecksum(t *testing.T) {
+	t.Parallel()
+
+	g, err := faker.NewGenerator(xrand.New(3), now, faker.KindIBAN, 0)
+	require.NoError(t, err)
+
+	for range 100 {
+		val, err := g.Gen(t.Context())
+		require.NoError(t, err)
+
+		iban, ok := val.(string)
+		require.True(t, ok)
+
+		var numeric strings.Builder
+		for _, r := range iban[4:] + iban[:4] {
+			if r >= 'A' && r <= 'Z' {
+				numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+			} else {
+				numeric.WriteRune(r)
+			}
+		}
+
+		n, ok := new(big.Int).SetString(numeric.String(), 10)
+		require.True(t, ok, iban)
+		require.Equal(t, int64(1), new(big.Int).Mod(n, big.NewInt(97)).Int64(), iban)
+	}
+}
+
+func Test_DateTimeBeforeNow(t *testing.T) {
+	t.Parallel()
+
+	g, err := faker.NewGenerator(xrand.New(4), now, faker.KindDateTime, 0)
+	require.NoError(t, err)
+
+	for range 100 {
+		val, err := g.Gen(t.Context())
+		require.NoError(t, err)
+
+		moment, err := time.Parse(time.DateTime, val.(string)) //nolint:forcetypeassert // ok for test
+		require.NoError(t, err)
+		require.False(t, moment.After(now), moment)
+		require.True(t, moment.After(now.AddDate(-1, 0, -1)), moment)
+	}
+}
+
+func Test_MaxLen(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 5, utf8.RuneCountInString(gen(t, faker.KindSentence, 5)))
+}
+
+func Test_KindByColumnName(t *testing.T) {
+	t.Parallel()
+
+	for column, expected := range map[string]string{
+		"email":         faker.KindEmail,
+		"Billing_Email": faker.KindEmail,
+		"phone":         faker.KindPhone,
+		"created_at":    faker.KindDateTime,
+		"first_name":    faker.KindFirstName,
+	} {
+		kind, ok := faker.KindByColumnName(column)
+		require.True(t, ok, column)
+		require.Equal(t, expected, kind, column)
+	}
+
+	_, ok := faker.KindByColumnName("amount")
+	require.False(t, ok)
+}
@@ -0,0 +1,46 @@
+package faker
+
+//nolint:gochecknoglobals // more convenient that constants here
+var (
+	firstNames = []string{
+		"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda", "David", "Elizabeth",
+		"William", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen",
+		"Daniel", "Lisa", "Matthew", "Nancy", "Anthony", "Betty", "Mark", "Sandra", "Paul", "Ashley",
+		"Steven", "Emily", "Andrew", "Donna", "Joshua", "Michelle", "Kenneth", "Carol", "Kevin", "Amanda",
+		"Olga", "Ivan", "Anna", "Lucas", "Sofia", "Mateo", "Hana", "Kenji", "Amara", "Noah",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+		"Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin",
+		"Lee", "Perez", "Thompson", "White", "Harris", "Sanchez", "Clark", "Ramirez", "Lewis", "Robinson",
+		"Walker", "Young", "Allen", "King", "Wright", "Scott", "Torres", "Nguyen", "Hill", "Flores",
+		"Petrov", "Novak", "Schmidt", "Rossi", "Dubois", "Tanaka", "Kowalski", "Larsen", "Okafor", "Silva",
+	}
+	streetNames = []string{
+		"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake", "Hill", "Park",
+		"Sunset", "River", "Church", "Mill", "Spring", "Highland", "Forest", "Meadow", "Bridge", "King",
+	}
+	streetSuffixes = []string{"St", "Ave", "Rd", "Blvd", "Ln", "Dr", "Way", "Ct", "Pl", "Terrace"}
+	cities         = []string{
+		"New York", "London", "Paris", "Berlin", "Madrid", "Rome", "Tokyo", "Toronto", "Sydney", "Amsterdam",
+		"Chicago", "Vienna", "Prague", "Warsaw", "Lisbon", "Dublin", "Oslo", "Helsinki", "Zurich", "Seoul",
+		"Austin", "Denver", "Boston", "Seattle", "Munich", "Lyon", "Milan", "Osaka", "Melbourne", "Vancouver",
+	}
+	countryCodes = []string{
+		"US", "GB", "DE", "FR", "ES", "IT", "NL", "BE", "SE", "NO", "DK", "FI", "PL", "CZ", "AT", "CH",
+		"PT", "IE", "CA", "MX", "BR", "AR", "JP", "KR", "CN", "IN", "AU", "NZ", "ZA", "NG", "EG", "TR",
+	}
+	companyWords = []string{
+		"Acme", "Globex", "Initech", "Umbrella", "Stark", "Wayne", "Vertex", "Nimbus", "Orbit", "Pioneer",
+		"Summit", "Quantum", "Horizon", "Apex", "Blue Ridge", "Silverline", "Northwind", "Bright", "Cobalt", "Evergreen",
+	}
+	companySuffixes = []string{"Inc", "LLC", "Ltd", "Group", "Corp", "Holdings", "Partners", "Labs", "Systems", "GmbH"}
+	domains         = []string{"example.com", "example.org", "example.net", "mail.test", "corp.test"}
+	loremWords      = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+		"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+		"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+		"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+	}
+)
@@ -5,29 +5,71 @@ import (
 	"math"
 	"math/rand/v2"
 
+	"github.com/jmozgit/datagen/internal/generator/distribution"
 	"github.com/jmozgit/datagen/internal/model"
 )
 
-type float32Gen struct{}
+type float32Gen struct {
+	rnd *rand.Rand
+}
 
-func NewUnboundedFloat32Generator() model.Generator {
-	return float32Gen{}
+func NewUnboundedFloat32Generator(rnd *rand.Rand) model.Generator {
+	return float32Gen{rnd: rnd}
 }
 
 func (f float32Gen) Gen(_ context.Context) (any, error) {
-	return math.Float32frombits(rand.Uint32()), nil //nolint:gosec // ok for this purpose
+	return math.Float32frombits(f.rnd.Uint32()), nil
 }
 
 func (f float32Gen) Close() {}
 
-func NewUnboundedFloat64Generator() model.Generator {
-	return float64Gen{}
+func NewUnboundedFloat64Generator(rnd *rand.Rand) model.Generator {
+	return float64Gen{rnd: rnd}
 }
 
-type float64Gen struct{}
+type float64Gen struct {
+	rnd *rand.Rand
+}
 
 func (f float64Gen) Gen(_ context.Context) (any, error) {
-	return math.Float64frombits(rand.Uint64()), nil //nolint:gosec // ok for this purpose
+	return math.Float64frombits(f.rnd.Uint64()), nil
 }
 
 func (f float64Gen) Close() {}
+
+type inRangeGen struct {
+	rnd      *rand.Rand
+	min, max float64
+}
+
+// NewInRangeGenerator generates finite values, for databases that reject NaN and infinities.
+func NewInRangeGenerator(rnd *rand.Rand, minV, maxV float64) model.Generator {
+	return inRangeGen{rnd: rnd, min: minV, max: maxV}
+}
+
+func (f inRangeGen) Gen(_ context.Context) (any, error) {
+	return f.min + f.rnd.Float64()*(f.max-f.min), nil
+}
+
+func (f inRangeGen) Close() {}
+
+type distributedGen struct {
+	sampler *distribution.Sampler
+	single  bool
+}
+
+// NewDistributedGenerator generates positions of the sampler, as float32 ones for 4 byte columns.
+func NewDistributedGenerator(sampler *distribution.Sampler, single bool) model.Generator {
+	return distributedGen{sampler: sampler, single: single}
+}
+
+func (f distributedGen) Gen(_ context.Context) (any, error) {
+	v := f.sampler.Sample()
+	if f.single {
+		return float32(v), nil
+	}
+
+	return v, nil
+}
+
+func (f distributedGen) Close() {}
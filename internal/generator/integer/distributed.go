@@ -0,0 +1,33 @@
+package integer
+
+import (
+	"context"
+	"math"
+
+	"github.com/jmozgit/datagen/internal/generator/distribution"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+type distributedGenerator struct {
+	sampler *distribution.Sampler
+}
+
+// NewDistributedGenerator rounds positions of the sampler to integers.
+func NewDistributedGenerator(sampler *distribution.Sampler) model.Generator {
+	return &distributedGenerator{sampler: sampler}
+}
+
+func (d *distributedGenerator) Gen(_ context.Context) (any, error) {
+	v := math.Round(d.sampler.Sample())
+	// float64 can't hold the int64 bounds exactly, converting them overflows
+	switch {
+	case v >= math.MaxInt64:
+		return int64(math.MaxInt64), nil
+	case v <= math.MinInt64:
+		return int64(math.MinInt64), nil
+	default:
+		return int64(v), nil
+	}
+}
+
+func (d *distributedGenerator) Close() {}
@@ -9,34 +9,27 @@ import (
 )
 
 type randomGenerator struct {
+	rnd *rand.Rand
 	min int64
 	max int64
 }
 
 func NewRandomInRangeGenerator(
+	rnd *rand.Rand,
 	minV int64, maxV int64,
 ) model.Generator {
-	return &randomGenerator{min: minV, max: maxV}
+	return &randomGenerator{rnd: rnd, min: minV, max: maxV}
 }
 
+// Gen returns values of the inclusive [min, max] range.
 func (r *randomGenerator) Gen(_ context.Context) (any, error) {
-	if r.max == r.min {
-		return r.max, nil
+	// span+1 overflows for the whole int64 range, every value is allowed then
+	span := uint64(r.max) - uint64(r.min)
+	if span == math.MaxUint64 {
+		return int64(r.rnd.Uint64()), nil //nolint:gosec // wrapping is intended
 	}
 
-	fromMin := int64(0)
-	if r.min == math.MinInt64 {
-		fromMin = rand.Int64() //nolint:gosec // ok
-	} else if r.min < 0 {
-		fromMin = rand.Int64N(-r.min) //nolint:gosec // ok
-	}
-
-	fromMax := int64(0)
-	if r.max > 0 {
-		fromMax = rand.Int64N(r.max) //nolint:gosec // ok
-	}
-
-	return r.min + fromMin + fromMax, nil
+	return r.min + int64(r.rnd.Uint64N(span+1)), nil //nolint:gosec // wrapping is intended
 }
 
 func (r *randomGenerator) Close() {}
@@ -2,15 +2,21 @@ package integer
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 
 	"github.com/jmozgit/datagen/internal/model"
 )
 
+var ErrInvalidState = errors.New("invalid generator state")
+
 type serialGenerator struct {
 	cur int64
 }
 
-func NewSerialIntegerGenerator(cur int64) model.Generator {
+// NewSerialIntegerGenerator counts from cur, its state is the next value.
+func NewSerialIntegerGenerator(cur int64) model.StatefulGenerator {
 	return &serialGenerator{cur: cur}
 }
 
@@ -21,4 +27,19 @@ func (s *serialGenerator) Gen(_ context.Context) (any, error) {
 	return cur, nil
 }
 
-func (s serialGenerator) Close() {}
+func (s *serialGenerator) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, uint64(s.cur)), nil
+}
+
+func (s *serialGenerator) UnmarshalBinary(data []byte) error {
+	const stateSize = 8
+
+	if len(data) != stateSize {
+		return fmt.Errorf("%w: serial of %d bytes", ErrInvalidState, len(data))
+	}
+	s.cur = int64(binary.BigEndian.Uint64(data))
+
+	return nil
+}
+
+func (s *serialGenerator) Close() {}
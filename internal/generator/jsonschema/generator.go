@@ -0,0 +1,325 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+)
+
+var ErrTooDeepDocument = errors.New("json schema requires a too deep document")
+
+const (
+	// optionalDepth is the nesting after which optional properties and array items are left out,
+	// so recursive schemas stop growing.
+	optionalDepth = 4
+	// maxDepth bounds documents whose schema requires endless nesting.
+	maxDepth = 32
+	// defaultMaxItems and defaultStringLen are used when the schema leaves them open.
+	defaultMaxItems  = 3
+	defaultStringLen = 12
+	// defaultNumberSpan is the width of numeric ranges open on one or both sides.
+	defaultNumberSpan = 1000
+	// wordLen is the length of words in formatted strings like emails and hosts.
+	wordLen = 8
+)
+
+type Generator struct {
+	rnd  *rand.Rand
+	now  time.Time
+	root *node
+}
+
+// NewGenerator makes json documents conforming to schema, a nil schema makes small random documents.
+// Times are around now, the reference time of the run.
+func NewGenerator(rnd *rand.Rand, now time.Time, schema map[string]any) (*Generator, error) {
+	if schema == nil {
+		return &Generator{rnd: rnd, now: now, root: nil}, nil
+	}
+
+	root, err := compile(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: json schema generator", err)
+	}
+
+	return &Generator{rnd: rnd, now: now, root: root}, nil
+}
+
+// Gen returns the document as json text, postgresql takes it as is for json and jsonb.
+func (g *Generator) Gen(_ context.Context) (any, error) {
+	const fnName = "json schema: gen"
+
+	var (
+		doc any
+		err error
+	)
+	if g.root == nil {
+		doc = g.randomValue(0)
+	} else if doc, err = g.gen(g.root, 0); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return string(raw), nil
+}
+
+func (g *Generator) Close() {}
+
+func (g *Generator) gen(n *node, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, ErrTooDeepDocument
+	}
+
+	switch {
+	case len(n.enum) > 0:
+		return n.enum[g.rnd.IntN(len(n.enum))], nil
+	case len(n.oneOf) > 0:
+		return g.gen(n.oneOf[g.rnd.IntN(len(n.oneOf))], depth)
+	}
+
+	switch g.pickType(n) {
+	case "null":
+		return nil, nil //nolint:nilnil // json null
+	case "boolean":
+		return g.rnd.IntN(2) == 1, nil
+	case "integer":
+		return g.integer(n), nil
+	case "number":
+		return g.number(n), nil
+	case "string":
+		return g.str(n), nil
+	case "array":
+		return g.array(n, depth)
+	case "object":
+		return g.object(n, depth)
+	default:
+		return g.randomValue(depth), nil
+	}
+}
+
+// pickType chooses one of the allowed types, a schema without one is typed by its keywords.
+func (g *Generator) pickType(n *node) string {
+	if len(n.types) > 0 {
+		return n.types[g.rnd.IntN(len(n.types))]
+	}
+
+	switch {
+	case len(n.properties) > 0:
+		return "object"
+	case n.items != nil:
+		return "array"
+	case n.minimum != nil || n.maximum != nil:
+		return "number"
+	case n.format != "" || n.minLength > 0 || n.maxLength >= 0:
+		return "string"
+	default:
+		return ""
+	}
+}
+
+func (g *Generator) object(n *node, depth int) (any, error) {
+	doc := make(map[string]any, len(n.properties))
+	for _, prop := range n.properties {
+		if !prop.required && (depth >= optionalDepth || g.rnd.IntN(2) == 0) {
+			continue
+		}
+
+		val, err := g.gen(prop.schema, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, prop.name)
+		}
+		doc[prop.name] = val
+	}
+
+	return doc, nil
+}
+
+func (g *Generator) array(n *node, depth int) (any, error) {
+	maxItems := n.maxItems
+	if maxItems < 0 {
+		maxItems = max(n.minItems, defaultMaxItems)
+	}
+	if depth >= optionalDepth {
+		maxItems = n.minItems
+	}
+
+	size := n.minItems + g.rnd.IntN(max(maxItems-n.minItems, 0)+1)
+	items := make([]any, size)
+	for i := range items {
+		if n.items == nil {
+			items[i] = g.randomScalar()
+
+			continue
+		}
+
+		val, err := g.gen(n.items, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = val
+	}
+
+	return items, nil
+}
+
+// span turns the bounds of a node into a closed range, open sides are defaultNumberSpan away.
+func span(n *node, step float64) (float64, float64) {
+	lo, hi := 0.0, float64(defaultNumberSpan)
+	switch {
+	case n.minimum != nil && n.maximum != nil:
+		lo, hi = n.minimum.value, n.maximum.value
+	case n.minimum != nil:
+		lo, hi = n.minimum.value, n.minimum.value+defaultNumberSpan
+	case n.maximum != nil:
+		lo, hi = n.maximum.value-defaultNumberSpan, n.maximum.value
+	}
+
+	if n.minimum != nil && n.minimum.exclusive {
+		lo += step
+	}
+	if n.maximum != nil && n.maximum.exclusive {
+		hi -= step
+	}
+
+	return lo, hi
+}
+
+func (g *Generator) integer(n *node) int64 {
+	lo, hi := span(n, 1)
+
+	step := math.Max(1, math.Round(n.multipleOf))
+	first, last := int64(math.Ceil(lo/step)), int64(math.Floor(hi/step))
+	if last < first {
+		return first * int64(step)
+	}
+
+	return (first + g.rnd.Int64N(last-first+1)) * int64(step)
+}
+
+func (g *Generator) number(n *node) float64 {
+	lo, hi := span(n, 0)
+	if n.multipleOf > 0 {
+		first, last := math.Ceil(lo/n.multipleOf), math.Floor(hi/n.multipleOf)
+		if last < first {
+			return first * n.multipleOf
+		}
+
+		return (first + float64(g.rnd.Int64N(int64(last-first)+1))) * n.multipleOf
+	}
+
+	val := lo + g.rnd.Float64()*(hi-lo)
+	// an exclusive bound may be drawn only when the range is empty
+	if n.minimum != nil && n.minimum.exclusive && val <= n.minimum.value {
+		val = math.Nextafter(n.minimum.value, math.Inf(1))
+	}
+	if n.maximum != nil && n.maximum.exclusive && val >= n.maximum.value {
+		val = math.Nextafter(n.maximum.value, math.Inf(-1))
+	}
+
+	return val
+}
+
+func (g *Generator) str(n *node) string {
+	if s, ok := g.formatted(n.format); ok {
+		return s
+	}
+
+	maxLen := n.maxLength
+	if maxLen < 0 {
+		maxLen = max(n.minLength, 1) + defaultStringLen
+	}
+
+	return xrand.LowerCaseString(g.rnd, n.minLength+g.rnd.IntN(max(maxLen-n.minLength, 0)+1))
+}
+
+// formatted makes values of the known string formats, the rest are plain strings like the spec allows.
+func (g *Generator) formatted(format string) (string, bool) {
+	switch format {
+	case "date-time":
+		return g.moment().Format(time.RFC3339), true
+	case "date":
+		return g.moment().Format(time.DateOnly), true
+	case "time":
+		return g.moment().Format(time.TimeOnly) + "Z", true
+	case "email":
+		return xrand.LowerCaseString(g.rnd, wordLen) + "@" + xrand.LowerCaseString(g.rnd, wordLen) + ".com", true
+	case "hostname":
+		return xrand.LowerCaseString(g.rnd, wordLen) + ".example.com", true
+	case "uri":
+		return "https://" + xrand.LowerCaseString(g.rnd, wordLen) + ".com/" + xrand.LowerCaseString(g.rnd, wordLen), true
+	case "uuid":
+		b := make([]byte, 16)
+		xrand.Read(g.rnd, b)
+		b[6] = (b[6] & 0x0f) | 0x40 //nolint:mnd // version 4
+		b[8] = (b[8] & 0x3f) | 0x80 //nolint:mnd // rfc 4122 variant
+		h := hex.EncodeToString(b)
+
+		return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], true
+	case "ipv4":
+		var b [4]byte
+		xrand.Read(g.rnd, b[:])
+
+		return netip.AddrFrom4(b).String(), true
+	case "ipv6":
+		var b [16]byte
+		xrand.Read(g.rnd, b[:])
+
+		return netip.AddrFrom16(b).String(), true
+	default:
+		return "", false
+	}
+}
+
+// moment is a second precision time within a year around the reference time.
+func (g *Generator) moment() time.Time {
+	const year = 365 * 24 * time.Hour
+
+	return g.now.UTC().Add(time.Duration(g.rnd.Int64N(int64(2*year))) - year).Truncate(time.Second)
+}
+
+// randomValue makes a small document of any shape, for schemas that don't restrict it.
+func (g *Generator) randomValue(depth int) any {
+	const maxKeys = 4
+
+	if depth >= 2 || g.rnd.IntN(3) == 0 {
+		return g.randomScalar()
+	}
+
+	if g.rnd.IntN(2) == 0 {
+		items := make([]any, 1+g.rnd.IntN(defaultMaxItems))
+		for i := range items {
+			items[i] = g.randomValue(depth + 1)
+		}
+
+		return items
+	}
+
+	doc := make(map[string]any)
+	for range 1 + g.rnd.IntN(maxKeys) {
+		doc[xrand.LowerCaseString(g.rnd, 1+g.rnd.IntN(wordLen))] = g.randomValue(depth + 1)
+	}
+
+	return doc
+}
+
+func (g *Generator) randomScalar() any {
+	kinds := []func() any{
+		func() any { return g.rnd.Int64N(defaultNumberSpan) },
+		func() any { return math.Round(g.rnd.Float64()*defaultNumberSpan*100) / 100 },
+		func() any { return xrand.LowerCaseString(g.rnd, 1+g.rnd.IntN(defaultStringLen)) },
+		func() any { return g.rnd.IntN(2) == 1 },
+	}
+
+	return kinds[g.rnd.IntN(len(kinds))]()
+}
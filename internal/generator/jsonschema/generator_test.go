@@ -0,0 +1,164 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/generator/jsonschema"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/stretchr/testify/require"
+)
+
+const orderSchema = `{
+	"$defs": {
+		"item": {
+			"type": "object",
+			"required": ["sku", "qty"],
+			"properties": {
+				"sku": {"type": "string", "minLength": 3, "maxLength": 6},
+				"qty": {"type": "integer", "minimum": 1, "exclusiveMaximum": 10},
+				"price": {"type": "number", "minimum": 0, "maximum": 99.5, "multipleOf": 0.5}
+			}
+		},
+		"category": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"enum": ["food", "toys"]},
+				"parent": {"$ref": "#/$defs/category"}
+			}
+		}
+	},
+	"type": "object",
+	"required": ["id", "status", "placed", "items", "category", "contact"],
+	"properties": {
+		"id": {"type": "string", "format": "uuid"},
+		"status": {"const": "new"},
+		"placed": {"type": "string", "format": "date-time"},
+		"items": {"type": "array", "minItems": 1, "maxItems": 4, "items": {"$ref": "#/$defs/item"}},
+		"category": {"$ref": "#/$defs/category"},
+		"contact": {"oneOf": [{"type": "string", "format": "email"}, {"type": "string", "format": "ipv4"}]},
+		"note": {"type": ["string", "null"]}
+	}
+}`
+
+//nolint:gochecknoglobals // ok for test
+var now = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_SchemaDocuments(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "order.json")
+	require.NoError(t, os.WriteFile(path, []byte(orderSchema), 0o600))
+
+	schema, err := jsonschema.Load(path)
+	require.NoError(t, err)
+
+	gen, err := jsonschema.NewGenerator(xrand.New(1, "orders"), now, schema)
+	require.NoError(t, err)
+
+	for range 200 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		raw, ok := val.(string)
+		require.True(t, ok)
+
+		var doc struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			Placed string `json:"placed"`
+			Items  []struct {
+				Sku   *string  `json:"sku"`
+				Qty   *int     `json:"qty"`
+				Price *float64 `json:"price"`
+			} `json:"items"`
+			Category map[string]any `json:"category"`
+			Contact  string         `json:"contact"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(raw), &doc), raw)
+
+		require.Len(t, doc.ID, 36)
+		require.Equal(t, "new", doc.Status)
+		_, err = time.Parse(time.RFC3339, doc.Placed)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, doc.Items)
+		require.LessOrEqual(t, len(doc.Items), 4)
+		for _, item := range doc.Items {
+			require.NotNil(t, item.Sku)
+			require.NotNil(t, item.Qty)
+			require.GreaterOrEqual(t, len(*item.Sku), 3)
+			require.LessOrEqual(t, len(*item.Sku), 6)
+			require.GreaterOrEqual(t, *item.Qty, 1)
+			require.Less(t, *item.Qty, 10)
+			if item.Price != nil {
+				require.LessOrEqual(t, *item.Price, 99.5)
+				require.InDelta(t, 0, *item.Price*2-float64(int(*item.Price*2)), 1e-9)
+			}
+		}
+
+		for category := doc.Category; category != nil; {
+			require.Contains(t, []any{"food", "toys"}, category["name"])
+			category, _ = category["parent"].(map[string]any)
+		}
+
+		if _, err := netip.ParseAddr(doc.Contact); err != nil {
+			require.Contains(t, doc.Contact, "@")
+		}
+	}
+}
+
+func Test_RandomDocuments(t *testing.T) {
+	t.Parallel()
+
+	gen, err := jsonschema.NewGenerator(xrand.New(1, "random"), now, nil)
+	require.NoError(t, err)
+
+	for range 50 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		raw, ok := val.(string)
+		require.True(t, ok)
+		require.True(t, json.Valid([]byte(raw)), raw)
+	}
+}
+
+func Test_UnsupportedSchemas(t *testing.T) {
+	t.Parallel()
+
+	for _, tC := range []struct {
+		desc     string
+		schema   map[string]any
+		expected error
+	}{
+		{
+			desc:     "pattern",
+			schema:   map[string]any{"type": "string", "pattern": "^[a-z]+$"},
+			expected: jsonschema.ErrUnsupportedKeyword,
+		},
+		{
+			desc:     "remote_ref",
+			schema:   map[string]any{"$ref": "https://example.com/schema.json"},
+			expected: jsonschema.ErrUnresolvedRef,
+		},
+		{
+			desc:     "missing_ref",
+			schema:   map[string]any{"properties": map[string]any{"a": map[string]any{"$ref": "#/$defs/a"}}},
+			expected: jsonschema.ErrUnresolvedRef,
+		},
+	} {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jsonschema.NewGenerator(xrand.New(1), now, tC.schema)
+			require.ErrorIs(t, err, tC.expected)
+		})
+	}
+}
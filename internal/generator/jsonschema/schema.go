@@ -0,0 +1,297 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnsupportedKeyword = errors.New("unsupported json schema keyword")
+	ErrInvalidSchema      = errors.New("invalid json schema")
+	ErrUnresolvedRef      = errors.New("unresolved json schema $ref")
+)
+
+// unsupportedKeywords constrain documents in ways the generator can't follow, they are refused rather than ignored.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var unsupportedKeywords = []string{"allOf", "not", "if", "pattern", "patternProperties", "dependentSchemas"}
+
+// node is a compiled schema, a $ref node shares the node of its target.
+type node struct {
+	types      []string
+	enum       []any
+	properties []property
+	items      *node
+	minItems   int
+	maxItems   int
+	minLength  int
+	maxLength  int
+	format     string
+	minimum    *bound
+	maximum    *bound
+	multipleOf float64
+	oneOf      []*node
+}
+
+type property struct {
+	name     string
+	required bool
+	schema   *node
+}
+
+type bound struct {
+	value     float64
+	exclusive bool
+}
+
+// Load reads a schema from a json or yaml file.
+func Load(path string) (map[string]any, error) {
+	const fnName = "json schema: load"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// yaml is a superset of json, so one decoder reads both
+	var schema map[string]any
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %s %s", err, path, fnName)
+	}
+
+	return schema, nil
+}
+
+type compiler struct {
+	root map[string]any
+	refs map[string]*node
+}
+
+func compile(schema map[string]any) (*node, error) {
+	c := compiler{root: schema, refs: make(map[string]*node)}
+
+	root, err := c.compile(schema, "#")
+	if err != nil {
+		return nil, fmt.Errorf("%w: compile", err)
+	}
+
+	return root, nil
+}
+
+//nolint:gocognit,cyclop,funlen // one branch per keyword reads better than a table of them
+func (c *compiler) compile(raw any, path string) (*node, error) {
+	if b, ok := raw.(bool); ok {
+		// true allows anything, false allows nothing and can't be generated
+		if !b {
+			return nil, fmt.Errorf("%w: false schema at %s", ErrInvalidSchema, path)
+		}
+
+		return &node{}, nil //nolint:exhaustruct // an empty schema allows any document
+	}
+
+	schema, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T at %s", ErrInvalidSchema, raw, path)
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		return c.resolve(ref)
+	}
+
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := schema[keyword]; ok {
+			return nil, fmt.Errorf("%w: %s at %s", ErrUnsupportedKeyword, keyword, path)
+		}
+	}
+
+	//nolint:exhaustruct // filled keyword by keyword
+	n := &node{minItems: 0, maxItems: -1, minLength: 0, maxLength: -1}
+
+	switch tp := schema["type"].(type) {
+	case nil:
+	case string:
+		n.types = []string{tp}
+	case []any:
+		for _, t := range tp {
+			s, ok := t.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: type %v at %s", ErrInvalidSchema, t, path)
+			}
+			n.types = append(n.types, s)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type %v at %s", ErrInvalidSchema, tp, path)
+	}
+
+	if constant, ok := schema["const"]; ok {
+		n.enum = []any{constant}
+	} else if enum, ok := schema["enum"].([]any); ok {
+		if len(enum) == 0 {
+			return nil, fmt.Errorf("%w: empty enum at %s", ErrInvalidSchema, path)
+		}
+		n.enum = enum
+	}
+
+	if err := c.compileObject(n, schema, path); err != nil {
+		return nil, err
+	}
+
+	if items, ok := schema["items"]; ok {
+		compiled, err := c.compile(items, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		n.items = compiled
+	}
+
+	for keyword, target := range map[string]*int{
+		"minItems": &n.minItems, "maxItems": &n.maxItems, "minLength": &n.minLength, "maxLength": &n.maxLength,
+	} {
+		if v, ok := number(schema[keyword]); ok {
+			*target = int(v)
+		}
+	}
+
+	n.format, _ = schema["format"].(string)
+	n.minimum = numericBound(schema, "minimum", "exclusiveMinimum")
+	n.maximum = numericBound(schema, "maximum", "exclusiveMaximum")
+	if v, ok := number(schema["multipleOf"]); ok && v > 0 {
+		n.multipleOf = v
+	}
+
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		branches, ok := schema[keyword].([]any)
+		if !ok {
+			continue
+		}
+
+		for i, branch := range branches {
+			compiled, err := c.compile(branch, path+"/"+keyword+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			n.oneOf = append(n.oneOf, compiled)
+		}
+	}
+
+	return n, nil
+}
+
+func (c *compiler) compileObject(n *node, schema map[string]any, path string) error {
+	required := make(map[string]bool)
+	if names, ok := schema["required"].([]any); ok {
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	// properties are kept sorted, documents must not depend on map order
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		compiled, err := c.compile(properties[name], path+"/properties/"+name)
+		if err != nil {
+			return err
+		}
+
+		n.properties = append(n.properties, property{name: name, required: required[name], schema: compiled})
+		delete(required, name)
+	}
+
+	// required properties without a schema may hold anything
+	rest := make([]string, 0, len(required))
+	for name := range required {
+		rest = append(rest, name)
+	}
+	slices.Sort(rest)
+	for _, name := range rest {
+		//nolint:exhaustruct // an empty schema allows any document
+		n.properties = append(n.properties, property{name: name, required: true, schema: &node{}})
+	}
+
+	return nil
+}
+
+// resolve compiles a local $ref once, recursive schemas share the node that is being compiled.
+func (c *compiler) resolve(ref string) (*node, error) {
+	if n, ok := c.refs[ref]; ok {
+		return n, nil
+	}
+
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("%w: only local refs are supported: %s", ErrUnresolvedRef, ref)
+	}
+
+	var target any = c.root
+	for _, token := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if token == "" {
+			continue
+		}
+
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		obj, ok := target.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnresolvedRef, ref)
+		}
+		if target, ok = obj[token]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnresolvedRef, ref)
+		}
+	}
+
+	n := &node{} //nolint:exhaustruct // filled once the target is compiled
+	c.refs[ref] = n
+
+	compiled, err := c.compile(target, ref)
+	if err != nil {
+		return nil, err
+	}
+	*n = *compiled
+
+	return n, nil
+}
+
+// numericBound reads both the draft 4 boolean and the later numeric form of exclusive bounds.
+func numericBound(schema map[string]any, inclusiveKey, exclusiveKey string) *bound {
+	if v, ok := number(schema[exclusiveKey]); ok {
+		return &bound{value: v, exclusive: true}
+	}
+
+	v, ok := number(schema[inclusiveKey])
+	if !ok {
+		return nil
+	}
+
+	exclusive, _ := schema[exclusiveKey].(bool)
+
+	return &bound{value: v, exclusive: exclusive}
+}
+
+// number reads numbers decoded from yaml and json alike.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
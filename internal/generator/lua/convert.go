@@ -0,0 +1,150 @@
+package lua
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	golua "github.com/yuin/gopher-lua"
+)
+
+// fromLua converts a returned value, tables become arrays for array columns and json text otherwise.
+func fromLua(val golua.LValue, target model.TargetType) (any, error) {
+	switch v := val.(type) {
+	case *golua.LNilType:
+		return nil, nil //nolint:nilnil // null
+	case golua.LBool:
+		return bool(v), nil
+	case golua.LString:
+		return string(v), nil
+	case golua.LNumber:
+		return number(v, target.Type == model.Integer), nil
+	case *golua.LTable:
+		if target.Type == model.Array {
+			return fromTable(v, target.ArrayElem.ElemType == model.Integer)
+		}
+
+		doc, err := fromTable(v, false)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: marshal table", err)
+		}
+
+		return string(raw), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLuaReturnedValue, val.Type())
+	}
+}
+
+// fromTable makes a slice of a sequence and a map of anything else.
+func fromTable(tbl *golua.LTable, integers bool) (any, error) {
+	var (
+		keys int
+		err  error
+	)
+	tbl.ForEach(func(golua.LValue, golua.LValue) { keys++ })
+
+	if n := tbl.MaxN(); n == keys {
+		out := make([]any, n)
+		for i := range n {
+			if out[i], err = fromNested(tbl.RawGetInt(i+1), integers); err != nil {
+				return nil, err
+			}
+		}
+
+		return out, nil
+	}
+
+	out := make(map[string]any, keys)
+	tbl.ForEach(func(key, val golua.LValue) {
+		if err != nil {
+			return
+		}
+		out[key.String()], err = fromNested(val, integers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func fromNested(val golua.LValue, integers bool) (any, error) {
+	switch v := val.(type) {
+	case golua.LNumber:
+		return number(v, integers), nil
+	case *golua.LTable:
+		return fromTable(v, integers)
+	default:
+		return fromLua(val, model.TargetType{}) //nolint:exhaustruct // only scalars get here
+	}
+}
+
+// number keeps lua numbers floats unless they go to integer columns.
+func number(v golua.LNumber, integer bool) any {
+	f := float64(v)
+	if integer && f == math.Trunc(f) {
+		return int64(f)
+	}
+
+	return f
+}
+
+// toLua converts row values and arguments.
+func toLua(state *golua.LState, val any) golua.LValue {
+	switch v := val.(type) {
+	case nil:
+		return golua.LNil
+	case bool:
+		return golua.LBool(v)
+	case string:
+		return golua.LString(v)
+	case []byte:
+		return golua.LString(v)
+	case time.Time:
+		return golua.LString(v.Format(time.RFC3339Nano))
+	case fmt.Stringer:
+		return golua.LString(v.String())
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() { //nolint:exhaustive // the rest is formatted
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return golua.LNumber(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return golua.LNumber(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return golua.LNumber(rv.Float())
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return golua.LNil
+		}
+
+		return toLua(state, rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		tbl := state.NewTable()
+		for i := range rv.Len() {
+			tbl.Append(toLua(state, rv.Index(i).Interface()))
+		}
+
+		return tbl
+	case reflect.Map:
+		tbl := state.NewTable()
+		iter := rv.MapRange()
+		for iter.Next() {
+			tbl.RawSetString(fmt.Sprint(iter.Key().Interface()), toLua(state, iter.Value().Interface()))
+		}
+
+		return tbl
+	default:
+		return golua.LString(fmt.Sprint(val))
+	}
+}
@@ -4,47 +4,212 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 
-	"github.com/jmozgit/datagen/internal/generator/ahead"
 	"github.com/jmozgit/datagen/internal/model"
 
 	golua "github.com/yuin/gopher-lua"
 )
 
-var ErrUnsupportedLuaReturnedValue = errors.New("unsupported lua returned value")
+var (
+	ErrUnsupportedLuaReturnedValue = errors.New("unsupported lua returned value")
+	ErrNoGenFunction               = errors.New("lua script reading the row must define gen(row, ctx)")
+	ErrNoRow                       = errors.New("lua script reads the row but there is none")
+	ErrGlobalsNotRestorable        = errors.New("globals of lua scripts can't be restored, the run can't be resumed")
+)
 
-type scriptExecutor struct {
-	state *golua.LState
-	path  string
+const genFunction = "gen"
+
+type Options struct {
+	// Args are given to gen as ctx.args.
+	Args map[string]any
+	// Columns gen reads from row, they are generated before the column.
+	Columns []string
+	// Target decides whether tables are returned as arrays or json and numbers as integers.
+	Target model.TargetType
+}
+
+// NewScriptExecutor runs the script once. A script defining a global gen(row, ctx) function gets it called
+// for every value, globals it sets persist between calls. Other scripts are run again for every value
+// and return it. math.random of both draws from rnd. Globals can't be saved, checkpoints refuse to restore them.
+func NewScriptExecutor(rnd *rand.Rand, path string, opts Options) (model.StatefulGenerator, error) {
+	const fnName = "new lua script executor"
+
+	state := golua.NewState()
+	seedRandom(state, rnd)
+
+	if err := state.DoFile(path); err != nil {
+		state.Close()
+
+		return nil, fmt.Errorf("%w: %s %s", err, path, fnName)
+	}
+
+	if fn, ok := state.GetGlobal(genFunction).(*golua.LFunction); ok {
+		state.SetTop(0)
+
+		return newFuncExecutor(state, fn, opts), nil
+	}
+
+	if len(opts.Columns) > 0 {
+		state.Close()
+
+		return nil, fmt.Errorf("%w: %s %s", ErrNoGenFunction, path, fnName)
+	}
+
+	first := golua.LValue(golua.LNil)
+	if state.GetTop() > 0 {
+		first = state.Get(-1)
+	}
+	state.SetTop(0)
+
+	return &scriptExecutor{
+		unrestorable: unrestorable{}, state: state, path: path, target: opts.Target, first: first,
+	}, nil
+}
+
+// unrestorable makes checkpoints refuse resuming tables with scripts, globals persist between values
+// but can't be saved.
+type unrestorable struct{}
+
+func (unrestorable) MarshalBinary() ([]byte, error) {
+	return nil, nil
 }
 
-func NewScriptExecutor(path string) model.Generator {
-	return ahead.NewGenerator(&scriptExecutor{path: path, state: golua.NewState()})
+func (unrestorable) UnmarshalBinary([]byte) error {
+	return fmt.Errorf("%w: lua state", ErrGlobalsNotRestorable)
+}
+
+type scriptExecutor struct {
+	unrestorable
+
+	state  *golua.LState
+	path   string
+	target model.TargetType
+	// first is what the script returned when it was loaded.
+	first golua.LValue
 }
 
 func (s *scriptExecutor) Gen(_ context.Context) (any, error) {
 	const fnName = "lua: gen"
 
-	err := s.state.DoFile(s.path)
+	val := s.first
+	if val != nil {
+		s.first = nil
+	} else {
+		err := s.state.DoFile(s.path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		val = s.state.Get(-1)
+		s.state.SetTop(0)
+	}
+
+	res, err := fromLua(val, s.target)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", err, fnName)
 	}
 
-	val := s.state.Get(-1)
-	s.state.Pop(1)
+	return res, nil
+}
 
-	switch v := val.(type) {
-	case golua.LBool:
-		return bool(v), nil
-	case golua.LString:
-		return string(v), nil
-	case golua.LNumber:
-		return float64(v), nil
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLuaReturnedValue, fnName)
+func (s *scriptExecutor) Close() {
+	s.state.Close()
+}
+
+type funcExecutor struct {
+	unrestorable
+
+	state   *golua.LState
+	fn      *golua.LFunction
+	ctx     *golua.LTable
+	columns []string
+	target  model.TargetType
+	calls   int
+}
+
+func newFuncExecutor(state *golua.LState, fn *golua.LFunction, opts Options) *funcExecutor {
+	ctx := state.NewTable()
+	ctx.RawSetString("args", toLua(state, opts.Args))
+
+	return &funcExecutor{
+		unrestorable: unrestorable{},
+		state:        state,
+		fn:           fn,
+		ctx:          ctx,
+		columns:      opts.Columns,
+		target:       opts.Target,
+		calls:        0,
 	}
 }
 
-func (s scriptExecutor) Close() {
-	s.state.Close()
+func (f *funcExecutor) DependsOn() []string {
+	return f.columns
+}
+
+func (f *funcExecutor) Gen(ctx context.Context) (any, error) {
+	const fnName = "lua: gen function"
+
+	row := f.state.NewTable()
+	if len(f.columns) > 0 {
+		values, ok := model.RowFromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNoRow, fnName)
+		}
+
+		for _, col := range f.columns {
+			val, _ := values.Value(col)
+			row.RawSetString(col, toLua(f.state, val))
+		}
+	}
+
+	f.calls++
+	f.ctx.RawSetString("n", golua.LNumber(f.calls))
+
+	err := f.state.CallByParam(golua.P{Fn: f.fn, NRet: 1, Protect: true, Handler: nil}, row, f.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	val := f.state.Get(-1)
+	f.state.Pop(1)
+
+	res, err := fromLua(val, f.target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return res, nil
+}
+
+func (f *funcExecutor) Close() {
+	f.state.Close()
+}
+
+// seedRandom replaces math.random with one drawing from rnd, math.randomseed does nothing
+// since the seed comes from the config.
+func seedRandom(state *golua.LState, rnd *rand.Rand) {
+	mathLib := state.GetGlobal("math")
+
+	state.SetField(mathLib, "random", state.NewFunction(func(l *golua.LState) int {
+		switch l.GetTop() {
+		case 0:
+			l.Push(golua.LNumber(rnd.Float64()))
+		case 1:
+			hi := l.CheckInt64(1)
+			if hi < 1 {
+				l.ArgError(1, "interval is empty")
+			}
+			l.Push(golua.LNumber(1 + rnd.Int64N(hi)))
+		default:
+			lo, hi := l.CheckInt64(1), l.CheckInt64(2)
+			if lo > hi {
+				l.ArgError(2, "interval is empty")
+			}
+			l.Push(golua.LNumber(lo + rnd.Int64N(hi-lo+1)))
+		}
+
+		return 1
+	}))
+	state.SetField(mathLib, "randomseed", state.NewFunction(func(*golua.LState) int { return 0 }))
 }
@@ -1,13 +1,19 @@
 package lua
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
 	"testing"
 
+	"github.com/jmozgit/datagen/internal/model"
+
 	"github.com/stretchr/testify/require"
 )
 
 func Test_HappyPath(t *testing.T) {
-	gen := NewScriptExecutor("testscripts/20digits.lua")
+	gen, err := NewScriptExecutor(rand.New(rand.NewPCG(1, 2)), "testscripts/20digits.lua", Options{})
+	require.NoError(t, err)
 
 	val, err := gen.Gen(t.Context())
 	require.NoError(t, err)
@@ -17,3 +23,72 @@ func Test_HappyPath(t *testing.T) {
 
 	require.Len(t, str, 20)
 }
+
+func Test_GenFunction(t *testing.T) {
+	newGen := func() model.Generator {
+		gen, err := NewScriptExecutor(rand.New(rand.NewPCG(1, 2)), "testscripts/gen.lua", Options{
+			Args:    map[string]any{"prefix": "ord"},
+			Columns: []string{"id"},
+			Target:  model.TargetType{Type: model.Text},
+		})
+		require.NoError(t, err)
+		t.Cleanup(gen.Close)
+
+		return gen
+	}
+
+	gen, same := newGen(), newGen()
+	require.Equal(t, []string{"id"}, gen.(model.DerivedGenerator).DependsOn())
+
+	row := model.NewRow(map[string]int{"id": 0})
+	ctx := model.WithRow(t.Context(), row)
+
+	for i := range 3 {
+		row.Reset([]any{int64(10 + i)})
+
+		val, err := gen.Gen(ctx)
+		require.NoError(t, err)
+
+		var doc struct {
+			Code string `json:"code"`
+			N    int    `json:"n"`
+			Tags []int  `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(val.(string)), &doc))
+		require.Equal(t, fmt.Sprintf("ord-%d-%d", 10+i, i+1), doc.Code)
+		require.Equal(t, i+1, doc.N)
+		require.Len(t, doc.Tags, 2)
+		require.Equal(t, 10+i, doc.Tags[0])
+
+		other, err := same.Gen(ctx)
+		require.NoError(t, err)
+		require.Equal(t, val, other)
+	}
+
+	_, err := gen.Gen(t.Context())
+	require.ErrorIs(t, err, ErrNoRow)
+}
+
+func Test_TablesToArrays(t *testing.T) {
+	gen, err := NewScriptExecutor(rand.New(rand.NewPCG(1, 2)), "testscripts/pairs.lua", Options{
+		Target: model.TargetType{
+			Type:      model.Array,
+			ArrayElem: model.ArrayInfo{ElemType: model.Integer},
+		},
+	})
+	require.NoError(t, err)
+	defer gen.Close()
+
+	for i := range int64(3) {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, []any{i + 1, 2 * (i + 1)}, val)
+	}
+}
+
+func Test_RowWithoutGenFunction(t *testing.T) {
+	_, err := NewScriptExecutor(rand.New(rand.NewPCG(1, 2)), "testscripts/20digits.lua", Options{
+		Columns: []string{"id"},
+	})
+	require.ErrorIs(t, err, ErrNoGenFunction)
+}
@@ -0,0 +1,9 @@
+-- counts calls in a global, reads the row and the arguments
+
+local seen = 0
+
+function gen(row, ctx)
+  seen = seen + 1
+  local code = ctx.args.prefix .. "-" .. row.id .. "-" .. seen
+  return { code = code, n = ctx.n, tags = { row.id, math.random(1, 6) } }
+end
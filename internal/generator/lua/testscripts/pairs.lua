@@ -0,0 +1,3 @@
+function gen(row, ctx)
+  return { ctx.n, ctx.n * 2 }
+end
@@ -0,0 +1,42 @@
+package decimal
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxDigits keeps the unscaled value within int64.
+const maxDigits = 18
+
+type generator struct {
+	rnd       *rand.Rand
+	precision int
+	scale     int
+	unsigned  bool
+}
+
+// NewGenerator generates values fitting decimal(precision, scale).
+func NewGenerator(rnd *rand.Rand, precision, scale int, unsigned bool) model.Generator {
+	return generator{
+		rnd:       rnd,
+		precision: min(precision, maxDigits),
+		scale:     min(scale, precision, maxDigits),
+		unsigned:  unsigned,
+	}
+}
+
+func (g generator) Gen(_ context.Context) (any, error) {
+	unscaled := g.rnd.Int64N(int64(math.Pow10(g.precision)))
+	if !g.unsigned && g.rnd.IntN(2) == 0 {
+		unscaled = -unscaled
+	}
+
+	return decimal.New(unscaled, -int32(g.scale)), nil //nolint:gosec // scale is small
+}
+
+func (g generator) Close() {}
@@ -0,0 +1,32 @@
+package set
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+type generator struct {
+	rnd     *rand.Rand
+	members []string
+}
+
+// NewGenerator generates mysql SET values: a random subset of members joined by commas.
+func NewGenerator(rnd *rand.Rand, members []string) model.Generator {
+	return generator{rnd: rnd, members: members}
+}
+
+func (g generator) Gen(_ context.Context) (any, error) {
+	picked := make([]string, 0, len(g.members))
+	for _, member := range g.members {
+		if g.rnd.IntN(2) == 0 {
+			picked = append(picked, member)
+		}
+	}
+
+	return strings.Join(picked, ","), nil
+}
+
+func (g generator) Close() {}
@@ -5,31 +5,44 @@ import (
 	"errors"
 	"fmt"
 	"math/rand/v2"
-	"reflect"
+	"net"
 
-	"github.com/go-faker/faker/v4"
 	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 )
 
 var ErrUnknownNetworkType = errors.New("unknown network type")
 
 type generator func() (any, error)
 
-func NewGenerator(name string) (model.Generator, error) {
+const (
+	ipv4Len = 4
+	ipv6Len = 16
+	macLen  = 6
+)
+
+func NewGenerator(rnd *rand.Rand, name string) (model.Generator, error) {
 	switch name {
 	case "inet", "cidr":
-		genNet := faker.GetNetworker()
 		return generator(func() (any, error) {
-			if rand.Int()%2 == 0 {
-				return genNet.IPv4(reflect.Value{})
+			if rnd.Int()%2 == 0 {
+				ip := make(net.IP, ipv4Len)
+				xrand.Read(rnd, ip)
+
+				return ip.String(), nil
 			}
 
-			return genNet.IPv6(reflect.Value{})
+			ip := make(net.IP, ipv6Len)
+			xrand.Read(rnd, ip)
+
+			return ip.String(), nil
 		}), nil
 	case "macaddr", "macaddr8":
-		genNet := faker.GetNetworker()
 		return generator(func() (any, error) {
-			return genNet.MacAddress(reflect.Value{})
+			mac := make(net.HardwareAddr, macLen)
+			xrand.Read(rnd, mac)
+
+			return mac.String(), nil
 		}), nil
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnknownNetworkType, name)
@@ -8,22 +8,25 @@ import (
 )
 
 type generator struct {
+	rnd           *rand.Rand
 	nullFraction  int
 	baseGenerator model.Generator
 }
 
 func NewGenerator(
+	rnd *rand.Rand,
 	nullFraction int,
 	baseGenerator model.Generator,
 ) model.Generator {
 	return &generator{
+		rnd:           rnd,
 		nullFraction:  nullFraction,
 		baseGenerator: baseGenerator,
 	}
 }
 
 func (g *generator) Gen(ctx context.Context) (any, error) {
-	if rand.IntN(100) <= g.nullFraction {
+	if g.rnd.IntN(100) <= g.nullFraction {
 		return nil, nil
 	}
 
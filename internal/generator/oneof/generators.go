@@ -0,0 +1,34 @@
+package oneof
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+// Generators takes every value from one of the generators picked at random.
+type Generators struct {
+	rnd  *rand.Rand
+	gens []model.Generator
+}
+
+func NewGenerators(rnd *rand.Rand, gens []model.Generator) Generators {
+	return Generators{rnd: rnd, gens: gens}
+}
+
+func (g Generators) Gen(ctx context.Context) (any, error) {
+	val, err := g.gens[g.rnd.IntN(len(g.gens))].Gen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: oneof generators", err)
+	}
+
+	return val, nil
+}
+
+func (g Generators) Close() {
+	for _, gen := range g.gens {
+		gen.Close()
+	}
+}
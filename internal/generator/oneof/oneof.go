@@ -6,15 +6,16 @@ import (
 )
 
 type Generator[T any] struct {
+	rnd    *rand.Rand
 	values []T
 }
 
-func NewGenerator[T any](values []T) Generator[T] {
-	return Generator[T]{values: values}
+func NewGenerator[T any](rnd *rand.Rand, values []T) Generator[T] {
+	return Generator[T]{rnd: rnd, values: values}
 }
 
 func (g Generator[T]) Gen(_ context.Context) (any, error) {
-	return g.values[rand.IntN(len(g.values))], nil
+	return g.values[g.rnd.IntN(len(g.values))], nil
 }
 
 func (g Generator[T]) Close() {}
@@ -0,0 +1,119 @@
+package sequence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+var ErrNoValues = errors.New("sequence returned no values")
+
+type seqGenerator struct {
+	conn     db.Connect
+	query    string
+	size     int
+	prefetch []int64
+}
+
+// NewGenerator takes values from the sequence, so that later inserts of the application don't collide
+// with generated rows. Values are fetched by blocks of prefetch size, a round trip per row is too slow.
+func NewGenerator(conn db.Connect, seqName model.TableName, prefetch int) model.Generator {
+	return &seqGenerator{
+		conn: conn,
+		query: fmt.Sprintf(
+			"SELECT %s.NEXTVAL FROM dual CONNECT BY LEVEL <= %d",
+			seqName.Quoted(), prefetch,
+		),
+		size:     prefetch,
+		prefetch: nil,
+	}
+}
+
+func (s *seqGenerator) Gen(ctx context.Context) (any, error) {
+	if len(s.prefetch) == 0 {
+		if err := s.fetch(ctx); err != nil {
+			return nil, fmt.Errorf("%w: sequence gen", err)
+		}
+	}
+
+	next := s.prefetch[0]
+	s.prefetch = s.prefetch[1:]
+
+	return next, nil
+}
+
+func (s *seqGenerator) fetch(ctx context.Context) error {
+	rows, err := s.conn.Query(ctx, s.query)
+	if err != nil {
+		return fmt.Errorf("%w: fetch", err)
+	}
+	defer rows.Close()
+
+	values := make([]int64, 0, s.size)
+	for rows.Next() {
+		var val int64
+		if err := rows.Scan(&val); err != nil {
+			return fmt.Errorf("%w: fetch", err)
+		}
+
+		values = append(values, val)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: fetch", err)
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("%w: fetch", ErrNoValues)
+	}
+	s.prefetch = values
+
+	return nil
+}
+
+func (s *seqGenerator) Close() {}
+
+type seqPeekGenerator struct {
+	conn    db.Connect
+	owner   string
+	name    string
+	next    int64
+	step    int64
+	fetched bool
+}
+
+// NewPeekGenerator counts from the last number of the sequence the dictionary tells without NEXTVAL,
+// so a dry run leaves the sequence as it is.
+func NewPeekGenerator(conn db.Connect, seqName model.TableName) model.Generator {
+	return &seqPeekGenerator{
+		conn:    conn,
+		owner:   seqName.Schema.AsArgument(),
+		name:    seqName.Table.AsArgument(),
+		next:    0,
+		step:    0,
+		fetched: false,
+	}
+}
+
+func (s *seqPeekGenerator) Gen(ctx context.Context) (any, error) {
+	const query = `
+	SELECT last_number, increment_by FROM all_sequences WHERE sequence_owner = :1 AND sequence_name = :2
+	`
+
+	if !s.fetched {
+		if err := s.conn.QueryRow(ctx, query, s.owner, s.name).Scan(&s.next, &s.step); err != nil {
+			return nil, fmt.Errorf("%w: sequence peek gen", err)
+		}
+		s.fetched = true
+	}
+
+	next := s.next
+	s.next += s.step
+
+	return next, nil
+}
+
+func (s *seqPeekGenerator) Close() {}
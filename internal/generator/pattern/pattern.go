@@ -0,0 +1,237 @@
+package pattern
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var (
+	ErrInvalidPattern = errors.New("invalid pattern")
+	ErrPatternTooLong = errors.New("pattern doesn't match strings short enough")
+)
+
+const (
+	// maxRepeat bounds *, + and {n,} repetitions above their minimum.
+	maxRepeat = 8
+	// attempts to fit into the length before falling back to the shortest match.
+	attempts = 16
+	// printable ascii is preferred for classes and dots, ' ' to '~'.
+	printableLo, printableHi = 0x20, 0x7e
+)
+
+type generator struct {
+	rnd    *rand.Rand
+	re     *syntax.Regexp
+	maxLen int
+	// printable are ranges of character classes narrowed to printable ascii, if they have any.
+	printable map[*syntax.Regexp][]rune
+}
+
+// NewGenerator makes strings matching a perl-like regular expression, not longer than a positive maxLen runes.
+func NewGenerator(rnd *rand.Rand, expr string, maxLen int) (model.Generator, error) {
+	const fnName = "new pattern generator"
+
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrInvalidPattern, err, fnName)
+	}
+
+	shortest, ok := minLen(re)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q matches nothing: %s", ErrInvalidPattern, expr, fnName)
+	}
+
+	if maxLen > 0 && shortest > maxLen {
+		return nil, fmt.Errorf("%w: %q is at least %d long, limit %d: %s", ErrPatternTooLong, expr, shortest, maxLen, fnName)
+	}
+
+	gen := generator{rnd: rnd, re: re, maxLen: maxLen, printable: make(map[*syntax.Regexp][]rune)}
+	gen.narrowClasses(re)
+
+	return gen, nil
+}
+
+func (g generator) Gen(_ context.Context) (any, error) {
+	var builder strings.Builder
+
+	for range attempts {
+		builder.Reset()
+		g.write(&builder, g.re, false)
+
+		if g.maxLen <= 0 || utf8.RuneCountInString(builder.String()) <= g.maxLen {
+			return builder.String(), nil
+		}
+	}
+
+	builder.Reset()
+	g.write(&builder, g.re, true)
+
+	return builder.String(), nil
+}
+
+func (g generator) Close() {}
+
+func (g generator) narrowClasses(re *syntax.Regexp) {
+	if re.Op == syntax.OpCharClass {
+		var ranges []rune
+		for i := 0; i < len(re.Rune); i += 2 {
+			lo, hi := max(re.Rune[i], printableLo), min(re.Rune[i+1], printableHi)
+			if lo <= hi {
+				ranges = append(ranges, lo, hi)
+			}
+		}
+
+		if len(ranges) > 0 {
+			g.printable[re] = ranges
+		}
+	}
+
+	for _, sub := range re.Sub {
+		g.narrowClasses(sub)
+	}
+}
+
+// write appends a random match of re, or a shortest one.
+//
+//nolint:cyclop // a case per operator
+func (g generator) write(builder *strings.Builder, re *syntax.Regexp, shortest bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && g.rnd.IntN(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			builder.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		ranges, ok := g.printable[re]
+		if !ok {
+			ranges = re.Rune
+		}
+		builder.WriteRune(g.pickRune(ranges))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		builder.WriteRune(printableLo + g.rnd.Int32N(printableHi-printableLo+1))
+	case syntax.OpCapture:
+		g.write(builder, re.Sub[0], shortest)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.write(builder, sub, shortest)
+		}
+	case syntax.OpAlternate:
+		g.write(builder, g.alternative(re, shortest), shortest)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		for range g.repeats(re, shortest) {
+			g.write(builder, re.Sub[0], shortest)
+		}
+	default:
+		// anchors, boundaries and empty matches take no characters
+	}
+}
+
+func (g generator) pickRune(ranges []rune) rune {
+	total := 0
+	for i := 0; i < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+
+	n := g.rnd.IntN(total)
+	for i := 0; i < len(ranges); i += 2 {
+		width := int(ranges[i+1]-ranges[i]) + 1
+		if n < width {
+			return ranges[i] + rune(n)
+		}
+		n -= width
+	}
+
+	return ranges[0]
+}
+
+func (g generator) alternative(re *syntax.Regexp, shortest bool) *syntax.Regexp {
+	if !shortest {
+		return re.Sub[g.rnd.IntN(len(re.Sub))]
+	}
+
+	best, bestLen := re.Sub[0], -1
+	for _, sub := range re.Sub {
+		if n, ok := minLen(sub); ok && (bestLen == -1 || n < bestLen) {
+			best, bestLen = sub, n
+		}
+	}
+
+	return best
+}
+
+func (g generator) repeats(re *syntax.Regexp, shortest bool) int {
+	var lo, hi int
+	switch re.Op {
+	case syntax.OpStar:
+		lo, hi = 0, maxRepeat
+	case syntax.OpPlus:
+		lo, hi = 1, maxRepeat
+	case syntax.OpQuest:
+		lo, hi = 0, 1
+	default:
+		lo, hi = re.Min, re.Max
+		if hi == -1 {
+			hi = lo + maxRepeat
+		}
+	}
+
+	if shortest {
+		return lo
+	}
+
+	return lo + g.rnd.IntN(hi-lo+1)
+}
+
+// minLen is the length of the shortest match of re, false if nothing matches.
+func minLen(re *syntax.Regexp) (int, bool) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return 0, false
+	case syntax.OpLiteral:
+		return len(re.Rune), true
+	case syntax.OpCharClass:
+		return 1, len(re.Rune) > 0
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1, true
+	case syntax.OpCapture, syntax.OpPlus:
+		return minLen(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		return 0, true
+	case syntax.OpRepeat:
+		n, ok := minLen(re.Sub[0])
+
+		return re.Min * n, ok || re.Min == 0
+	case syntax.OpConcat:
+		total := 0
+		for _, sub := range re.Sub {
+			n, ok := minLen(sub)
+			if !ok {
+				return 0, false
+			}
+			total += n
+		}
+
+		return total, true
+	case syntax.OpAlternate:
+		shortest, found := 0, false
+		for _, sub := range re.Sub {
+			if n, ok := minLen(sub); ok && (!found || n < shortest) {
+				shortest, found = n, true
+			}
+		}
+
+		return shortest, found
+	default:
+		return 0, true
+	}
+}
@@ -0,0 +1,58 @@
+package pattern_test
+
+import (
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jmozgit/datagen/internal/generator/pattern"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Matches(t *testing.T) {
+	t.Parallel()
+
+	for _, tC := range []struct {
+		expr   string
+		maxLen int
+	}{
+		{expr: `ORD-[0-9]{8}`, maxLen: 0},
+		{expr: `^[A-Z]{2}-\d{3,5}-(?:red|green|blue)$`, maxLen: 0},
+		{expr: `[a-z]+@[a-z]+\.(com|org)`, maxLen: 12},
+		{expr: `(?i)sku_[a-f0-9]*`, maxLen: 6},
+		{expr: `[^a-z]x?.\w\s`, maxLen: 0},
+		{expr: `ab{2,}c`, maxLen: 0},
+	} {
+		t.Run(tC.expr, func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := pattern.NewGenerator(xrand.New(1, tC.expr), tC.expr, tC.maxLen)
+			require.NoError(t, err)
+
+			re := regexp.MustCompile(`^(?:` + tC.expr + `)$`)
+			for range 200 {
+				val, err := gen.Gen(t.Context())
+				require.NoError(t, err)
+
+				str, ok := val.(string)
+				require.True(t, ok)
+				require.True(t, re.MatchString(str), str)
+				if tC.maxLen > 0 {
+					require.LessOrEqual(t, utf8.RuneCountInString(str), tC.maxLen, str)
+				}
+			}
+		})
+	}
+}
+
+func Test_InvalidPatterns(t *testing.T) {
+	t.Parallel()
+
+	_, err := pattern.NewGenerator(xrand.New(1), `[0-9`, 0)
+	require.ErrorIs(t, err, pattern.ErrInvalidPattern)
+
+	_, err = pattern.NewGenerator(xrand.New(1), `ORD-[0-9]{8}`, 10)
+	require.ErrorIs(t, err, pattern.ErrPatternTooLong)
+}
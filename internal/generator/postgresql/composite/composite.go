@@ -0,0 +1,37 @@
+package composite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+type generator struct {
+	fields []model.Generator
+}
+
+// NewGenerator builds records out of values of the field generators, in their order.
+func NewGenerator(fields []model.Generator) model.Generator {
+	return generator{fields: fields}
+}
+
+func (g generator) Gen(ctx context.Context) (any, error) {
+	record := make(model.Record, len(g.fields))
+	for i, field := range g.fields {
+		val, err := field.Gen(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: composite: gen", err)
+		}
+
+		record[i] = val
+	}
+
+	return record, nil
+}
+
+func (g generator) Close() {
+	for _, field := range g.fields {
+		field.Close()
+	}
+}
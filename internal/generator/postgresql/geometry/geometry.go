@@ -17,39 +17,39 @@ func isZero(a float64) bool {
 	return a < 1e-9
 }
 
-var sourceNameGenerators = map[string]func() any{
-	"box": func() any {
+var sourceNameGenerators = map[string]func(rnd *rand.Rand) any{
+	"box": func(rnd *rand.Rand) any {
 		return pgtype.Box{
 			P: [2]pgtype.Vec2{
 				{
-					X: rand.Float64()*180 - 90,
-					Y: rand.Float64()*360 - 180,
+					X: rnd.Float64()*180 - 90,
+					Y: rnd.Float64()*360 - 180,
 				},
 				{
-					X: rand.Float64()*180 - 90,
-					Y: rand.Float64()*360 - 180,
+					X: rnd.Float64()*180 - 90,
+					Y: rnd.Float64()*360 - 180,
 				},
 			},
 			Valid: true,
 		}
 	},
-	"circle": func() any {
+	"circle": func(rnd *rand.Rand) any {
 		return pgtype.Circle{
 			P: pgtype.Vec2{
-				X: rand.Float64()*180 - 90,
-				Y: rand.Float64()*360 - 180,
+				X: rnd.Float64()*180 - 90,
+				Y: rnd.Float64()*360 - 180,
 			},
-			R:     rand.Float64() * 1000,
+			R:     rnd.Float64() * 1000,
 			Valid: true,
 		}
 	},
-	"line": func() any {
+	"line": func(rnd *rand.Rand) any {
 		var (
 			a float64
 			b float64
 		)
 		for {
-			a, b = math.Float64frombits(rand.Uint64()), math.Float64frombits(rand.Uint64())
+			a, b = math.Float64frombits(rnd.Uint64()), math.Float64frombits(rnd.Uint64())
 			a = math.Round(a*100) / 100
 			b = math.Round(b*100) / 100
 			if !isZero(a) || !isZero(b) {
@@ -60,57 +60,57 @@ var sourceNameGenerators = map[string]func() any{
 		return pgtype.Line{
 			A:     a,
 			B:     b,
-			C:     math.Float64frombits(rand.Uint64()),
+			C:     math.Float64frombits(rnd.Uint64()),
 			Valid: true,
 		}
 	},
-	"lseg": func() any {
+	"lseg": func(rnd *rand.Rand) any {
 		return pgtype.Lseg{
 			P: [2]pgtype.Vec2{
 				{
-					X: rand.Float64()*180 - 90,
-					Y: rand.Float64()*360 - 180,
+					X: rnd.Float64()*180 - 90,
+					Y: rnd.Float64()*360 - 180,
 				},
 				{
-					X: rand.Float64()*180 - 90,
-					Y: rand.Float64()*360 - 180,
+					X: rnd.Float64()*180 - 90,
+					Y: rnd.Float64()*360 - 180,
 				},
 			},
 			Valid: true,
 		}
 	},
-	"path": func() any {
-		size := rand.IntN(30) + 5
+	"path": func(rnd *rand.Rand) any {
+		size := rnd.IntN(30) + 5
 		p := make([]pgtype.Vec2, size)
 		for i := range p {
 			p[i] = pgtype.Vec2{
-				X: rand.Float64()*180 - 90,
-				Y: rand.Float64()*360 - 180,
+				X: rnd.Float64()*180 - 90,
+				Y: rnd.Float64()*360 - 180,
 			}
 		}
 
 		return pgtype.Path{
 			P:      p,
-			Closed: rand.IntN(2) == 0,
+			Closed: rnd.IntN(2) == 0,
 			Valid:  true,
 		}
 	},
-	"point": func() any {
+	"point": func(rnd *rand.Rand) any {
 		return pgtype.Point{
 			P: pgtype.Vec2{
-				X: rand.Float64()*180 - 90,
-				Y: rand.Float64()*360 - 180,
+				X: rnd.Float64()*180 - 90,
+				Y: rnd.Float64()*360 - 180,
 			},
 			Valid: true,
 		}
 	},
-	"polygon": func() any {
-		size := rand.IntN(30) + 5
+	"polygon": func(rnd *rand.Rand) any {
+		size := rnd.IntN(30) + 5
 		p := make([]pgtype.Vec2, size)
 		for i := range p {
 			p[i] = pgtype.Vec2{
-				X: rand.Float64()*180 - 90,
-				Y: rand.Float64()*360 - 180,
+				X: rnd.Float64()*180 - 90,
+				Y: rnd.Float64()*360 - 180,
 			}
 		}
 
@@ -121,23 +121,22 @@ var sourceNameGenerators = map[string]func() any{
 	},
 }
 
-type Generator struct {
-	bySourceName map[string]func() any
+type generator struct {
+	rnd *rand.Rand
+	gen func(rnd *rand.Rand) any
 }
 
-type generator func() any
-
 func (g generator) Gen(_ context.Context) (any, error) {
-	return g(), nil
+	return g.gen(g.rnd), nil
 }
 
 func (g generator) Close() {}
 
-func NewGenerator(sourceName string) (model.Generator, error) {
+func NewGenerator(rnd *rand.Rand, sourceName string) (model.Generator, error) {
 	gen, ok := sourceNameGenerators[sourceName]
 	if !ok {
 		return nil, fmt.Errorf("%w: new generator", ErrUnknownGeometryType)
 	}
 
-	return generator(gen), nil
+	return generator{rnd: rnd, gen: gen}, nil
 }
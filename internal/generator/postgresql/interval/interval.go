@@ -9,17 +9,19 @@ import (
 	"github.com/jmozgit/datagen/internal/model"
 )
 
-type generator struct{}
+type generator struct {
+	rnd *rand.Rand
+}
 
-func NewPostgresql() model.Generator {
-	return generator{}
+func NewPostgresql(rnd *rand.Rand) model.Generator {
+	return generator{rnd: rnd}
 }
 
 func (g generator) Gen(_ context.Context) (any, error) {
 	return pgtype.Interval{
-		Microseconds: rand.Int64N((time.Hour * 24).Microseconds()),
-		Days:         rand.Int32N(31),
-		Months:       rand.Int32N(12),
+		Microseconds: g.rnd.Int64N((time.Hour * 24).Microseconds()),
+		Days:         g.rnd.Int32N(31),
+		Months:       g.rnd.Int32N(12),
 		Valid:        true,
 	}, nil
 }
@@ -10,16 +10,17 @@ import (
 )
 
 type pgNumericGenerator struct {
+	rnd       *rand.Rand
 	scale     int
 	precision int
 }
 
-func NewPostgresqlNumericGenerator(scale int, precision int) model.Generator {
-	return pgNumericGenerator{scale: scale, precision: precision}
+func NewPostgresqlNumericGenerator(rnd *rand.Rand, scale int, precision int) model.Generator {
+	return pgNumericGenerator{rnd: rnd, scale: scale, precision: precision}
 }
 
-func randSign() int {
-	if rand.Int()%2 == 0 { //nolint:gosec // ok
+func randSign(rnd *rand.Rand) int {
+	if rnd.Int()%2 == 0 {
 		return 1
 	}
 
@@ -28,7 +29,7 @@ func randSign() int {
 
 func (p pgNumericGenerator) Gen(_ context.Context) (any, error) {
 	if p.precision == 0 {
-		return math.Float64frombits(rand.Uint64()), nil //nolint:gosec // ok
+		return math.Float64frombits(p.rnd.Uint64()), nil
 	}
 
 	if p.scale > 0 {
@@ -37,23 +38,23 @@ func (p pgNumericGenerator) Gen(_ context.Context) (any, error) {
 		case diff == 0:
 			minV := int(math.Pow10(p.precision - 1))
 			maxV := int(math.Pow10(p.precision))
-			sdigits := rand.IntN(maxV-minV) + minV //nolint:gosec // ok
-			sdigits = randSign() * sdigits
+			sdigits := p.rnd.IntN(maxV-minV) + minV
+			sdigits = randSign(p.rnd) * sdigits
 
 			return decimal.New(int64(sdigits), -int32(p.precision)), nil
 		case diff > 0:
 			minV := int(math.Pow10(p.precision - 1))
 			maxV := int(math.Pow10(p.precision))
-			sdigits := rand.IntN(maxV-minV) + minV //nolint:gosec // ok
-			sdigits = randSign() * sdigits
-			freqPart := p.scale + rand.IntN(p.precision) //nolint:gosec // ok
+			sdigits := p.rnd.IntN(maxV-minV) + minV
+			sdigits = randSign(p.rnd) * sdigits
+			freqPart := p.scale + p.rnd.IntN(p.precision)
 
 			return decimal.New(int64(sdigits), -int32(freqPart)), nil
 		case diff < 0:
 			minV := int(math.Pow10(p.precision - 1))
 			maxV := int(math.Pow10(p.precision))
-			sdigits := rand.IntN(maxV-minV) + minV //nolint:gosec // ok
-			sdigits = randSign() * sdigits
+			sdigits := p.rnd.IntN(maxV-minV) + minV
+			sdigits = randSign(p.rnd) * sdigits
 
 			return decimal.New(int64(sdigits), -int32(p.scale)), nil
 		}
@@ -62,9 +63,40 @@ func (p pgNumericGenerator) Gen(_ context.Context) (any, error) {
 	absS := int(math.Abs(float64(p.scale)))
 	maxDigits := int(math.Pow10(p.precision))
 	step := int(math.Pow10(absS))
-	val := randSign() * rand.IntN(maxDigits) * step //nolint:gosec // ok
+	val := randSign(p.rnd) * p.rnd.IntN(maxDigits) * step
 
 	return float64(val), nil
 }
 
 func (p pgNumericGenerator) Close() {}
+
+type inRangeGenerator struct {
+	rnd      *rand.Rand
+	min, max float64
+	scale    int
+}
+
+// NewInRangeGenerator generates values of [minV, maxV] rounded to scale digits, a negative scale keeps floats.
+func NewInRangeGenerator(rnd *rand.Rand, minV, maxV float64, scale int) model.Generator {
+	return inRangeGenerator{rnd: rnd, min: minV, max: maxV, scale: scale}
+}
+
+func (g inRangeGenerator) Gen(_ context.Context) (any, error) {
+	val := g.min + g.rnd.Float64()*(g.max-g.min)
+	if g.scale < 0 {
+		return val, nil
+	}
+
+	// rounding may step over a bound, the nearest value inside is taken then
+	dec := decimal.NewFromFloat(val).Round(int32(g.scale)) //nolint:gosec // scale is small
+	if lower := decimal.NewFromFloat(g.min); dec.LessThan(lower) {
+		dec = lower.RoundCeil(int32(g.scale)) //nolint:gosec // scale is small
+	}
+	if upper := decimal.NewFromFloat(g.max); dec.GreaterThan(upper) {
+		dec = upper.RoundFloor(int32(g.scale)) //nolint:gosec // scale is small
+	}
+
+	return dec, nil
+}
+
+func (g inRangeGenerator) Close() {}
@@ -0,0 +1,34 @@
+package oid
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+)
+
+// InlineGenerator makes large object contents of the same sizes as ApproximatelySizedGenerator
+// without touching the database, the saver decides how to create them.
+type InlineGenerator struct {
+	rnd            *rand.Rand
+	sizedBytes     int64
+	changeRangeAbs int64
+}
+
+func NewInlineGenerator(rnd *rand.Rand, sizedBytes int64, changeRangeAbs int64) *InlineGenerator {
+	return &InlineGenerator{
+		rnd:            rnd,
+		sizedBytes:     sizedBytes,
+		changeRangeAbs: changeRangeAbs,
+	}
+}
+
+func (g *InlineGenerator) Gen(_ context.Context) (any, error) {
+	data := make([]byte, max(0, nextSize(g.rnd, g.sizedBytes, g.changeRangeAbs)))
+	xrand.Read(g.rnd, data)
+
+	return model.LargeObject{Data: data}, nil
+}
+
+func (g *InlineGenerator) Close() {}
@@ -2,17 +2,18 @@ package oid
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
 	"io"
-	mathrand "math/rand/v2"
+	"math/rand/v2"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 )
 
 type ApproximatelySizedGenerator struct {
+	rnd            *rand.Rand
 	pool           *pgxpool.Pool
 	sizedBytes     int64
 	changeRangeAbs int64
@@ -22,6 +23,7 @@ type ApproximatelySizedGenerator struct {
 }
 
 func NewApproximatelySizedGenerator(
+	rnd *rand.Rand,
 	pool *pgxpool.Pool,
 	sizedBytes int64, changeRangeAbs int64,
 	resolver model.ReferenceResolver,
@@ -29,6 +31,7 @@ func NewApproximatelySizedGenerator(
 	column model.Identifier,
 ) (model.LOGenerator, func()) {
 	g := &ApproximatelySizedGenerator{
+		rnd:            rnd,
 		pool:           pool,
 		sizedBytes:     sizedBytes,
 		changeRangeAbs: changeRangeAbs,
@@ -41,17 +44,21 @@ func NewApproximatelySizedGenerator(
 	}
 }
 
-func (g *ApproximatelySizedGenerator) Gen(ctx context.Context) (any, error) {
-	sign := mathrand.Int() % 2
+func nextSize(rnd *rand.Rand, sizedBytes int64, changeRangeAbs int64) int64 {
+	sign := rnd.Int() % 2
 	if sign == 0 {
 		sign = -1
 	}
-	rng := g.changeRangeAbs
-	if g.changeRangeAbs > 0 {
-		rng = mathrand.Int64N(g.changeRangeAbs)
+	rng := changeRangeAbs
+	if changeRangeAbs > 0 {
+		rng = rnd.Int64N(changeRangeAbs)
 	}
 
-	oid, err := g.createAndFillOID(ctx, g.sizedBytes+int64(sign)*rng)
+	return sizedBytes + int64(sign)*rng
+}
+
+func (g *ApproximatelySizedGenerator) Gen(ctx context.Context) (any, error) {
+	oid, err := g.createAndFillOID(ctx, nextSize(g.rnd, g.sizedBytes, g.changeRangeAbs))
 	if err != nil {
 		return nil, fmt.Errorf("%w: gen", err)
 	}
@@ -93,7 +100,7 @@ func (g *ApproximatelySizedGenerator) createAndFillOID(ctx context.Context, size
 	}
 	defer obj.Close()
 
-	if _, err := io.CopyN(obj, rand.Reader, size); err != nil {
+	if _, err := io.CopyN(obj, xrand.Reader(g.rnd), size); err != nil {
 		return 0, fmt.Errorf("%w: create and fill oid", err)
 	}
 
@@ -0,0 +1,256 @@
+package rangetype
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrWidthTooLarge   = errors.New("range width doesn't fit the subtype")
+	ErrUnexpectedBound = errors.New("unexpected range bound")
+)
+
+const (
+	// maxRedraws bounds attempts to draw distinct bounds before giving up on a non empty range.
+	maxRedraws = 8
+	// maxMultirangeLen is the largest number of ranges drawn for one multirange.
+	maxMultirangeLen = 3
+)
+
+// Subtype is the arithmetic on values of the range subtype the generator needs.
+type Subtype[T any] interface {
+	Less(a, b T) bool
+	// Shift moves v by width units of the subtype, ok is false when the result leaves the subtype.
+	Shift(v T, width float64) (T, bool)
+}
+
+type Options struct {
+	LowerType pgtype.BoundType
+	UpperType pgtype.BoundType
+	// EmptyFraction is the percent of empty ranges
+	EmptyFraction int
+	// MinWidth and MaxWidth are in units of the subtype, zero MaxWidth leaves the width to the drawn bounds
+	MinWidth float64
+	MaxWidth float64
+	// Multi makes multiranges of the ranges
+	Multi bool
+}
+
+type Generator[T any] struct {
+	rnd     *rand.Rand
+	gen     model.Generator
+	subtype Subtype[T]
+	opts    Options
+}
+
+// NewGenerator builds ranges with bounds drawn from gen, ordered by subtype.
+func NewGenerator[T any](rnd *rand.Rand, gen model.Generator, subtype Subtype[T], opts Options) *Generator[T] {
+	return &Generator[T]{
+		rnd:     rnd,
+		gen:     gen,
+		subtype: subtype,
+		opts:    opts,
+	}
+}
+
+func (g *Generator[T]) Gen(ctx context.Context) (any, error) {
+	const fnName = "range: gen"
+
+	empty := g.opts.EmptyFraction > 0 && g.rnd.IntN(100) < g.opts.EmptyFraction
+	if !g.opts.Multi {
+		if empty {
+			//nolint:exhaustruct // empty ranges have no bounds
+			return pgtype.Range[T]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}, nil
+		}
+
+		r, err := g.genRange(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return r, nil
+	}
+
+	// an empty multirange has no ranges at all, postgresql merges overlapping ones on its own
+	multirange := make(pgtype.Multirange[pgtype.Range[T]], 0, maxMultirangeLen)
+	if empty {
+		return multirange, nil
+	}
+
+	for range 1 + g.rnd.IntN(maxMultirangeLen) {
+		r, err := g.genRange(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		multirange = append(multirange, r)
+	}
+
+	return multirange, nil
+}
+
+func (g *Generator[T]) genRange(ctx context.Context) (pgtype.Range[T], error) {
+	lower, err := g.bound(ctx)
+	if err != nil {
+		return pgtype.Range[T]{}, err
+	}
+
+	var upper T
+	if g.opts.MaxWidth > 0 {
+		width := g.opts.MinWidth + g.rnd.Float64()*(g.opts.MaxWidth-g.opts.MinWidth)
+		lower, upper, err = g.widen(lower, lower, width)
+	} else {
+		lower, upper, err = g.drawUpper(ctx, lower)
+	}
+	if err != nil {
+		return pgtype.Range[T]{}, err
+	}
+
+	return pgtype.Range[T]{
+		Lower:     lower,
+		Upper:     upper,
+		LowerType: g.opts.LowerType,
+		UpperType: g.opts.UpperType,
+		Valid:     true,
+	}, nil
+}
+
+// drawUpper draws the other bound and orders both, MinWidth is added on top of their distance.
+func (g *Generator[T]) drawUpper(ctx context.Context, lower T) (T, T, error) {
+	var upper T
+	for range maxRedraws {
+		var err error
+		upper, err = g.bound(ctx)
+		if err != nil {
+			return lower, upper, err
+		}
+
+		// equal bounds make an empty range unless both are inclusive
+		if g.subtype.Less(lower, upper) || g.subtype.Less(upper, lower) {
+			break
+		}
+	}
+
+	if g.subtype.Less(upper, lower) {
+		lower, upper = upper, lower
+	}
+
+	if g.opts.MinWidth == 0 {
+		return lower, upper, nil
+	}
+
+	return g.widen(lower, upper, g.opts.MinWidth)
+}
+
+// widen moves upper up by width, or lower down when upper would leave the subtype.
+func (g *Generator[T]) widen(lower, upper T, width float64) (T, T, error) {
+	if shifted, ok := g.subtype.Shift(upper, width); ok {
+		return lower, shifted, nil
+	}
+
+	if shifted, ok := g.subtype.Shift(lower, -width); ok {
+		return shifted, upper, nil
+	}
+
+	return lower, upper, fmt.Errorf("%w: %v", ErrWidthTooLarge, width)
+}
+
+func (g *Generator[T]) bound(ctx context.Context) (T, error) {
+	raw, err := g.gen.Gen(ctx)
+	if err != nil {
+		var zero T
+
+		return zero, err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	val, ok := raw.(T)
+	if !ok {
+		return val, fmt.Errorf("%w: %T", ErrUnexpectedBound, raw)
+	}
+
+	return val, nil
+}
+
+func (g *Generator[T]) Close() {
+	g.gen.Close()
+}
+
+type integerSubtype struct {
+	minV int64
+	maxV int64
+}
+
+// NewIntegerSubtype is the subtype of int64 values within [minV, maxV].
+func NewIntegerSubtype(minV, maxV int64) Subtype[int64] {
+	return integerSubtype{minV: minV, maxV: maxV}
+}
+
+func (s integerSubtype) Less(a, b int64) bool {
+	return a < b
+}
+
+func (s integerSubtype) Shift(v int64, width float64) (int64, bool) {
+	w := int64(math.Round(width))
+	if (w > 0 && v > s.maxV-w) || (w < 0 && v < s.minV-w) {
+		return v, false
+	}
+
+	return v + w, true
+}
+
+type floatSubtype struct{}
+
+// NewFloatSubtype is the subtype of float64 values.
+func NewFloatSubtype() Subtype[float64] {
+	return floatSubtype{}
+}
+
+func (s floatSubtype) Less(a, b float64) bool {
+	return a < b
+}
+
+func (s floatSubtype) Shift(v float64, width float64) (float64, bool) {
+	shifted := v + width
+
+	return shifted, !math.IsInf(shifted, 0)
+}
+
+type timeSubtype struct {
+	days bool
+}
+
+// NewTimeSubtype is the subtype of time.Time values, widths are nanoseconds.
+// With days only the date of values counts and widths are whole days.
+func NewTimeSubtype(days bool) Subtype[time.Time] {
+	return timeSubtype{days: days}
+}
+
+func (s timeSubtype) Less(a, b time.Time) bool {
+	if s.days {
+		return date(a).Before(date(b))
+	}
+
+	return a.Before(b)
+}
+
+func (s timeSubtype) Shift(v time.Time, width float64) (time.Time, bool) {
+	if s.days {
+		return v.AddDate(0, 0, int(math.Round(width/float64(24*time.Hour)))), true
+	}
+
+	return v.Add(time.Duration(width)), true
+}
+
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
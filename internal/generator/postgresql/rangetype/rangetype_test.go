@@ -0,0 +1,88 @@
+package rangetype_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/generator/postgresql/rangetype"
+	"github.com/jmozgit/datagen/internal/generator/timestamp"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IntegerRanges(t *testing.T) {
+	t.Parallel()
+
+	rnd := xrand.New(1, "int4range")
+	// bounds close to the top of int4 have to be shifted down to fit the width
+	gen := rangetype.NewGenerator(
+		rnd,
+		integer.NewRandomInRangeGenerator(rnd, math.MaxInt32-100, math.MaxInt32),
+		rangetype.NewIntegerSubtype(math.MinInt32, math.MaxInt32),
+		rangetype.Options{
+			LowerType:     pgtype.Inclusive,
+			UpperType:     pgtype.Inclusive,
+			EmptyFraction: 20,
+			MinWidth:      200,
+			MaxWidth:      300,
+			Multi:         false,
+		},
+	)
+
+	empty := 0
+	for range 1000 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		r, ok := val.(pgtype.Range[int64])
+		require.True(t, ok)
+		if r.LowerType == pgtype.Empty {
+			empty++
+
+			continue
+		}
+
+		require.Equal(t, pgtype.Inclusive, r.LowerType)
+		require.Equal(t, pgtype.Inclusive, r.UpperType)
+		require.LessOrEqual(t, r.Upper, int64(math.MaxInt32))
+		require.GreaterOrEqual(t, r.Upper-r.Lower, int64(200))
+		require.LessOrEqual(t, r.Upper-r.Lower, int64(300))
+	}
+	require.InDelta(t, 200, empty, 60)
+}
+
+func Test_DateMultiranges(t *testing.T) {
+	t.Parallel()
+
+	rnd := xrand.New(1, "datemultirange")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	gen := rangetype.NewGenerator(
+		rnd,
+		timestamp.NewInRangeGenerator(rnd, from, from.AddDate(0, 0, 3)),
+		rangetype.NewTimeSubtype(true),
+		rangetype.Options{
+			LowerType:     pgtype.Inclusive,
+			UpperType:     pgtype.Exclusive,
+			EmptyFraction: 0,
+			MinWidth:      float64(24 * time.Hour),
+			MaxWidth:      0,
+			Multi:         true,
+		},
+	)
+
+	for range 100 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		multirange, ok := val.(pgtype.Multirange[pgtype.Range[time.Time]])
+		require.True(t, ok)
+		require.NotEmpty(t, multirange)
+		for _, r := range multirange {
+			require.GreaterOrEqual(t, r.Upper.Sub(r.Lower), 24*time.Hour)
+		}
+	}
+}
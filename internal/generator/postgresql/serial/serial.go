@@ -33,3 +33,46 @@ func (s *seqGenerator) Gen(ctx context.Context) (any, error) {
 }
 
 func (s *seqGenerator) Close() {}
+
+type seqPeekGenerator struct {
+	conn    db.Connect
+	seqName string
+	next    int64
+	step    int64
+	fetched bool
+}
+
+// NewSeqPeekGenerator counts from the next value of the sequence without taking it,
+// so a dry run leaves the sequence as it is.
+func NewSeqPeekGenerator(conn db.Connect, seqName string) model.Generator {
+	return &seqPeekGenerator{
+		conn:    conn,
+		seqName: seqName,
+		next:    0,
+		step:    0,
+		fetched: false,
+	}
+}
+
+func (s *seqPeekGenerator) Gen(ctx context.Context) (any, error) {
+	const query = `
+	SELECT
+		COALESCE(last_value + increment_by, start_value), increment_by
+	FROM pg_sequences
+	WHERE format('%I.%I', schemaname, sequencename)::regclass = $1::regclass
+	`
+
+	if !s.fetched {
+		if err := s.conn.QueryRow(ctx, query, s.seqName).Scan(&s.next, &s.step); err != nil {
+			return nil, fmt.Errorf("%w: serial peek gen", err)
+		}
+		s.fetched = true
+	}
+
+	next := s.next
+	s.next += s.step
+
+	return next, nil
+}
+
+func (s *seqPeekGenerator) Close() {}
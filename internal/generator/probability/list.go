@@ -12,12 +12,13 @@ import (
 var ErrInvalidDistValues = errors.New("invalid dist or values")
 
 type List[T any] struct {
+	rnd       *rand.Rand
 	values    []T
 	prefixSum []int
 	totalSum  int
 }
 
-func NewList[T any](dist []int, values []T) (model.Generator, error) {
+func NewList[T any](rnd *rand.Rand, dist []int, values []T) (model.Generator, error) {
 	const fnName = "probability: new list"
 
 	if len(dist) == 0 {
@@ -36,6 +37,7 @@ func NewList[T any](dist []int, values []T) (model.Generator, error) {
 	}
 
 	return &List[T]{
+		rnd:       rnd,
 		values:    values,
 		prefixSum: prefixSum,
 		totalSum:  totalSum,
@@ -43,7 +45,7 @@ func NewList[T any](dist []int, values []T) (model.Generator, error) {
 }
 
 func (l *List[T]) Gen(_ context.Context) (any, error) {
-	sector := rand.IntN(l.totalSum + 1)
+	sector := l.rnd.IntN(l.totalSum + 1)
 	for i := range l.prefixSum {
 		if sector <= l.prefixSum[i] {
 			return l.values[i], nil
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/jmozgit/datagen/internal/generator/probability"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,7 +28,7 @@ func Test_ListProbabilityGenerator(t *testing.T) {
 		totalCnt += p
 	}
 
-	generator, err := probability.NewList(probabilities, types)
+	generator, err := probability.NewList(xrand.New(1), probabilities, types)
 	require.NoError(t, err)
 
 	stat := make(map[string]int)
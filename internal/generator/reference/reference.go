@@ -4,18 +4,35 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/jmozgit/datagen/internal/model"
 )
 
 var Sink any
 
+// readInterval is how often an empty parent is read again while it's being filled.
+const readInterval = 100 * time.Millisecond
+
 type BufferedValues struct {
 	columnReader model.ColumnValueReader
 	refTable     model.TableName
 	refCol       model.Identifier
 	batchSize    int
 	next         chan any
+	parentDone   <-chan struct{}
+
+	// values are pushed from reads and save callbacks, the latter may outlive the generator
+	mu     sync.Mutex
+	closed bool
+	// recent values are taken again once the finished parent has nothing else
+	recent []any
+	pushed int
+	taken  int
+	// exhausted is set once the finished parent has no rows to read
+	exhausted bool
 }
 
 func NewBufferedValuesGenerator(
@@ -32,6 +49,13 @@ func NewBufferedValuesGenerator(
 		refCol:       refCol,
 		batchSize:    bufferedSize,
 		next:         make(chan any, bufferedSize),
+		parentDone:   refresolver.Finished(refTable),
+		mu:           sync.Mutex{},
+		closed:       false,
+		recent:       make([]any, 0, bufferedSize),
+		pushed:       0,
+		taken:        0,
+		exhausted:    false,
 	}
 
 	return buf, func() {
@@ -49,49 +73,106 @@ func (b *BufferedValues) Gen(ctx context.Context) (any, error) {
 }
 
 func (b *BufferedValues) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
 	close(b.next)
 }
 
+// push doesn't block, the value is dropped if the buffer is full or the generator is closed.
+func (b *BufferedValues) push(val any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	if len(b.recent) < b.batchSize {
+		b.recent = append(b.recent, val)
+	} else {
+		b.recent[b.pushed%b.batchSize] = val
+	}
+	b.pushed++
+
+	select {
+	case b.next <- val:
+	default:
+	}
+}
+
+// waitNextValue reads the parent once the buffer is empty and waits for saved values then,
+// reading it again no more often than readInterval. A finished parent without rows gives NULL.
 func (b *BufferedValues) waitNextValue(ctx context.Context) (any, error) {
 	for {
 		select {
 		case val := <-b.next:
 			return val, nil
+		default:
+		}
+
+		if b.exhausted {
+			return b.recentValue(), nil
+		}
+
+		if b.fallbackRead(ctx) {
+			continue
+		}
+
+		select {
+		case val := <-b.next:
+			return val, nil
+		case <-b.parentDone:
+			b.exhausted = true
+
+			return b.recentValue(), nil
 		case <-ctx.Done():
 			return nil, fmt.Errorf("%w: wait next value", ctx.Err())
-		default:
-			b.fallbackRead(ctx)
+		case <-time.After(readInterval):
 		}
 	}
 }
 
-func (b *BufferedValues) fallbackRead(ctx context.Context) {
+// fallbackRead returns false if the parent has no rows.
+func (b *BufferedValues) fallbackRead(ctx context.Context) bool {
 	values, err := b.columnReader.ReadValues(ctx)
 	if err != nil {
 		slog.Error("failed to read values", slog.Any("error", err))
-		return
+		return false
 	}
 
-	go func() {
-		for _, val := range values {
-			select {
-			case b.next <- val:
-			default:
-				continue
-			}
-		}
-	}()
+	for _, val := range values {
+		b.push(val)
+	}
+
+	return len(values) > 0
+}
+
+// recentValue takes pushed values round, nil if there were none.
+func (b *BufferedValues) recentValue() any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.recent) == 0 {
+		return nil
+	}
+
+	val := b.recent[b.taken%len(b.recent)]
+	b.taken++
+
+	return val
 }
 
 func (b *BufferedValues) onTargetSavedValues(
 	batch model.SaveBatch,
 ) {
-	idx := 0
-	for i := 1; i < len(batch.Schema.Columns); i++ {
-		if batch.Schema.Columns[i].SourceName == b.refCol {
-			idx = i
-			break
-		}
+	idx := slices.IndexFunc(batch.Schema.Columns, func(col model.TargetType) bool {
+		return col.SourceName == b.refCol
+	})
+	// the referenced column might be filled by the database, its values are read back then
+	if idx == -1 {
+		return
 	}
 
 	for i, row := range batch.Data {
@@ -99,10 +180,6 @@ func (b *BufferedValues) onTargetSavedValues(
 			continue
 		}
 
-		select {
-		case b.next <- row[idx]:
-		default:
-			continue
-		}
+		b.push(row[idx])
 	}
 }
@@ -12,6 +12,7 @@ import (
 var ErrCantTakeValue = errors.New("can't take value")
 
 type generator struct {
+	rnd           *rand.Rand
 	fallback      model.ColumnValueReader
 	reuseFraction int
 	buff          []any
@@ -20,6 +21,7 @@ type generator struct {
 
 func NewGenerator(
 	ctx context.Context,
+	rnd *rand.Rand,
 	fallback model.ColumnValueReader,
 	reuseFraction int,
 	baseGenerator model.Generator,
@@ -30,6 +32,7 @@ func NewGenerator(
 	}
 
 	return &generator{
+		rnd:           rnd,
 		fallback:      fallback,
 		reuseFraction: reuseFraction,
 		buff:          vals,
@@ -42,11 +45,11 @@ func (g *generator) Close() {
 }
 
 func (g *generator) Gen(ctx context.Context) (any, error) {
-	if rand.IntN(100) > g.reuseFraction {
+	if g.rnd.IntN(100) > g.reuseFraction {
 		return g.genFromGenerator(ctx)
 	}
 
-	if rand.Int()%41 == 0 {
+	if g.rnd.Int()%41 == 0 {
 		if err := g.resetBuf(ctx); err != nil {
 			return nil, fmt.Errorf("%w: reuse gen", err)
 		}
@@ -60,7 +63,7 @@ func (g *generator) Gen(ctx context.Context) (any, error) {
 		return g.genFromGenerator(ctx)
 	}
 
-	idx := rand.IntN(len(g.buff))
+	idx := g.rnd.IntN(len(g.buff))
 	return g.buff[idx], nil
 }
 
@@ -81,10 +84,10 @@ func (g *generator) genFromGenerator(ctx context.Context) (any, error) {
 		return nil, fmt.Errorf("%w: reuse gen", err)
 	}
 
-	storeValue := rand.Int()%2 == 0
+	storeValue := g.rnd.Int()%2 == 0
 	if storeValue {
 		if len(g.buff) >= 10 {
-			g.buff[rand.IntN(len(g.buff))] = val
+			g.buff[g.rnd.IntN(len(g.buff))] = val
 		}
 		g.buff = append(g.buff, val)
 	}
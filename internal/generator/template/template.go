@@ -0,0 +1,166 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// Segment is either literal text or a placeholder action made of a name and its arguments.
+type Segment struct {
+	Literal string
+	Action  []string
+}
+
+// Parse splits text into literals and {{name arg...}} placeholders, quoted arguments may hold spaces.
+func Parse(text string) ([]Segment, error) {
+	const fnName = "parse template"
+
+	var segments []Segment
+	for text != "" {
+		literal, rest, found := strings.Cut(text, "{{")
+		if literal != "" {
+			segments = append(segments, Segment{Literal: literal, Action: nil})
+		}
+
+		if !found {
+			break
+		}
+
+		action, rest, found := cutAction(rest)
+		if !found {
+			return nil, fmt.Errorf("%w: unclosed placeholder: %s", ErrInvalidTemplate, fnName)
+		}
+
+		args, err := splitAction(action)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		segments = append(segments, Segment{Literal: "", Action: args})
+		text = rest
+	}
+
+	return segments, nil
+}
+
+// cutAction finds the closing braces outside of quotes.
+func cutAction(text string) (string, string, bool) {
+	quoted, escaped := false, false
+	for i, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(text[i:], "}}"):
+			return text[:i], text[i+2:], true
+		}
+	}
+
+	return "", "", false
+}
+
+func splitAction(action string) ([]string, error) {
+	var args []string
+
+	for action = strings.TrimSpace(action); action != ""; action = strings.TrimSpace(action) {
+		if action[0] != '"' {
+			end := strings.IndexFunc(action, unicode.IsSpace)
+			if end == -1 {
+				end = len(action)
+			}
+			args = append(args, action[:end])
+			action = action[end:]
+
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(action)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: %s", ErrInvalidTemplate, err, action)
+		}
+
+		arg, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: %s", ErrInvalidTemplate, err, quoted)
+		}
+		args = append(args, arg)
+		action = action[len(quoted):]
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: empty placeholder", ErrInvalidTemplate)
+	}
+
+	return args, nil
+}
+
+// Part is literal text if Generator is nil.
+type Part struct {
+	Literal   string
+	Generator model.Generator
+}
+
+type generator struct {
+	parts  []Part
+	maxLen int
+}
+
+// NewGenerator renders parts into a string per value, values longer than a positive maxLen characters are cut.
+func NewGenerator(parts []Part, maxLen int) model.Generator {
+	return generator{parts: parts, maxLen: maxLen}
+}
+
+func (g generator) Gen(ctx context.Context) (any, error) {
+	var builder strings.Builder
+
+	for _, part := range g.parts {
+		if part.Generator == nil {
+			builder.WriteString(part.Literal)
+
+			continue
+		}
+
+		val, err := part.Generator.Gen(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: template gen", err)
+		}
+
+		switch v := val.(type) {
+		case nil:
+		case []byte:
+			builder.Write(v)
+		case time.Time:
+			builder.WriteString(v.Format(time.RFC3339))
+		default:
+			fmt.Fprint(&builder, v)
+		}
+	}
+
+	val := builder.String()
+	if g.maxLen > 0 && utf8.RuneCountInString(val) > g.maxLen {
+		val = string([]rune(val)[:g.maxLen])
+	}
+
+	return val, nil
+}
+
+func (g generator) Close() {
+	for _, part := range g.parts {
+		if part.Generator != nil {
+			part.Generator.Close()
+		}
+	}
+}
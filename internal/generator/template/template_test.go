@@ -0,0 +1,60 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/generator/integer"
+	"github.com/jmozgit/datagen/internal/generator/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+
+	segments, err := template.Parse(`{{faker.first_name}}.{{ seq }}@{{oneof "a.com" "b }}.com"}}!`)
+	require.NoError(t, err)
+	require.Equal(t, []template.Segment{
+		{Literal: "", Action: []string{"faker.first_name"}},
+		{Literal: ".", Action: nil},
+		{Literal: "", Action: []string{"seq"}},
+		{Literal: "@", Action: nil},
+		{Literal: "", Action: []string{"oneof", "a.com", "b }}.com"}},
+		{Literal: "!", Action: nil},
+	}, segments)
+
+	for _, text := range []string{`{{seq`, `{{ }}`, `{{oneof "a}}`} {
+		_, err := template.Parse(text)
+		require.ErrorIs(t, err, template.ErrInvalidTemplate, text)
+	}
+}
+
+func Test_Gen(t *testing.T) {
+	t.Parallel()
+
+	gen := template.NewGenerator([]template.Part{
+		{Literal: "ORD-", Generator: nil},
+		{Literal: "", Generator: integer.NewSerialIntegerGenerator(7)},
+	}, 0)
+	defer gen.Close()
+
+	for _, expected := range []string{"ORD-7", "ORD-8"} {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, expected, val)
+	}
+}
+
+func Test_GenCutsToMaxLen(t *testing.T) {
+	t.Parallel()
+
+	gen := template.NewGenerator([]template.Part{
+		{Literal: "Zoë-", Generator: nil},
+		{Literal: "", Generator: integer.NewSerialIntegerGenerator(1234)},
+	}, 5)
+	defer gen.Close()
+
+	val, err := gen.Gen(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "Zoë-1", val)
+}
@@ -2,42 +2,47 @@ package text
 
 import (
 	"context"
-	mathrand "math/rand/v2"
+	"math/rand/v2"
 
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/xrand"
 )
 
 type inRangeSizeGenerator struct {
+	rnd      *rand.Rand
 	from, to int
 }
 
-func NewInRangeSizeGenerator(from, to int) model.Generator {
+func NewInRangeSizeGenerator(rnd *rand.Rand, from, to int) model.Generator {
 	return inRangeSizeGenerator{
+		rnd:  rnd,
 		from: from,
 		to:   to,
 	}
 }
 
 func (i inRangeSizeGenerator) Gen(_ context.Context) (any, error) {
-	sz := i.from + mathrand.IntN(i.to-i.from+1)
-	return xrand.String(sz), nil
+	sz := i.from + i.rnd.IntN(i.to-i.from+1)
+
+	return xrand.String(i.rnd, sz), nil
 }
 
 func (i inRangeSizeGenerator) Close() {}
 
 type fixedSizeGenerator struct {
+	rnd  *rand.Rand
 	size int
 }
 
-func NewFixedSizedStringGenerator(size int) model.Generator {
+func NewFixedSizedStringGenerator(rnd *rand.Rand, size int) model.Generator {
 	return fixedSizeGenerator{
+		rnd:  rnd,
 		size: size,
 	}
 }
 
 func (a fixedSizeGenerator) Gen(_ context.Context) (any, error) {
-	return xrand.String(a.size), nil
+	return xrand.String(a.rnd, a.size), nil
 }
 
 func (a fixedSizeGenerator) Close() {}
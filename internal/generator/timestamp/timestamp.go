@@ -2,40 +2,68 @@ package timestamp
 
 import (
 	"context"
+	"math"
 	"math/rand/v2"
 	"time"
 
+	"github.com/jmozgit/datagen/internal/generator/distribution"
 	"github.com/jmozgit/datagen/internal/model"
 )
 
-type alwaysNow struct{}
+type alwaysNow struct {
+	now time.Time
+}
 
-func NewAlwaysNowGenerator() model.Generator {
-	return alwaysNow{}
+// NewAlwaysNowGenerator gives the reference time of the run rather than the clock, so seeded runs repeat.
+func NewAlwaysNowGenerator(now time.Time) model.Generator {
+	return alwaysNow{now: now}
 }
 
 func (a alwaysNow) Gen(_ context.Context) (any, error) {
-	return time.Now(), nil
+	return a.now, nil
 }
 
 func (a alwaysNow) Close() {}
 
 type inRange struct {
+	rnd  *rand.Rand
 	from time.Time
 	to   time.Time
 }
 
-func NewInRangeGenerator(from, to time.Time) inRange {
-	return inRange{from: from, to: to}
+func NewInRangeGenerator(rnd *rand.Rand, from, to time.Time) inRange {
+	return inRange{rnd: rnd, from: from, to: to}
 }
 
 func (i inRange) Gen(_ context.Context) (any, error) {
 	fromUnix := i.from.Unix()
 	toUnix := i.to.Unix()
 
-	sec := fromUnix + rand.Int64N(toUnix-fromUnix) //nolint:gosec // ok
+	sec := fromUnix + i.rnd.Int64N(toUnix-fromUnix)
 
 	return time.Unix(sec, 0), nil
 }
 
 func (i inRange) Close() {}
+
+type distributed struct {
+	sampler *distribution.Sampler
+	from    time.Time
+}
+
+// NewDistributedGenerator makes timestamps the sampled number of days after from.
+func NewDistributedGenerator(sampler *distribution.Sampler, from time.Time) model.Generator {
+	return distributed{sampler: sampler, from: from}
+}
+
+func (d distributed) Gen(_ context.Context) (any, error) {
+	const secondsInDay = 24 * 60 * 60
+
+	sec, frac := math.Modf(d.sampler.Sample() * secondsInDay)
+	nsec := int64(d.from.Nanosecond()) + int64(frac*float64(time.Second))
+
+	// time.Unix carries nanoseconds beyond a second over to seconds
+	return time.Unix(d.from.Unix()+int64(sec), nsec), nil
+}
+
+func (d distributed) Close() {}
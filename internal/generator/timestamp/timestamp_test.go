@@ -0,0 +1,40 @@
+package timestamp_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/generator/distribution"
+	"github.com/jmozgit/datagen/internal/generator/timestamp"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DistributedKeepsNanoseconds(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // uniform takes no parameters
+	sampler, err := distribution.New(rand.New(rand.NewPCG(1, 2)), distribution.Params{}, 0, 1)
+	require.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := timestamp.NewDistributedGenerator(sampler, from)
+
+	withNanos := 0
+	for range 100 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		moment, ok := val.(time.Time)
+		require.True(t, ok)
+		require.False(t, moment.Before(from))
+		require.False(t, moment.After(from.Add(24*time.Hour)))
+
+		if moment.Nanosecond() != 0 {
+			withNanos++
+		}
+	}
+
+	require.Greater(t, withNanos, 90)
+}
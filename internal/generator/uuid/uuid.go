@@ -2,7 +2,11 @@ package uuid
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net"
+	"time"
 
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/xrand"
@@ -12,14 +16,48 @@ import (
 
 const systemNameLen = 10
 
-type uuidV1Generator struct{}
+var errRandomHWAddr = errors.New("hardware address is taken from the random source")
 
-func NewUUIDV1Generator() model.Generator {
-	return uuidV1Generator{}
+// newGen builds a uuid generator which takes its random bits from rnd.
+func newGen(rnd *rand.Rand) *gouuid.Gen {
+	return gouuid.NewGenWithOptions(gouuid.WithRandomReader(xrand.Reader(rnd)))
+}
+
+// clock ticks a millisecond from the reference time on every uuid.
+type clock struct {
+	next time.Time
+}
+
+func (c *clock) now() time.Time {
+	now := c.next
+	c.next = c.next.Add(time.Millisecond)
+
+	return now
+}
+
+// newTimeGen builds a generator of time based versions which embed a clock started at now
+// and a random hardware address instead of the real ones, so they repeat with the seed.
+// A resumed run starts the clock at now again.
+func newTimeGen(rnd *rand.Rand, now time.Time) *gouuid.Gen {
+	c := &clock{next: now}
+
+	return gouuid.NewGenWithOptions(
+		gouuid.WithRandomReader(xrand.Reader(rnd)),
+		gouuid.WithEpochFunc(c.now),
+		gouuid.WithHWAddrFunc(func() (net.HardwareAddr, error) { return nil, errRandomHWAddr }),
+	)
+}
+
+type uuidV1Generator struct {
+	gen *gouuid.Gen
+}
+
+func NewUUIDV1Generator(rnd *rand.Rand, now time.Time) model.Generator {
+	return uuidV1Generator{gen: newTimeGen(rnd, now)}
 }
 
 func (u uuidV1Generator) Gen(_ context.Context) (any, error) {
-	val, err := gouuid.NewV1()
+	val, err := u.gen.NewV1()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v1 gen", err)
 	}
@@ -29,31 +67,36 @@ func (u uuidV1Generator) Gen(_ context.Context) (any, error) {
 
 func (u uuidV1Generator) Close() {}
 
-func NewUUIDV3Generator() model.Generator {
-	return uuidV3Generator{}
+func NewUUIDV3Generator(rnd *rand.Rand) model.Generator {
+	return uuidV3Generator{rnd: rnd, gen: newGen(rnd)}
 }
 
-type uuidV3Generator struct{}
+type uuidV3Generator struct {
+	rnd *rand.Rand
+	gen *gouuid.Gen
+}
 
 func (u uuidV3Generator) Gen(_ context.Context) (any, error) {
-	v4, err := gouuid.NewV4()
+	v4, err := u.gen.NewV4()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v3 gen", err)
 	}
 
-	return gouuid.NewV3(v4, xrand.LowerCaseString(systemNameLen)), nil
+	return u.gen.NewV3(v4, xrand.LowerCaseString(u.rnd, systemNameLen)), nil
 }
 
 func (u uuidV3Generator) Close() {}
 
-func NewUUIDV4Generator() model.Generator {
-	return uuidV4Generator{}
+func NewUUIDV4Generator(rnd *rand.Rand) model.Generator {
+	return uuidV4Generator{gen: newGen(rnd)}
 }
 
-type uuidV4Generator struct{}
+type uuidV4Generator struct {
+	gen *gouuid.Gen
+}
 
 func (u uuidV4Generator) Gen(_ context.Context) (any, error) {
-	val, err := gouuid.NewV4()
+	val, err := u.gen.NewV4()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v4 gen", err)
 	}
@@ -63,31 +106,36 @@ func (u uuidV4Generator) Gen(_ context.Context) (any, error) {
 
 func (u uuidV4Generator) Close() {}
 
-func NewUUIDV5Generator() model.Generator {
-	return uuidV5Generator{}
+func NewUUIDV5Generator(rnd *rand.Rand) model.Generator {
+	return uuidV5Generator{rnd: rnd, gen: newGen(rnd)}
 }
 
-type uuidV5Generator struct{}
+type uuidV5Generator struct {
+	rnd *rand.Rand
+	gen *gouuid.Gen
+}
 
 func (u uuidV5Generator) Gen(_ context.Context) (any, error) {
-	v4, err := gouuid.NewV4()
+	v4, err := u.gen.NewV4()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v3 gen", err)
 	}
 
-	return gouuid.NewV5(v4, xrand.LowerCaseString(systemNameLen)), nil
+	return u.gen.NewV5(v4, xrand.LowerCaseString(u.rnd, systemNameLen)), nil
 }
 
 func (u uuidV5Generator) Close() {}
 
-func NewUUIDV6Generator() model.Generator {
-	return uuidV6Generator{}
+func NewUUIDV6Generator(rnd *rand.Rand, now time.Time) model.Generator {
+	return uuidV6Generator{gen: newTimeGen(rnd, now)}
 }
 
-type uuidV6Generator struct{}
+type uuidV6Generator struct {
+	gen *gouuid.Gen
+}
 
 func (u uuidV6Generator) Gen(_ context.Context) (any, error) {
-	val, err := gouuid.NewV6()
+	val, err := u.gen.NewV6()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v6 gen", err)
 	}
@@ -97,14 +145,16 @@ func (u uuidV6Generator) Gen(_ context.Context) (any, error) {
 
 func (u uuidV6Generator) Close() {}
 
-func NewUUIDV7Generator() model.Generator {
-	return uuidV7Generator{}
+func NewUUIDV7Generator(rnd *rand.Rand, now time.Time) model.Generator {
+	return uuidV7Generator{gen: newTimeGen(rnd, now)}
 }
 
-type uuidV7Generator struct{}
+type uuidV7Generator struct {
+	gen *gouuid.Gen
+}
 
 func (u uuidV7Generator) Gen(_ context.Context) (any, error) {
-	val, err := gouuid.NewV7()
+	val, err := u.gen.NewV7()
 	if err != nil {
 		return nil, fmt.Errorf("%w: uuid v7 gen", err)
 	}
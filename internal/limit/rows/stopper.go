@@ -32,6 +32,18 @@ func (s *Stopper) NextTicket(ctx context.Context, batchSize int64) (model.Ticket
 	}, nil
 }
 
+func (s *Stopper) Progress() model.LimitProgress {
+	return model.LimitProgress{
+		Rows:      s.collected,
+		InitSize:  0,
+		ExtraSize: 0,
+	}
+}
+
+func (s *Stopper) Restore(progress model.LimitProgress) {
+	s.collected = progress.Rows
+}
+
 func (s *Stopper) Collect(ctx context.Context, report model.SaveReport) {
 	s.collected += int64(report.RowsSaved)
 	s.errCounter += report.ConstraintViolation
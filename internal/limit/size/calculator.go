@@ -0,0 +1,62 @@
+package size
+
+import "sync"
+
+type calculator struct {
+	mu          sync.Mutex
+	init        uint64
+	staticSize  uint64
+	dynamicSize uint64
+}
+
+// newCalculator starts from the table size, it's the static size until the next fetch.
+func newCalculator(init uint64) *calculator {
+	return &calculator{
+		init:        init,
+		staticSize:  init,
+		dynamicSize: 0,
+	}
+}
+
+func (c *calculator) resetStaticSize(sz uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.staticSize = sz
+}
+
+func (c *calculator) addDynamicSize(sz uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dynamicSize += sz
+}
+
+func (c *calculator) collected() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the table may shrink below its initial size, vacuum truncating it for one
+	generated := c.dynamicSize + c.staticSize
+	if generated <= c.init {
+		return 0
+	}
+
+	return generated - c.init
+}
+
+func (c *calculator) snapshot() (uint64, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.init, c.dynamicSize
+}
+
+// restore keeps the static size, it's the current table size holding rows of the interrupted run.
+func (c *calculator) restore(init, dynamicSize uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.init = init
+	c.dynamicSize = dynamicSize
+}
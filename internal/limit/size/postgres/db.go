@@ -8,19 +8,19 @@ import (
 	"github.com/jmozgit/datagen/internal/pkg/db"
 )
 
-type connector struct {
+type Sizer struct {
 	oid     uint32
 	connect db.Connect
 }
 
-func newConnector(ctx context.Context, connect db.Connect, table model.TableName) (*connector, error) {
+func NewSizer(ctx context.Context, connect db.Connect, table model.TableName) (*Sizer, error) {
 	const query = `
 	SELECT c.oid
 		FROM pg_class c
-	JOIN 
-		information_schema.tables t ON t.table_name = c.relname AND t.table_schema = $1
-	WHERE 
-		t.table_name = $2
+	JOIN
+		pg_namespace n ON n.oid = c.relnamespace
+	WHERE
+		n.nspname = $1 AND c.relname = $2
 	`
 
 	var oid uint32
@@ -28,11 +28,12 @@ func newConnector(ctx context.Context, connect db.Connect, table model.TableName
 		return nil, fmt.Errorf("%w: new table sizer", err)
 	}
 
-	return &connector{connect: connect, oid: oid}, nil
+	return &Sizer{connect: connect, oid: oid}, nil
 }
 
-func (t *connector) TableSize(ctx context.Context) (uint64, error) {
-	const query = `select pg_table_size($1)`
+// TableSize sums the sizes of all partitions of a partitioned table, the tree of a plain table is the table itself.
+func (t *Sizer) TableSize(ctx context.Context) (uint64, error) {
+	const query = `SELECT COALESCE(SUM(pg_table_size(relid)), 0)::int8 FROM pg_partition_tree($1)`
 
 	var size uint64
 	if err := t.connect.QueryRow(ctx, query, t.oid).Scan(&size); err != nil {
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+// Sizer sums pages of the table and its indexes, the dbstat virtual table must be compiled in.
+type Sizer struct {
+	table   model.TableName
+	connect db.Connect
+}
+
+func NewSizer(connect db.Connect, table model.TableName) *Sizer {
+	return &Sizer{connect: connect, table: table}
+}
+
+func (s *Sizer) TableSize(ctx context.Context) (uint64, error) {
+	const query = `
+		SELECT
+			COALESCE(SUM(pgsize), 0)
+		FROM
+			dbstat(?)
+		WHERE
+			name = ? OR name IN (SELECT name FROM pragma_index_list(?, ?))
+	`
+
+	var (
+		schema = s.table.Schema.AsArgument()
+		table  = s.table.Table.AsArgument()
+		size   uint64
+	)
+	if err := s.connect.QueryRow(ctx, query, schema, table, table, schema).Scan(&size); err != nil {
+		return 0, fmt.Errorf("%w: table size", err)
+	}
+
+	return size, nil
+}
@@ -1,4 +1,4 @@
-package postgres
+package size
 
 import (
 	"context"
@@ -10,18 +10,23 @@ import (
 	"github.com/jmozgit/datagen/internal/limit"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/chans"
-	"github.com/jmozgit/datagen/internal/pkg/db"
 )
 
+// TableSizer reports the size a table takes in the database.
+type TableSizer interface {
+	TableSize(ctx context.Context) (uint64, error)
+}
+
 type Stopper struct {
 	limit        uint64
-	connector    *connector
+	sizer        TableSizer
 	calculator   *calculator
 	wait         sync.WaitGroup
 	cancelFn     context.CancelFunc
 	closeReading chan []model.LOGenerated
 	values       <-chan []model.LOGenerated
 	tableName    model.TableName
+	rows         int64
 	errCounter   int
 	collector    limit.Collector
 
@@ -32,19 +37,14 @@ type Stopper struct {
 func NewStopper(
 	ctx context.Context,
 	limit uint64,
-	connect db.Connect,
+	sizer TableSizer,
 	tableName model.TableName,
 	collector limit.Collector,
 	loGenerated ...<-chan []model.LOGenerated,
 ) (*Stopper, error) {
 	const fnName = "new stopper"
 
-	connector, err := newConnector(ctx, connect, tableName)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %s", err, fnName)
-	}
-
-	size, err := connector.TableSize(ctx)
+	size, err := sizer.TableSize(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", err, fnName)
 	}
@@ -53,13 +53,14 @@ func NewStopper(
 
 	return &Stopper{
 		limit:        limit,
-		connector:    connector,
+		sizer:        sizer,
 		wait:         sync.WaitGroup{},
 		values:       chans.FanIn(append(loGenerated, closeReading)...),
 		closeReading: closeReading,
 		collector:    collector,
 		tableName:    tableName,
 		calculator:   newCalculator(size),
+		rows:         0,
 		cancelFn:     nil,
 	}, nil
 }
@@ -83,12 +84,29 @@ func (s *Stopper) NextTicket(_ context.Context, batchRows int64) (model.Ticket,
 	}, nil
 }
 
+func (s *Stopper) Progress() model.LimitProgress {
+	init, dynamicSize := s.calculator.snapshot()
+
+	return model.LimitProgress{
+		Rows:      s.rows,
+		InitSize:  init,
+		ExtraSize: dynamicSize,
+	}
+}
+
+// Restore makes the stopper count the size from the table size seen by the interrupted run.
+func (s *Stopper) Restore(progress model.LimitProgress) {
+	s.rows = progress.Rows
+	s.calculator.restore(progress.InitSize, progress.ExtraSize)
+}
+
 func (s *Stopper) Collect(ctx context.Context, report model.SaveReport) {
+	s.rows += int64(report.RowsSaved)
 	s.errCounter += report.ConstraintViolation
 
 	s.collector.Collect(ctx, model.ProgressState{
 		Table:                s.tableName.String(),
-		RowsCollected:        int64(report.RowsSaved),
+		RowsCollected:        s.rows,
 		SizeCollected:        datasize.ByteSize(0),
 		ViolationConstraints: int64(s.errCounter),
 	})
@@ -123,7 +141,7 @@ func (s *Stopper) updateCalculator(
 		case <-ctx.Done():
 			return
 		case <-timer.C:
-			size, err := s.connector.TableSize(ctx)
+			size, err := s.sizer.TableSize(ctx)
 			if err != nil {
 				s.mu.Lock()
 				s.stickyErr = err
@@ -0,0 +1,75 @@
+package size_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/limit/size"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+type noopCollector struct{}
+
+func (noopCollector) Collect(context.Context, model.ProgressState) {}
+
+type fixedSizer uint64
+
+func (s fixedSizer) TableSize(context.Context) (uint64, error) {
+	return uint64(s), nil
+}
+
+func newStopper(t *testing.T, tableSize uint64) *size.Stopper {
+	t.Helper()
+
+	//nolint:exhaustruct // ok for test
+	stopper, err := size.NewStopper(
+		t.Context(), 500, fixedSizer(tableSize), model.TableName{Table: model.PGIdentifier("t")}, noopCollector{},
+	)
+	require.NoError(t, err)
+
+	return stopper
+}
+
+func Test_StopperCountsRows(t *testing.T) {
+	t.Parallel()
+
+	stopper := newStopper(t, 1000)
+	stopper.Collect(t.Context(), model.SaveReport{RowsSaved: 10, ConstraintViolation: 0})
+	stopper.Collect(t.Context(), model.SaveReport{RowsSaved: 5, ConstraintViolation: 0})
+
+	require.Equal(t, model.LimitProgress{Rows: 15, InitSize: 1000, ExtraSize: 0}, stopper.Progress())
+}
+
+func Test_StopperRestore(t *testing.T) {
+	t.Parallel()
+
+	// the interrupted run started at 800 bytes and left the table at 1000
+	stopper := newStopper(t, 1000)
+	stopper.Restore(model.LimitProgress{Rows: 10, InitSize: 800, ExtraSize: 0})
+
+	ticket, err := stopper.NextTicket(t.Context(), 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), ticket.AllowedRows)
+	require.Equal(t, int64(10), stopper.Progress().Rows)
+
+	// large objects it saved count too
+	stopper = newStopper(t, 1000)
+	stopper.Restore(model.LimitProgress{Rows: 10, InitSize: 800, ExtraSize: 300})
+
+	ticket, err = stopper.NextTicket(t.Context(), 100)
+	require.NoError(t, err)
+	require.Zero(t, ticket.AllowedRows)
+}
+
+func Test_StopperTableBelowInitSize(t *testing.T) {
+	t.Parallel()
+
+	stopper := newStopper(t, 100)
+	stopper.Restore(model.LimitProgress{Rows: 0, InitSize: 800, ExtraSize: 50})
+
+	ticket, err := stopper.NextTicket(t.Context(), 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), ticket.AllowedRows)
+}
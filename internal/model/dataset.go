@@ -2,8 +2,10 @@ package model
 
 import (
 	"context"
+	"encoding"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/jackc/pgx/v5"
@@ -13,6 +15,9 @@ type Driver int
 
 const (
 	DriverPostgresql Driver = iota
+	DriverMySQL
+	DriverSQLite
+	DriverOracle
 )
 
 type Identifier struct {
@@ -24,14 +29,28 @@ func PGIdentifier(val string) Identifier {
 	return Identifier{drivder: DriverPostgresql, value: val}
 }
 
+func MySQLIdentifier(val string) Identifier {
+	return Identifier{drivder: DriverMySQL, value: val}
+}
+
+func SQLiteIdentifier(val string) Identifier {
+	return Identifier{drivder: DriverSQLite, value: val}
+}
+
+func OracleIdentifier(val string) Identifier {
+	return Identifier{drivder: DriverOracle, value: val}
+}
+
 func (i Identifier) AsArgument() string {
 	return i.value
 }
 
 func (i Identifier) Quoted() string {
 	switch i.drivder {
-	case DriverPostgresql:
+	case DriverPostgresql, DriverSQLite, DriverOracle:
 		return pgx.Identifier([]string{i.value}).Sanitize()
+	case DriverMySQL:
+		return "`" + strings.ReplaceAll(i.value, "`", "``") + "`"
 	default:
 		panic("unknown driver")
 	}
@@ -41,6 +60,9 @@ type DatasetSchema struct {
 	TableName         TableName
 	Columns           []TargetType
 	UniqueConstraints [][]Identifier
+	ColumnOrders      []ColumnOrder
+	// UnsupportedChecks are CHECK constraints and partition bounds which generated rows may violate.
+	UnsupportedChecks []string
 }
 
 type Limit struct {
@@ -57,10 +79,53 @@ type Limiter interface {
 	Collect(context.Context, SaveReport)
 }
 
+// LimitProgress is what a limiter has to remember to continue an interrupted run.
+type LimitProgress struct {
+	Rows int64
+	// InitSize is the table size observed before the very first run.
+	InitSize uint64
+	// ExtraSize is the size saved outside the table, e.g. large objects.
+	ExtraSize uint64
+}
+
+type ResumableLimiter interface {
+	Limiter
+	Progress() LimitProgress
+	Restore(LimitProgress)
+}
+
+type RandState interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// GeneratorState is the state of a generator whose values follow from the ones it made before,
+// like a counter. Checkpoints keep it next to random sources.
+type GeneratorState interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+type StatefulGenerator interface {
+	Generator
+	GeneratorState
+}
+
 type Task struct {
 	DatasetSchema DatasetSchema
 	Generators    []Generator
 	Limiter       Limiter
+	// RandStates holds the random source of every column, in column order.
+	RandStates []RandState
+	// GeneratorStates holds states of generators keeping one, in the order they were made.
+	GeneratorStates []GeneratorState
+	// GenOrder lists column indexes in the order values are generated, columns go before derived ones
+	// reading them. Nil means the column order.
+	GenOrder []int
+	// RowColumns are indexes of columns derived generators read from the row, by the names they use.
+	RowColumns map[string]int
+	// Configured marks columns with a generator set by the user, nil if there are none.
+	Configured []bool
 }
 
 func (t *Task) TableName() string {
@@ -13,6 +13,21 @@ const (
 	AcceptanceUserSettings
 )
 
+func (r AcceptanceReason) String() string {
+	switch r {
+	case AcceptanceReasonColumnType:
+		return "column type"
+	case AcceptanceReasonDriverAwareness:
+		return "driver awareness"
+	case AcceptanceReasonReference:
+		return "reference"
+	case AcceptanceUserSettings:
+		return "user settings"
+	default:
+		return "unknown"
+	}
+}
+
 type Generator interface {
 	Gen(ctx context.Context) (any, error)
 	Close()
@@ -27,6 +42,24 @@ type LOGenerated struct {
 	Size uint64
 }
 
+// LargeObject is the content of a large object the saver creates itself,
+// it's generated instead of an oid when rows don't go to the database directly.
+type LargeObject struct {
+	Data []byte
+}
+
+// Record is a value of a composite type, fields go in the attribute order.
+type Record []any
+
+// IsNull and Index let pgx encode the record once the composite type is registered.
+func (r Record) IsNull() bool {
+	return r == nil
+}
+
+func (r Record) Index(i int) any {
+	return r[i]
+}
+
 type ChooseCallback func()
 
 type AcceptanceDecision struct {
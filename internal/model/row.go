@@ -0,0 +1,47 @@
+package model
+
+import "context"
+
+// DerivedGenerator makes values out of other columns of the same row.
+type DerivedGenerator interface {
+	Generator
+	// DependsOn are the columns read from the row, named as the user wrote them.
+	DependsOn() []string
+}
+
+// Row gives generators the values generated so far for the row being made.
+type Row struct {
+	values  []any
+	columns map[string]int
+}
+
+// NewRow makes a row reading columns by the indexes of the task columns.
+func NewRow(columns map[string]int) *Row {
+	return &Row{values: nil, columns: columns}
+}
+
+// Reset points the row to the values of the next row.
+func (r *Row) Reset(values []any) {
+	r.values = values
+}
+
+func (r *Row) Value(column string) (any, bool) {
+	idx, ok := r.columns[column]
+	if !ok || idx >= len(r.values) {
+		return nil, false
+	}
+
+	return r.values[idx], true
+}
+
+type rowKey struct{}
+
+func WithRow(ctx context.Context, row *Row) context.Context {
+	return context.WithValue(ctx, rowKey{}, row)
+}
+
+func RowFromContext(ctx context.Context) (*Row, bool) {
+	row, ok := ctx.Value(rowKey{}).(*Row)
+
+	return row, ok
+}
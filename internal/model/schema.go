@@ -8,6 +8,7 @@ import (
 
 type SchemaProvider interface {
 	TableIdentifier(ctx context.Context, table *config.Table) (TableName, error)
+	SchemaTables(ctx context.Context, schema string) ([]TableName, error)
 	ColumnIdentifier(ctx context.Context, tableName TableName, column string) (Identifier, error)
 	Table(ctx context.Context, table TableName) (DatasetSchema, error)
 }
@@ -0,0 +1,31 @@
+package model
+
+// ColumnStats are the planner statistics of a column, values are in their text form.
+type ColumnStats struct {
+	Column   Identifier
+	NullFrac float64
+	// NDistinct counts distinct values, negative ones are minus their share of rows.
+	NDistinct       float64
+	MostCommonVals  []string
+	MostCommonFreqs []float64
+	// HistogramBounds split values other than the most common ones into buckets of equal population.
+	HistogramBounds []string
+	AvgWidth        int
+	Correlation     float64
+	// MaxLength is the declared length of character columns, zero if there is none.
+	MaxLength  int
+	ForeignKey bool
+}
+
+// ColumnDetails are what the catalog tells about a column beyond its type.
+type ColumnDetails struct {
+	Column Identifier
+	// MaxLength is the declared length of character columns, zero if there is none.
+	MaxLength int
+	// EnumValues are labels of an enum column in their sort order.
+	EnumValues []string
+	// Sequence tells whether the column takes values of a sequence, as serial and identity columns do.
+	Sequence bool
+	// References is the table a foreign key of the column points to, nil if there is none.
+	References *TableName
+}
@@ -24,10 +24,16 @@ type Column struct {
 	Type         string
 	FixedSize    int
 	ElemSizeByte sql.NullInt64
+	// Generated columns are computed by the database, inserts can't set them.
+	Generated bool
+	// HasDefault tells whether the database fills the column when an insert omits it.
+	HasDefault bool
 }
 
 type Table struct {
 	Name          TableName
 	Columns       []Column
 	UniqueIndexes [][]Identifier
+	// Checks holds definitions of CHECK constraints as the database prints them.
+	Checks []string
 }
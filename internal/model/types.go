@@ -12,14 +12,48 @@ const (
 	UUID
 	Reference
 	Array
+	Composite
 )
 
+func (c CommonType) String() string {
+	switch c {
+	case DriverSpecified:
+		return "driver specified"
+	case Integer:
+		return "integer"
+	case Float:
+		return "float"
+	case Text:
+		return "text"
+	case Timestamp:
+		return "timestamp"
+	case Date:
+		return "date"
+	case UUID:
+		return "uuid"
+	case Reference:
+		return "reference"
+	case Array:
+		return "array"
+	case Composite:
+		return "composite"
+	default:
+		return "unknown"
+	}
+}
+
 type ArrayInfo struct {
 	ElemType   CommonType
 	ElemSize   int64
 	SourceType string
 }
 
+// CompositeInfo describes a composite type, its attributes are looked up like columns of a table named after it.
+type CompositeInfo struct {
+	Name   TableName
+	Fields []TargetType
+}
+
 type TargetType struct {
 	SourceName Identifier
 	Type       CommonType
@@ -27,10 +61,43 @@ type TargetType struct {
 	IsNullable bool
 	FixedSize  int
 	ArrayElem  ArrayInfo
+	Composite  CompositeInfo
+	// Generated columns are computed by the database, inserts can't set them.
+	Generated bool
+	// HasDefault tells whether the database fills the column when an insert omits it.
+	HasDefault bool
+	// Check narrows generated values, nil if no CHECK constraint is understood for the column.
+	Check *ColumnCheck
+	// Partitions are what every partition accepts when the column is the partition key, a value has to satisfy
+	// one of them to land in the table. Nil if any value lands somewhere, empty if the table has no partitions.
+	Partitions []ColumnCheck
+}
+
+// ColumnCheck is what simple CHECK constraints tell about a single column, unset parts are nil.
+// Bounds and listed values keep the constraint literals as text, acceptors parse them into the column type.
+type ColumnCheck struct {
+	Min       *CheckBound
+	Max       *CheckBound
+	In        []string
+	MinLength *int
+	MaxLength *int
+}
+
+type CheckBound struct {
+	Value     string
+	Inclusive bool
+}
+
+// ColumnOrder is a CHECK constraint comparing two columns of a row, like CHECK (starts_at < ends_at).
+type ColumnOrder struct {
+	Lesser  Identifier
+	Greater Identifier
+	Strict  bool
 }
 
 type Subscription func(batch SaveBatch)
 
 type ReferenceResolver interface {
 	Register(TableName, TableName, Subscription)
+	Finished(TableName) <-chan struct{}
 }
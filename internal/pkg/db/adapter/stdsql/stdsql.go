@@ -0,0 +1,60 @@
+package stdsql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmozgit/datagen/internal/pkg/db"
+)
+
+type adapterDB struct {
+	db *sql.DB
+}
+
+// NewAdapterDB doesn't own the pool, its owner closes it.
+func NewAdapterDB(db *sql.DB) db.Connect {
+	return adapterDB{db: db}
+}
+
+func (a adapterDB) QueryRow(ctx context.Context, query string, args ...any) db.Row {
+	return a.db.QueryRowContext(ctx, query, args...)
+}
+
+func (a adapterDB) Query(ctx context.Context, query string, args ...any) (db.Rows, error) {
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // adapter
+	}
+
+	return adapterRows{rows: rows}, nil
+}
+
+func (a adapterDB) Execute(ctx context.Context, query string, args ...any) error {
+	_, err := a.db.ExecContext(ctx, query, args...)
+
+	return err //nolint:wrapcheck // adapter
+}
+
+func (a adapterDB) Close(_ context.Context) error {
+	return a.db.Close() //nolint:wrapcheck // adapter
+}
+
+type adapterRows struct {
+	rows *sql.Rows
+}
+
+func (a adapterRows) Close() {
+	_ = a.rows.Close()
+}
+
+func (a adapterRows) Err() error {
+	return a.rows.Err() //nolint:wrapcheck // adapter
+}
+
+func (a adapterRows) Next() bool {
+	return a.rows.Next()
+}
+
+func (a adapterRows) Scan(dest ...any) error {
+	return a.rows.Scan(dest...) //nolint:wrapcheck // adapter
+}
@@ -0,0 +1,240 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/stdsql"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/samber/lo"
+)
+
+type Conn struct {
+	saveSchema bool
+	cfg        *mysql.Config
+	conn       *sql.DB
+}
+
+// New creates a temporary database, dsn is in the go-sql-driver/mysql format.
+func New(t *testing.T, dsn string) (*Conn, error) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse dsn", err)
+	}
+
+	conn, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: mysql new", err)
+	}
+
+	dbname := genDBName()
+	if _, err := conn.ExecContext(ctx, "CREATE DATABASE "+dbname); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("%w: mysql new", err)
+	}
+
+	tempCfg := cfg.Clone()
+	tempCfg.DBName = dbname
+	tempCfg.ParseTime = true
+	tempConn, err := sql.Open("mysql", tempCfg.FormatDSN())
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("%w: connect config", err)
+	}
+
+	c := &Conn{conn: tempConn, cfg: tempCfg, saveSchema: false}
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+		if err = tempConn.Close(); err != nil {
+			t.Errorf("failed to close temp conn: %v", err)
+		}
+
+		if !c.saveSchema {
+			if _, err := conn.ExecContext(ctx, "DROP DATABASE "+dbname); err != nil {
+				t.Errorf("failed to drop database %s: %v", dbname, err)
+			}
+		}
+
+		if err = conn.Close(); err != nil {
+			t.Errorf("failed to close conn: %v", err)
+		}
+	})
+
+	return c, nil
+}
+
+func (c *Conn) Raw() *sql.DB {
+	return c.conn
+}
+
+func (c *Conn) CreateTable(ctx context.Context, table model.Table, opts ...options.CreateTableOption) error {
+	if _, err := c.conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+table.Name.Quoted()); err != nil {
+		return fmt.Errorf("%w: ensure unexistence %s", err, table.Name.Quoted())
+	}
+
+	params := options.CreateTableOptions{
+		PKs: make([]string, 0),
+		PartPolicy: options.PartPolicy{
+			Method: "",
+			Cnt:    0,
+			Field:  "",
+			Bounds: nil,
+		},
+		Preserve: false,
+		FGs:      "",
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if params.Preserve {
+		c.saveSchema = true
+	}
+
+	query := fmt.Sprintf("create table %s (", table.Name.Quoted())
+	query += strings.Join(lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return fmt.Sprintf("%s %s", c.Name.Quoted(), c.Type)
+	}), ",")
+
+	if len(params.PKs) != 0 {
+		query += fmt.Sprintf(",primary key (%s)", strings.Join(params.PKs, ","))
+	}
+
+	if len(params.FGs) != 0 {
+		query += fmt.Sprintf(", %s", params.FGs)
+	}
+
+	query += ")"
+
+	if params.PartPolicy.Method != "" {
+		query += fmt.Sprintf(" partition by hash(%s) partitions %d", params.PartPolicy.Field, params.PartPolicy.Cnt)
+	}
+
+	if _, err := c.conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%w: create table", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) OnEachRow(
+	ctx context.Context,
+	table model.Table,
+	fn func(row []any),
+	opts ...options.OnEachRowOption,
+) error {
+	optsRow := options.OnEachRow{
+		ScanFn: nil,
+	}
+	for _, opt := range opts {
+		opt(&optsRow)
+	}
+
+	columns := lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return c.Name.Quoted()
+	})
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM " + table.Name.Quoted()
+
+	rows, err := c.conn.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+	defer rows.Close()
+
+	buf := make([]any, len(table.Columns))
+	ptrBuf := make([]any, len(buf))
+	for i := range ptrBuf {
+		ptrBuf[i] = &buf[i]
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+
+	for rows.Next() {
+		if optsRow.ScanFn != nil {
+			buf, err := optsRow.ScanFn(rows)
+			if err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+
+			fn(buf)
+		} else {
+			if err := rows.Scan(ptrBuf...); err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+			decodeText(columnTypes, buf)
+
+			fn(buf)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var textTypes = []string{"CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "ENUM", "SET"}
+
+// decodeText turns text columns into strings, the driver returns them as bytes like blobs.
+func decodeText(columnTypes []*sql.ColumnType, row []any) {
+	for i, ct := range columnTypes {
+		if b, ok := row[i].([]byte); ok && slices.Contains(textTypes, ct.DatabaseTypeName()) {
+			row[i] = string(b)
+		}
+	}
+}
+
+func (c *Conn) ExecuteInFunc(ctx context.Context, fn func(ctx context.Context, conn db.Connect) error) error {
+	if err := fn(ctx, stdsql.NewAdapterDB(c.conn)); err != nil {
+		return fmt.Errorf("%w: execute in func", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) SQLConnection() *config.SQLConnection {
+	host, port, err := net.SplitHostPort(c.cfg.Addr)
+	if err != nil {
+		host, port = c.cfg.Addr, "3306"
+	}
+	portNum, _ := strconv.Atoi(port)
+
+	return &config.SQLConnection{
+		Host:     host,
+		Port:     portNum,
+		User:     c.cfg.User,
+		Password: c.cfg.Passwd,
+		DBName:   c.cfg.DBName,
+		Options:  make([]string, 0),
+	}
+}
+
+func genDBName() string {
+	const dbNameLen = 10
+
+	return xrand.LowerCaseString(xrand.Unseeded(), dbNameLen)
+}
@@ -6,6 +6,8 @@ type PartPolicy struct {
 	Method string
 	Cnt    int
 	Field  string
+	// Bounds are [from, to) pairs of range partitions.
+	Bounds [][2]string
 }
 
 type CreateTableOptions struct {
@@ -29,6 +31,18 @@ func WithHashPartitions(parts int, field string) CreateTableOption {
 			Method: "hash",
 			Cnt:    parts,
 			Field:  field,
+			Bounds: nil,
+		}
+	}
+}
+
+func WithRangePartitions(field string, bounds ...[2]string) CreateTableOption {
+	return func(c *CreateTableOptions) {
+		c.PartPolicy = PartPolicy{
+			Method: "range",
+			Cnt:    len(bounds),
+			Field:  field,
+			Bounds: bounds,
 		}
 	}
 }
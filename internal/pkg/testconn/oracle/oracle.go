@@ -0,0 +1,229 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/stdsql"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+
+	"github.com/samber/lo"
+	_ "github.com/sijms/go-ora/v2" // register driver
+)
+
+type Conn struct {
+	saveSchema bool
+	schema     string
+	connCfg    *config.SQLConnection
+	conn       *sql.DB
+}
+
+// New creates a temporary user to own test tables, dsn is a go-ora url
+// of a user allowed to create users and tables of others, like system.
+func New(t *testing.T, dsn string) (*Conn, error) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	connCfg, err := parseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: oracle new", err)
+	}
+
+	conn, err := sql.Open("oracle", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: oracle new", err)
+	}
+
+	schema := genSchemaName()
+	query := fmt.Sprintf(
+		`CREATE USER %s IDENTIFIED BY "%s" DEFAULT TABLESPACE users QUOTA UNLIMITED ON users`,
+		schema, genSchemaName(),
+	)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("%w: oracle new", err)
+	}
+
+	c := &Conn{conn: conn, connCfg: connCfg, schema: schema, saveSchema: false}
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+		if !c.saveSchema {
+			if _, err := conn.ExecContext(ctx, "DROP USER "+schema+" CASCADE"); err != nil {
+				t.Errorf("failed to drop user %s: %v", schema, err)
+			}
+		}
+
+		if err = conn.Close(); err != nil {
+			t.Errorf("failed to close conn: %v", err)
+		}
+	})
+
+	return c, nil
+}
+
+func parseURL(dsn string) (*config.SQLConnection, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse url", err)
+	}
+
+	host, port, err := net.SplitHostPort(parsed.Host)
+	if err != nil {
+		host, port = parsed.Host, "1521"
+	}
+	portNum, _ := strconv.Atoi(port)
+	password, _ := parsed.User.Password()
+
+	return &config.SQLConnection{
+		Host:     host,
+		Port:     portNum,
+		User:     parsed.User.Username(),
+		Password: password,
+		DBName:   strings.TrimPrefix(parsed.Path, "/"),
+		Options:  make([]string, 0),
+	}, nil
+}
+
+func (c *Conn) Raw() *sql.DB {
+	return c.conn
+}
+
+// Schema is the temporary user in upper case, the way oracle stores it.
+func (c *Conn) Schema() string {
+	return c.schema
+}
+
+func (c *Conn) CreateTable(ctx context.Context, table model.Table, opts ...options.CreateTableOption) error {
+	params := options.CreateTableOptions{
+		PKs: make([]string, 0),
+		PartPolicy: options.PartPolicy{
+			Method: "",
+			Cnt:    0,
+			Field:  "",
+			Bounds: nil,
+		},
+		Preserve: false,
+		FGs:      "",
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if params.Preserve {
+		c.saveSchema = true
+	}
+
+	query := fmt.Sprintf("create table %s (", table.Name.Quoted())
+	query += strings.Join(lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return fmt.Sprintf("%s %s", c.Name.Quoted(), c.Type)
+	}), ",")
+
+	// test columns are lower case, they are created quoted
+	if len(params.PKs) != 0 {
+		pks := lo.Map(params.PKs, func(pk string, _ int) string {
+			return model.OracleIdentifier(pk).Quoted()
+		})
+		query += fmt.Sprintf(",primary key (%s)", strings.Join(pks, ","))
+	}
+
+	if len(params.FGs) != 0 {
+		query += fmt.Sprintf(", %s", params.FGs)
+	}
+
+	query += ")"
+
+	if params.PartPolicy.Method != "" {
+		query += fmt.Sprintf(" partition by hash(%s) partitions %d", params.PartPolicy.Field, params.PartPolicy.Cnt)
+	}
+
+	if _, err := c.conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%w: create table", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) OnEachRow(
+	ctx context.Context,
+	table model.Table,
+	fn func(row []any),
+	opts ...options.OnEachRowOption,
+) error {
+	optsRow := options.OnEachRow{
+		ScanFn: nil,
+	}
+	for _, opt := range opts {
+		opt(&optsRow)
+	}
+
+	columns := lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return c.Name.Quoted()
+	})
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM " + table.Name.Quoted()
+
+	rows, err := c.conn.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+	defer rows.Close()
+
+	buf := make([]any, len(table.Columns))
+	ptrBuf := make([]any, len(buf))
+	for i := range ptrBuf {
+		ptrBuf[i] = &buf[i]
+	}
+
+	for rows.Next() {
+		if optsRow.ScanFn != nil {
+			buf, err := optsRow.ScanFn(rows)
+			if err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+
+			fn(buf)
+		} else {
+			if err := rows.Scan(ptrBuf...); err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+
+			fn(buf)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) ExecuteInFunc(ctx context.Context, fn func(ctx context.Context, conn db.Connect) error) error {
+	if err := fn(ctx, stdsql.NewAdapterDB(c.conn)); err != nil {
+		return fmt.Errorf("%w: execute in func", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) SQLConnection() *config.SQLConnection {
+	return c.connCfg
+}
+
+func genSchemaName() string {
+	const schemaNameLen = 10
+
+	return strings.ToUpper(xrand.LowerCaseString(xrand.Unseeded(), schemaNameLen))
+}
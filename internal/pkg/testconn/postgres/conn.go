@@ -100,6 +100,7 @@ func (c *Conn) CreateTable(ctx context.Context, table model.Table, opts ...optio
 			Method: "",
 			Cnt:    0,
 			Field:  "",
+			Bounds: nil,
 		},
 		Preserve: false,
 	}
@@ -127,15 +128,20 @@ func (c *Conn) CreateTable(ctx context.Context, table model.Table, opts ...optio
 	query += ")"
 
 	if params.PartPolicy.Method != "" {
-		query += fmt.Sprintf("partition by hash(%s)", params.PartPolicy.Field)
+		query += fmt.Sprintf("partition by %s(%s)", params.PartPolicy.Method, params.PartPolicy.Field)
 		if _, err := c.conn.Exec(ctx, query); err != nil {
 			return fmt.Errorf("%w: create table", err)
 		}
 
 		for i := range params.PartPolicy.Cnt {
+			bound := fmt.Sprintf("with (modulus %d, remainder %d)", params.PartPolicy.Cnt, i)
+			if params.PartPolicy.Method == "range" {
+				bound = fmt.Sprintf("from ('%s') to ('%s')", params.PartPolicy.Bounds[i][0], params.PartPolicy.Bounds[i][1])
+			}
+
 			part := fmt.Sprintf(
-				"create table %s_part_%d partition of %s for values with (modulus %d, remainder %d)",
-				table.Name.Table.AsArgument(), i, table.Name.Quoted(), params.PartPolicy.Cnt, i,
+				"create table %s_part_%d partition of %s for values %s",
+				table.Name.Table.AsArgument(), i, table.Name.Quoted(), bound,
 			)
 
 			if _, err := c.conn.Exec(ctx, part); err != nil {
@@ -241,5 +247,5 @@ func (c *Conn) ensureUnexistence(ctx context.Context, name model.TableName) erro
 func genDBName() string {
 	const dbNameLen = 10
 
-	return xrand.LowerCaseString(dbNameLen)
+	return xrand.LowerCaseString(xrand.Unseeded(), dbNameLen)
 }
@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/stdsql"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+
+	"github.com/samber/lo"
+	_ "modernc.org/sqlite" // register driver
+)
+
+type Conn struct {
+	database *config.SQLiteDatabase
+	conn     *sql.DB
+}
+
+// New creates a database file in a temporary directory, it's removed with the directory.
+func New(t *testing.T) (*Conn, error) {
+	t.Helper()
+
+	database := &config.SQLiteDatabase{
+		Path:    filepath.Join(t.TempDir(), "datagen.db"),
+		Options: make([]string, 0),
+	}
+
+	conn, err := sql.Open("sqlite", database.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: sqlite new", err)
+	}
+
+	t.Cleanup(func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("failed to close conn: %v", err)
+		}
+	})
+
+	return &Conn{database: database, conn: conn}, nil
+}
+
+func (c *Conn) Raw() *sql.DB {
+	return c.conn
+}
+
+func (c *Conn) CreateTable(ctx context.Context, table model.Table, opts ...options.CreateTableOption) error {
+	if _, err := c.conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+table.Name.Quoted()); err != nil {
+		return fmt.Errorf("%w: ensure unexistence %s", err, table.Name.Quoted())
+	}
+
+	params := options.CreateTableOptions{
+		PKs: make([]string, 0),
+		PartPolicy: options.PartPolicy{
+			Method: "",
+			Cnt:    0,
+			Field:  "",
+			Bounds: nil,
+		},
+		Preserve: false,
+		FGs:      "",
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	query := fmt.Sprintf("create table %s (", table.Name.Quoted())
+	query += strings.Join(lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return fmt.Sprintf("%s %s", c.Name.Quoted(), c.Type)
+	}), ",")
+
+	if len(params.PKs) != 0 {
+		query += fmt.Sprintf(",primary key (%s)", strings.Join(params.PKs, ","))
+	}
+
+	if len(params.FGs) != 0 {
+		query += fmt.Sprintf(", %s", params.FGs)
+	}
+
+	query += ")"
+
+	if _, err := c.conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%w: create table", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) OnEachRow(
+	ctx context.Context,
+	table model.Table,
+	fn func(row []any),
+	opts ...options.OnEachRowOption,
+) error {
+	optsRow := options.OnEachRow{
+		ScanFn: nil,
+	}
+	for _, opt := range opts {
+		opt(&optsRow)
+	}
+
+	columns := lo.Map(table.Columns, func(c model.Column, _ int) string {
+		return c.Name.Quoted()
+	})
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM " + table.Name.Quoted()
+
+	rows, err := c.conn.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+	defer rows.Close()
+
+	buf := make([]any, len(table.Columns))
+	ptrBuf := make([]any, len(buf))
+	for i := range ptrBuf {
+		ptrBuf[i] = &buf[i]
+	}
+
+	for rows.Next() {
+		if optsRow.ScanFn != nil {
+			buf, err := optsRow.ScanFn(rows)
+			if err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+
+			fn(buf)
+		} else {
+			if err := rows.Scan(ptrBuf...); err != nil {
+				return fmt.Errorf("%w: on each row", err)
+			}
+
+			fn(buf)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%w: on each row", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) ExecuteInFunc(ctx context.Context, fn func(ctx context.Context, conn db.Connect) error) error {
+	if err := fn(ctx, stdsql.NewAdapterDB(c.conn)); err != nil {
+		return fmt.Errorf("%w: execute in func", err)
+	}
+
+	return nil
+}
+
+func (c *Conn) SQLiteDatabase() *config.SQLiteDatabase {
+	return c.database
+}
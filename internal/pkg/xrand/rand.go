@@ -1,14 +1,85 @@
 package xrand
 
-import "math/rand/v2"
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"sync"
+)
 
 //nolint:gochecknoglobals // more convenient that constants here
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyz")
 
-func LowerCaseString(n int) string {
+// Source is a PCG source derived from a seed and a set of keys.
+// Its state can be saved and restored, which is what checkpoints rely on.
+type Source struct {
+	mu  sync.Mutex
+	pcg *rand.PCG
+}
+
+func NewSource(seed uint64, keys ...string) *Source {
+	h := fnv.New64a()
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return &Source{pcg: rand.NewPCG(seed, h.Sum64())}
+}
+
+func (s *Source) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pcg.Uint64()
+}
+
+func (s *Source) MarshalBinary() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.pcg.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("%w: source marshal", err)
+	}
+
+	return data, nil
+}
+
+func (s *Source) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.pcg.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("%w: source unmarshal", err)
+	}
+
+	return nil
+}
+
+// New returns a random generator derived from seed and keys.
+// The same seed and keys always produce the same sequence of values.
+func New(seed uint64, keys ...string) *rand.Rand {
+	return rand.New(NewSource(seed, keys...)) //nolint:gosec // reproducibility is the point
+}
+
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64() //nolint:gosec // no problem here
+}
+
+// Unseeded returns a random generator backed by the process-wide source.
+// It is safe for concurrent use.
+func Unseeded() *rand.Rand {
+	return rand.New(globalSource{}) //nolint:gosec // no problem here
+}
+
+func LowerCaseString(rnd *rand.Rand, n int) string {
 	b := make([]rune, n)
 	for i := range b {
-		b[i] = letterRunes[rand.IntN(len(letterRunes))] //nolint:gosec // no problem here
+		b[i] = letterRunes[rnd.IntN(len(letterRunes))]
 	}
 
 	return string(b)
@@ -16,11 +87,37 @@ func LowerCaseString(n int) string {
 
 var letters = append(letterRunes, []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890_!@#$%^&*()><:")...)
 
-func String(n int) string {
+func String(rnd *rand.Rand, n int) string {
 	b := make([]rune, n)
 	for i := range b {
-		b[i] = letters[rand.IntN(len(letters))] //nolint:gosec // no problem here
+		b[i] = letters[rnd.IntN(len(letters))]
 	}
 
 	return string(b)
 }
+
+// Read fills p with random bytes taken from rnd.
+func Read(rnd *rand.Rand, p []byte) {
+	for i := 0; i < len(p); i += 8 {
+		v := rnd.Uint64()
+		for j := i; j < min(i+8, len(p)); j++ {
+			p[j] = byte(v)
+			v >>= 8
+		}
+	}
+}
+
+type reader struct {
+	rnd *rand.Rand
+}
+
+func (r reader) Read(p []byte) (int, error) {
+	Read(r.rnd, p)
+
+	return len(p), nil
+}
+
+// Reader returns an endless stream of random bytes taken from rnd.
+func Reader(rnd *rand.Rand) io.Reader {
+	return reader{rnd: rnd}
+}
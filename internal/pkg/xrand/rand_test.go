@@ -0,0 +1,56 @@
+package xrand_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	first := xrand.New(42, "public.users", "email")
+	second := xrand.New(42, "public.users", "email")
+	otherColumn := xrand.New(42, "public.users", "name")
+
+	require.Equal(t, xrand.String(first, 64), xrand.String(second, 64))
+	require.NotEqual(t, xrand.String(first, 64), xrand.String(otherColumn, 64))
+}
+
+func Test_KeysAreNotConcatenated(t *testing.T) {
+	t.Parallel()
+
+	require.NotEqual(t,
+		xrand.New(1, "ab", "c").Uint64(),
+		xrand.New(1, "a", "bc").Uint64(),
+	)
+}
+
+func Test_Read(t *testing.T) {
+	t.Parallel()
+
+	first, second := make([]byte, 21), make([]byte, 21)
+	xrand.Read(xrand.New(7), first)
+	xrand.Read(xrand.New(7), second)
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, make([]byte, 21), first)
+}
+
+func Test_SourceRestore(t *testing.T) {
+	t.Parallel()
+
+	src := xrand.NewSource(3, "public.orders", "id")
+	for range 10 {
+		src.Uint64()
+	}
+
+	state, err := src.MarshalBinary()
+	require.NoError(t, err)
+	expected := src.Uint64()
+
+	restored := xrand.NewSource(3, "public.orders", "id")
+	require.NoError(t, restored.UnmarshalBinary(state))
+	require.Equal(t, expected, restored.Uint64())
+}
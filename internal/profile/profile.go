@@ -0,0 +1,426 @@
+package profile
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/distribution"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// mcvCoverage is the share of non null values the most common ones must cover to replace the column.
+	mcvCoverage = 0.95
+	// serialCorrelation is how closely unique integers must follow the physical order to become a sequence.
+	serialCorrelation = 0.9
+	// freqScale turns frequencies into integer weights.
+	freqScale  = 10000
+	percent    = 100
+	hoursInDay = 24
+)
+
+//nolint:gochecknoglobals // more convenient that constants here
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// Generators turns statistics of a table into settings reproducing the shape of its columns: the null
+// fraction, lists of the most common values, histograms of numbers and timestamps along with their most
+// common values, lengths of texts and how often values repeat.
+// Columns without statistics, referencing other tables or generated by the database keep the defaults,
+// unique ones are only made sequences of integers.
+func Generators(dataset model.DatasetSchema, stats []model.ColumnStats) []config.Generator {
+	byColumn := lo.KeyBy(stats, func(s model.ColumnStats) model.Identifier { return s.Column })
+	unique := lo.FlatMap(dataset.UniqueConstraints, func(cols []model.Identifier, _ int) []model.Identifier {
+		return cols
+	})
+
+	out := make([]config.Generator, 0, len(dataset.Columns))
+	for _, col := range dataset.Columns {
+		s, ok := byColumn[col.SourceName]
+		if !ok || s.ForeignKey || col.Generated {
+			continue
+		}
+
+		var gen config.Generator
+		if slices.Contains(unique, col.SourceName) {
+			gen, ok = serial(col, s)
+		} else {
+			gen, ok = generator(col, s)
+		}
+
+		if ok {
+			out = append(out, gen)
+		}
+	}
+
+	return out
+}
+
+func generator(col model.TargetType, s model.ColumnStats) (config.Generator, bool) {
+	//nolint:exhaustruct // settings are set below
+	gen := config.Generator{Column: col.SourceName.AsArgument()}
+	if col.IsNullable {
+		gen.NullFraction = int(math.Round(s.NullFrac * percent))
+	}
+
+	// values satisfy checks of the column only if they are taken from it as they are
+	if values, ok := mostCommon(col, s); ok {
+		gen.Type = config.GeneratorTypeProbabilityList
+		gen.ListProbability = values
+
+		return gen, true
+	}
+
+	if col.Check != nil {
+		return gen, false
+	}
+
+	var ok bool
+	switch col.Type { //nolint:exhaustive // the rest keeps the defaults
+	case model.Integer:
+		gen.Type, gen.Integer = config.GeneratorTypeInteger, integerHistogram(s)
+		ok = gen.Integer != nil
+	case model.Float:
+		gen.Type, gen.Float = config.GeneratorTypeFloat, floatHistogram(s)
+		ok = gen.Float != nil
+	case model.Timestamp, model.Date:
+		gen.Type, gen.Timestamp = config.GeneratorTypeTimestamp, timeHistogram(s)
+		ok = gen.Timestamp != nil
+	case model.Text:
+		// texts are random strings of a length, the most common values aren't among them
+		gen.Type, gen.Text = config.GeneratorTypeText, textLength(s)
+		gen.ReuseFraction = reuseFraction(s, 1-s.NullFrac)
+
+		return gen, true
+	default:
+		return gen, false
+	}
+
+	gen.ReuseFraction = reuseFraction(s, rest(s))
+
+	return gen, ok
+}
+
+// serial keeps unique integers unique.
+func serial(col model.TargetType, s model.ColumnStats) (config.Generator, bool) {
+	if col.Type != model.Integer || col.Check != nil {
+		return config.Generator{}, false //nolint:exhaustruct // declined
+	}
+
+	settings := sequence(s)
+
+	//nolint:exhaustruct // only integer settings
+	return config.Generator{
+		Column:  col.SourceName.AsArgument(),
+		Type:    config.GeneratorTypeInteger,
+		Integer: settings,
+	}, settings != nil
+}
+
+// mostCommon replaces the column by its most common values if there are hardly any others.
+func mostCommon(col model.TargetType, s model.ColumnStats) (*config.ListProbability, bool) {
+	if len(s.MostCommonVals) == 0 || len(s.MostCommonVals) != len(s.MostCommonFreqs) {
+		return nil, false
+	}
+
+	nonNull := 1 - s.NullFrac
+	if nonNull <= 0 || lo.Sum(s.MostCommonFreqs) < mcvCoverage*nonNull {
+		return nil, false
+	}
+
+	var parse func(string) (any, bool)
+	switch {
+	case col.Type == model.Integer:
+		parse = func(v string) (any, bool) { return parseInt(v) }
+	case col.Type == model.Float:
+		parse = func(v string) (any, bool) { return parseFloat(v) }
+	case col.SourceType == "bool":
+		parse = func(v string) (any, bool) { return v == "t", v == "t" || v == "f" }
+	case col.Type == model.Text, col.Type == model.UUID, col.Type == model.DriverSpecified:
+		parse = func(v string) (any, bool) { return v, true }
+	default:
+		return nil, false
+	}
+
+	values, ok := parseAll(s.MostCommonVals, parse)
+	if !ok {
+		return nil, false
+	}
+
+	return &config.ListProbability{
+		Values: values,
+		Distribution: lo.Map(s.MostCommonFreqs, func(f float64, _ int) int {
+			return max(1, int(math.Round(f*freqScale)))
+		}),
+	}, true
+}
+
+func integerHistogram(s model.ColumnStats) *config.Integer {
+	if s.NDistinct == -1 && math.Abs(s.Correlation) >= serialCorrelation {
+		return sequence(s)
+	}
+
+	dist, ok := numberDistribution(s)
+	if !ok {
+		return nil
+	}
+
+	// the range is parsed apart from the distribution, floats lose precision of big integers
+	values, _ := mostCommonVals(s)
+	ints, ok := parseAll(slices.Concat(s.HistogramBounds, values), parseInt)
+	if !ok {
+		return nil
+	}
+
+	//nolint:exhaustruct // the size comes from the column
+	return &config.Integer{
+		MinValue:     lo.ToPtr(slices.Min(ints)),
+		MaxValue:     lo.ToPtr(slices.Max(ints)),
+		Distribution: dist,
+	}
+}
+
+// sequence starts at the smallest value seen.
+func sequence(s model.ColumnStats) *config.Integer {
+	if len(s.HistogramBounds) == 0 {
+		return nil
+	}
+
+	start, ok := parseInt(s.HistogramBounds[0])
+	if !ok {
+		return nil
+	}
+
+	//nolint:exhaustruct // serial has no range
+	return &config.Integer{Format: lo.ToPtr("serial"), MinValue: &start}
+}
+
+func floatHistogram(s model.ColumnStats) *config.Float {
+	dist, ok := numberDistribution(s)
+	if !ok {
+		return nil
+	}
+
+	//nolint:exhaustruct // the size comes from the column
+	return &config.Float{
+		MinValue:     lo.ToPtr(dist.Bounds[0]),
+		MaxValue:     lo.ToPtr(dist.Bounds[len(dist.Bounds)-1]),
+		Distribution: dist,
+	}
+}
+
+func numberDistribution(s model.ColumnStats) (*config.Distribution, bool) {
+	bounds, _ := histogram(s.HistogramBounds, parseFloat)
+
+	values, freqs := mostCommonVals(s)
+	points, ok := parseAll(values, parseFloat)
+	if !ok && len(values) > 0 {
+		return nil, false
+	}
+
+	return distributionOf(bounds, points, freqs, rest(s))
+}
+
+func timeHistogram(s model.ColumnStats) *config.Timestamp {
+	bounds, _ := histogram(s.HistogramBounds, parseTime)
+
+	values, freqs := mostCommonVals(s)
+	points, ok := parseAll(values, parseTime)
+	if !ok && len(values) > 0 {
+		return nil
+	}
+
+	all := slices.Concat(bounds, points)
+	if len(all) == 0 {
+		return nil
+	}
+
+	from := slices.MinFunc(all, time.Time.Compare)
+	days := func(t time.Time, _ int) float64 { return t.Sub(from).Hours() / hoursInDay }
+
+	dist, ok := distributionOf(lo.Map(bounds, days), lo.Map(points, days), freqs, rest(s))
+	if !ok {
+		return nil
+	}
+
+	return &config.Timestamp{
+		OnlyNow:      false,
+		From:         lo.ToPtr(from),
+		To:           lo.ToPtr(slices.MaxFunc(all, time.Time.Compare)),
+		Distribution: dist,
+	}
+}
+
+// textLength targets the average length, avg_width counts the header of short texts too.
+func textLength(s model.ColumnStats) *config.Text {
+	chars := max(s.AvgWidth-1, 1)
+	from, to := max(chars/2, 1), max(chars+chars/2, 1)
+	if s.MaxLength > 0 {
+		to = min(to, s.MaxLength)
+		from = min(from, to)
+	}
+
+	return &config.Text{CharLenFrom: from, CharLenTo: to}
+}
+
+// histogramDistribution gives buckets equal weights, pg_stats bounds split values in groups of the same size.
+func histogramDistribution(bounds []float64) *config.Distribution {
+	weights := make([]int, len(bounds)-1)
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	//nolint:exhaustruct // only histogram parameters
+	return &config.Distribution{
+		Kind:    distribution.KindHistogram,
+		Bounds:  bounds,
+		Weights: weights,
+	}
+}
+
+// distributionOf mixes the histogram with the most common values, pg_stats leaves them out of the histogram.
+// Every most common value is a bucket as wide as a point weighted by its frequency, buckets of the histogram
+// share what the most common values and nulls leave.
+func distributionOf(bounds, points, freqs []float64, rest float64) (*config.Distribution, bool) {
+	if len(points) == 0 {
+		if len(bounds) == 0 {
+			return nil, false
+		}
+
+		return histogramDistribution(bounds), true
+	}
+
+	var bucket float64
+	if len(bounds) > 1 {
+		bucket = rest / float64(len(bounds)-1)
+	}
+
+	pointFreqs := make(map[float64]float64, len(points))
+	for i, p := range points {
+		pointFreqs[p] += freqs[i]
+	}
+	// a bucket without width holds a single value
+	for i := 0; i+1 < len(bounds); i++ {
+		if bounds[i] == bounds[i+1] {
+			pointFreqs[bounds[i]] += bucket
+		}
+	}
+
+	breaks := slices.Compact(slices.Sorted(slices.Values(slices.Concat(bounds, points))))
+
+	outBounds := []float64{breaks[0]}
+	var weights []int
+	for i, b := range breaks {
+		if freq, ok := pointFreqs[b]; ok {
+			outBounds = append(outBounds, b)
+			weights = append(weights, max(1, int(math.Round(freq*freqScale))))
+		}
+
+		if i+1 < len(breaks) {
+			outBounds = append(outBounds, breaks[i+1])
+			weights = append(weights, int(math.Round(spread(bounds, bucket, b, breaks[i+1])*freqScale)))
+		}
+	}
+
+	//nolint:exhaustruct // only histogram parameters
+	return &config.Distribution{
+		Kind:    distribution.KindHistogram,
+		Bounds:  outBounds,
+		Weights: weights,
+	}, true
+}
+
+// spread is the part of the bucket weight falling in [from, to], which lies within a single bucket or outside.
+func spread(bounds []float64, bucket, from, to float64) float64 {
+	for i := 0; i+1 < len(bounds); i++ {
+		if bounds[i] <= from && to <= bounds[i+1] && bounds[i] < bounds[i+1] {
+			return bucket * (to - from) / (bounds[i+1] - bounds[i])
+		}
+	}
+
+	return 0
+}
+
+// mostCommonVals are empty if pg_stats has none or they don't match their frequencies.
+func mostCommonVals(s model.ColumnStats) ([]string, []float64) {
+	if len(s.MostCommonVals) != len(s.MostCommonFreqs) {
+		return nil, nil
+	}
+
+	return s.MostCommonVals, s.MostCommonFreqs
+}
+
+// rest is the share of rows holding values other than nulls and the most common ones.
+func rest(s model.ColumnStats) float64 {
+	_, freqs := mostCommonVals(s)
+
+	return max(0, 1-s.NullFrac-lo.Sum(freqs))
+}
+
+// reuseFraction repeats saved values as often as n_distinct tells, fresh is the share of rows getting values
+// which hardly ever repeat by themselves. A negative n_distinct is minus the share of distinct values among
+// rows. A positive one is a count which doesn't scale with the number of rows to generate, they aren't known
+// here, so it's left out.
+func reuseFraction(s model.ColumnStats, fresh float64) int {
+	if s.NDistinct >= 0 || fresh <= 0 {
+		return 0
+	}
+
+	return max(0, int(math.Round((1+s.NDistinct/fresh)*percent)))
+}
+
+func histogram[T any](raw []string, parse func(string) (T, bool)) ([]T, bool) {
+	const minBounds = 2
+	if len(raw) < minBounds {
+		return nil, false
+	}
+
+	return parseAll(raw, parse)
+}
+
+func parseAll[T any](raw []string, parse func(string) (T, bool)) ([]T, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	out := make([]T, len(raw))
+	for i, v := range raw {
+		var ok bool
+		if out[i], ok = parse(v); !ok {
+			return nil, false
+		}
+	}
+
+	return out, true
+}
+
+func parseInt(v string) (int64, bool) {
+	n, err := strconv.ParseInt(v, 10, 64)
+
+	return n, err == nil
+}
+
+func parseFloat(v string) (float64, bool) {
+	f, err := strconv.ParseFloat(v, 64)
+
+	return f, err == nil && !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+func parseTime(v string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
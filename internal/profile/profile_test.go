@@ -0,0 +1,168 @@
+package profile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/profile"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func column(name string, tp model.CommonType, sourceType string, nullable bool) model.TargetType {
+	//nolint:exhaustruct // ok for test
+	return model.TargetType{
+		SourceName: model.PGIdentifier(name),
+		Type:       tp,
+		SourceType: sourceType,
+		IsNullable: nullable,
+	}
+}
+
+//nolint:funlen // a table of cases
+func Test_Generators(t *testing.T) {
+	//nolint:exhaustruct // ok for test
+	dataset := model.DatasetSchema{
+		Columns: []model.TargetType{
+			column("id", model.Integer, "int8", false),
+			column("status", model.Text, "text", true),
+			column("active", model.DriverSpecified, "bool", false),
+			column("amount", model.Integer, "int4", false),
+			column("created_at", model.Timestamp, "timestamp", false),
+			column("comment", model.Text, "varchar", true),
+			column("customer_id", model.Integer, "int4", false),
+			column("email", model.Text, "text", false),
+			column("no_stats", model.Text, "text", false),
+		},
+		UniqueConstraints: [][]model.Identifier{
+			{model.PGIdentifier("id")},
+			{model.PGIdentifier("email")},
+		},
+	}
+
+	//nolint:exhaustruct // ok for test
+	stats := []model.ColumnStats{
+		{
+			Column: model.PGIdentifier("id"), NDistinct: -1, Correlation: 1,
+			HistogramBounds: []string{"100", "5000", "10000"},
+		},
+		{
+			Column: model.PGIdentifier("status"), NullFrac: 0.1, NDistinct: 2,
+			MostCommonVals: []string{"paid", "new"}, MostCommonFreqs: []float64{0.6, 0.3},
+		},
+		{
+			Column: model.PGIdentifier("active"), NDistinct: 2,
+			MostCommonVals: []string{"t", "f"}, MostCommonFreqs: []float64{0.75, 0.25},
+		},
+		{
+			Column: model.PGIdentifier("amount"), NDistinct: 300,
+			MostCommonVals: []string{"5"}, MostCommonFreqs: []float64{0.2},
+			HistogramBounds: []string{"1", "10", "1000"},
+		},
+		{
+			Column: model.PGIdentifier("created_at"), NDistinct: -0.9,
+			HistogramBounds: []string{"2024-01-01 00:00:00", "2024-01-03 12:00:00", "2024-01-11 00:00:00"},
+		},
+		{Column: model.PGIdentifier("comment"), NullFrac: 0.5, AvgWidth: 41, MaxLength: 50},
+		{Column: model.PGIdentifier("customer_id"), ForeignKey: true, HistogramBounds: []string{"1", "2"}},
+		{Column: model.PGIdentifier("email"), NDistinct: -1, AvgWidth: 20},
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	//nolint:exhaustruct // ok for test
+	expected := []config.Generator{
+		{
+			Column:  "id",
+			Type:    config.GeneratorTypeInteger,
+			Integer: &config.Integer{Format: lo.ToPtr("serial"), MinValue: lo.ToPtr(int64(100))},
+		},
+		{
+			Column:          "status",
+			Type:            config.GeneratorTypeProbabilityList,
+			ListProbability: &config.ListProbability{Values: []any{"paid", "new"}, Distribution: []int{6000, 3000}},
+			NullFraction:    10,
+		},
+		{
+			Column:          "active",
+			Type:            config.GeneratorTypeProbabilityList,
+			ListProbability: &config.ListProbability{Values: []any{true, false}, Distribution: []int{7500, 2500}},
+		},
+		{
+			Column: "amount",
+			Type:   config.GeneratorTypeInteger,
+			Integer: &config.Integer{
+				MinValue: lo.ToPtr(int64(1)),
+				MaxValue: lo.ToPtr(int64(1000)),
+				Distribution: &config.Distribution{
+					// the most common value is a point bucket, the histogram shares the rest
+					Kind: "histogram", Bounds: []float64{1, 5, 5, 10, 1000}, Weights: []int{1778, 2000, 2222, 4000},
+				},
+			},
+		},
+		{
+			Column: "created_at",
+			Type:   config.GeneratorTypeTimestamp,
+			Timestamp: &config.Timestamp{
+				From: lo.ToPtr(from),
+				To:   lo.ToPtr(from.AddDate(0, 0, 10)),
+				Distribution: &config.Distribution{
+					Kind: "histogram", Bounds: []float64{0, 2.5, 10}, Weights: []int{1, 1},
+				},
+			},
+			// a tenth of values repeat
+			ReuseFraction: 10,
+		},
+		{
+			Column:       "comment",
+			Type:         config.GeneratorTypeText,
+			Text:         &config.Text{CharLenFrom: 20, CharLenTo: 50},
+			NullFraction: 50,
+		},
+	}
+
+	require.Equal(t, expected, profile.Generators(dataset, stats))
+}
+
+func Test_ChecksKeepOnlyMostCommon(t *testing.T) {
+	col := column("qty", model.Integer, "int4", false)
+	col.Check = &model.ColumnCheck{Min: &model.CheckBound{Value: "0", Inclusive: false}} //nolint:exhaustruct // ok
+
+	//nolint:exhaustruct // ok for test
+	dataset := model.DatasetSchema{Columns: []model.TargetType{col}}
+
+	//nolint:exhaustruct // ok for test
+	stats := []model.ColumnStats{{Column: col.SourceName, HistogramBounds: []string{"1", "10"}}}
+	require.Empty(t, profile.Generators(dataset, stats))
+
+	stats[0].MostCommonVals, stats[0].MostCommonFreqs = []string{"1", "2"}, []float64{0.5, 0.5}
+	gens := profile.Generators(dataset, stats)
+	require.Len(t, gens, 1)
+	require.Equal(t, []any{int64(1), int64(2)}, gens[0].ListProbability.Values)
+}
+
+func Test_MostCommonOutsideHistogram(t *testing.T) {
+	col := column("price", model.Float, "float8", true)
+
+	//nolint:exhaustruct // ok for test
+	dataset := model.DatasetSchema{Columns: []model.TargetType{col}}
+
+	//nolint:exhaustruct // ok for test
+	stats := []model.ColumnStats{{
+		Column: col.SourceName, NullFrac: 0.2, NDistinct: 300,
+		MostCommonVals: []string{"0"}, MostCommonFreqs: []float64{0.4},
+		HistogramBounds: []string{"1", "2", "3"},
+	}}
+
+	gens := profile.Generators(dataset, stats)
+	require.Len(t, gens, 1)
+	require.Equal(t, lo.ToPtr(0.0), gens[0].Float.MinValue)
+	require.Equal(t, lo.ToPtr(3.0), gens[0].Float.MaxValue)
+	// nothing is between the most common value and the histogram
+	require.Equal(t, []float64{0, 0, 1, 2, 3}, gens[0].Float.Distribution.Bounds)
+	require.Equal(t, []int{4000, 0, 2000, 2000}, gens[0].Float.Distribution.Weights)
+	require.Zero(t, gens[0].ReuseFraction)
+}
@@ -5,14 +5,16 @@ import (
 )
 
 type Service struct {
-	deps map[model.TableName][]model.TableName
-	subs map[model.TableName][]model.Subscription
+	deps     map[model.TableName][]model.TableName
+	subs     map[model.TableName][]model.Subscription
+	finished map[model.TableName]chan struct{}
 }
 
 func NewService() *Service {
 	return &Service{
-		deps: make(map[model.TableName][]model.TableName),
-		subs: make(map[model.TableName][]model.Subscription),
+		deps:     make(map[model.TableName][]model.TableName),
+		subs:     make(map[model.TableName][]model.Subscription),
+		finished: make(map[model.TableName]chan struct{}),
 	}
 }
 
@@ -32,3 +34,29 @@ func (s *Service) OnProcessed(batch model.SaveBatch) {
 		subFn(batch)
 	}
 }
+
+// Finished is closed once the table gets no more rows, see OnFinished.
+func (s *Service) Finished(table model.TableName) <-chan struct{} {
+	return s.finishedCh(table)
+}
+
+func (s *Service) finishedCh(table model.TableName) chan struct{} {
+	ch, ok := s.finished[table]
+	if !ok {
+		ch = make(chan struct{})
+		s.finished[table] = ch
+	}
+
+	return ch
+}
+
+// OnFinished tells references to the table that nothing but the processed batches is coming.
+// Gen doesn't call it, its tasks are generated in parallel and references read saved rows back.
+func (s *Service) OnFinished(table model.TableName) {
+	ch := s.finishedCh(table)
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
@@ -0,0 +1,297 @@
+package encode
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const timeLayout = "2006-01-02 15:04:05.999999Z07:00"
+
+// Text renders a non nil generated value the way PostgreSQL prints it in text format,
+// so the output can be loaded back with COPY.
+func Text(v any) (string, error) {
+	const fnName = "encode: text"
+
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case model.Record:
+		return recordLiteral(val)
+	case pgtype.RangeValuer:
+		return rangeLiteral(val)
+	case pgtype.MultirangeGetter:
+		return multirangeLiteral(val)
+	case []byte:
+		return `\x` + hex.EncodeToString(val), nil
+	case model.LargeObject:
+		// files can't refer to large objects, they carry the content like bytea
+		return Text(val.Data)
+	case bool:
+		return strconv.FormatBool(val), nil
+	case float32:
+		return formatFloat(float64(val), 32), nil
+	case float64:
+		return formatFloat(val, 64), nil
+	case time.Time:
+		return val.Format(timeLayout), nil
+	case driver.Valuer:
+		raw, err := val.Value()
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, fnName)
+		}
+		if raw == nil {
+			return "", nil
+		}
+
+		return Text(raw)
+	case fmt.Stringer:
+		return val.String(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // the rest is printed as is
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Slice, reflect.Array:
+		return arrayLiteral(rv)
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+}
+
+func arrayLiteral(rv reflect.Value) (string, error) {
+	const fnName = "array literal"
+
+	var builder strings.Builder
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	builder.WriteByte('{')
+	for i := range rv.Len() {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+
+		elem := rv.Index(i).Interface()
+		if elem == nil {
+			builder.WriteString("NULL")
+
+			continue
+		}
+
+		text, err := Text(elem)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if isList(elem) {
+			builder.WriteString(text)
+
+			continue
+		}
+
+		builder.WriteByte('"')
+		builder.WriteString(escaper.Replace(text))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte('}')
+
+	return builder.String(), nil
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var recordEscaper = strings.NewReplacer(`"`, `""`, `\`, `\\`)
+
+// recordLiteral renders a composite value, fields with separators or spaces are quoted and nulls are left empty.
+func recordLiteral(r model.Record) (string, error) {
+	const fnName = "record literal"
+
+	var builder strings.Builder
+
+	builder.WriteByte('(')
+	for i, field := range r {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+
+		if field == nil {
+			continue
+		}
+
+		text, err := Text(field)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if text != "" && !strings.ContainsAny(text, `"\(), `+"\t\n\r\v\f") {
+			builder.WriteString(text)
+
+			continue
+		}
+
+		builder.WriteByte('"')
+		builder.WriteString(recordEscaper.Replace(text))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte(')')
+
+	return builder.String(), nil
+}
+
+// rangeLiteral renders a range like ["1","5"), bounds are always quoted and unbounded sides are left empty.
+func rangeLiteral(r pgtype.RangeValuer) (string, error) {
+	const fnName = "range literal"
+
+	lowerType, upperType := r.BoundTypes()
+	if lowerType == pgtype.Empty {
+		return "empty", nil
+	}
+
+	lower, upper := r.Bounds()
+
+	var builder strings.Builder
+
+	builder.WriteByte(rangeBracket(lowerType, '[', '('))
+	for i, bound := range []any{lower, upper} {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+
+		if (i == 0 && lowerType == pgtype.Unbounded) || (i > 0 && upperType == pgtype.Unbounded) {
+			continue
+		}
+
+		// pgtype ranges hand out pointers to their bounds
+		if rv := reflect.ValueOf(bound); rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				continue
+			}
+			bound = rv.Elem().Interface()
+		}
+		if bound == nil {
+			continue
+		}
+
+		text, err := Text(bound)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		builder.WriteByte('"')
+		builder.WriteString(recordEscaper.Replace(text))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte(rangeBracket(upperType, ']', ')'))
+
+	return builder.String(), nil
+}
+
+func rangeBracket(tp pgtype.BoundType, inclusive, exclusive byte) byte {
+	if tp == pgtype.Inclusive {
+		return inclusive
+	}
+
+	return exclusive
+}
+
+// multirangeLiteral renders a multirange like {["1","5"),["7","9")}.
+func multirangeLiteral(m pgtype.MultirangeGetter) (string, error) {
+	ranges := make([]string, 0, m.Len())
+	for i := range m.Len() {
+		text, err := Text(m.Index(i))
+		if err != nil {
+			return "", fmt.Errorf("%w: multirange literal", err)
+		}
+
+		ranges = append(ranges, text)
+	}
+
+	return "{" + strings.Join(ranges, ",") + "}", nil
+}
+
+// isList reports whether Text renders v as an array literal.
+func isList(v any) bool {
+	switch v.(type) {
+	case []byte, driver.Valuer, fmt.Stringer, model.Record, pgtype.RangeValuer, pgtype.MultirangeGetter:
+		return false
+	}
+
+	kind := reflect.ValueOf(v).Kind()
+
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// JSON converts a generated value into one encoding/json renders without losing it:
+// numbers, strings and booleans stay native, lists become json arrays, the rest is Text.
+func JSON(v any) (any, error) {
+	const fnName = "encode: json"
+
+	switch val := v.(type) {
+	case nil, string, bool:
+		return val, nil
+	case float32:
+		if math.IsNaN(float64(val)) || math.IsInf(float64(val), 0) {
+			return formatFloat(float64(val), 32), nil
+		}
+
+		return val, nil
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return formatFloat(val, 64), nil
+		}
+
+		return val, nil
+	case time.Time:
+		return val.Format(time.RFC3339Nano), nil
+	case []byte, model.LargeObject, driver.Valuer, fmt.Stringer, pgtype.RangeValuer, pgtype.MultirangeGetter:
+		text, err := Text(val)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		return text, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // the rest is rendered as text
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v, nil
+	case reflect.Slice, reflect.Array:
+		out := make([]any, rv.Len())
+		for i := range out {
+			elem, err := JSON(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, fnName)
+			}
+
+			out[i] = elem
+		}
+
+		return out, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,88 @@
+package encode_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/encode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TextRecord(t *testing.T) {
+	t.Parallel()
+
+	record := model.Record{
+		int64(12),
+		"Baker Street",
+		nil,
+		"",
+		`say "hi" \ bye`,
+		time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+		model.Record{"a,b", int32(1)},
+		[]any{"x", "y"},
+	}
+
+	text, err := encode.Text(record)
+	require.NoError(t, err)
+	require.Equal(t,
+		`(12,"Baker Street",,"","say ""hi"" \\ bye","2024-05-01 10:00:00Z","(""a,b"",1)","{""x"",""y""}")`,
+		text,
+	)
+
+	text, err = encode.Text([]any{model.Record{int64(1), "two"}})
+	require.NoError(t, err)
+	require.Equal(t, `{"(1,two)"}`, text)
+}
+
+func Test_TextRange(t *testing.T) {
+	t.Parallel()
+
+	r := pgtype.Range[int64]{
+		Lower:     1,
+		Upper:     5,
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Exclusive,
+		Valid:     true,
+	}
+	text, err := encode.Text(r)
+	require.NoError(t, err)
+	require.Equal(t, `["1","5")`, text)
+
+	//nolint:exhaustruct // ok for tests
+	empty := pgtype.Range[int64]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}
+	text, err = encode.Text(pgtype.Multirange[pgtype.Range[int64]]{r, empty})
+	require.NoError(t, err)
+	require.Equal(t, `{["1","5"),empty}`, text)
+
+	//nolint:exhaustruct // ok for tests
+	unbounded := pgtype.Range[time.Time]{
+		Lower:     time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+		LowerType: pgtype.Exclusive,
+		UpperType: pgtype.Unbounded,
+		Valid:     true,
+	}
+	text, err = encode.Text(unbounded)
+	require.NoError(t, err)
+	require.Equal(t, `("2024-05-01 10:00:00Z",)`, text)
+
+	value, err := encode.JSON(r)
+	require.NoError(t, err)
+	require.Equal(t, `["1","5")`, value)
+}
+
+func Test_LargeObject(t *testing.T) {
+	t.Parallel()
+
+	lo := model.LargeObject{Data: []byte{0xde, 0xad, 0x01}}
+
+	text, err := encode.Text(lo)
+	require.NoError(t, err)
+	require.Equal(t, `\xdead01`, text)
+
+	value, err := encode.JSON(lo)
+	require.NoError(t, err)
+	require.Equal(t, `\xdead01`, value)
+}
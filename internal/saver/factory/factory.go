@@ -7,17 +7,38 @@ import (
 
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/closer"
+	"github.com/jmozgit/datagen/internal/saver/file"
+	"github.com/jmozgit/datagen/internal/saver/mysql"
+	"github.com/jmozgit/datagen/internal/saver/oracle"
+	"github.com/jmozgit/datagen/internal/saver/parquet"
 	"github.com/jmozgit/datagen/internal/saver/postgres"
+	"github.com/jmozgit/datagen/internal/saver/sqldump"
+	"github.com/jmozgit/datagen/internal/saver/sqlite"
+
+	"github.com/samber/lo"
 )
 
-var ErrUnknownConnectionType = errors.New("unknown connection type")
+var (
+	ErrUnknownConnectionType = errors.New("unknown connection type")
+	ErrUnknownOutputType     = errors.New("unknown output type")
+)
 
 type Saver interface {
 	PrepareHints(ctx context.Context, schema model.DatasetSchema) *model.SavingHints
 	Save(ctx context.Context, batch model.SaveBatch) (model.SavedBatch, error)
 }
 
-func GetSaver(ctx context.Context, cfg config.Config) (Saver, error) {
+// TableOrderer is implemented by savers whose output depends on the order tables are filled in.
+type TableOrderer interface {
+	SetTableOrder(order []model.TableName)
+}
+
+func GetSaver(ctx context.Context, cfg config.Config, closer *closer.Registry) (Saver, error) {
+	if cfg.Output != nil {
+		return getOutputSaver(cfg.Output, closer)
+	}
+
 	switch cfg.Connection.Type {
 	case config.PostgresqlConnection:
 		pgdb, err := postgres.New(ctx, cfg.Connection.ConnString())
@@ -26,7 +47,62 @@ func GetSaver(ctx context.Context, cfg config.Config) (Saver, error) {
 		}
 
 		return pgdb, nil
+	case config.MySQLConnection:
+		mysqldb, err := mysql.New(cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for mysql", err)
+		}
+		closer.Add(mysqldb)
+
+		return mysqldb, nil
+	case config.SQLiteConnection:
+		sqlitedb, err := sqlite.New(cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for sqlite", err)
+		}
+		closer.Add(sqlitedb)
+
+		return sqlitedb, nil
+	case config.OracleConnection:
+		oradb, err := oracle.New(cfg.Connection.ConnString())
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for oracle", err)
+		}
+		closer.Add(oradb)
+
+		return oradb, nil
 	default:
 		return nil, fmt.Errorf("%w: get saver %s", ErrUnknownConnectionType, cfg.Connection.Type)
 	}
 }
+
+func getOutputSaver(output *config.Output, closer *closer.Registry) (Saver, error) {
+	switch output.Type {
+	case config.FileOutputType:
+		saver, err := file.New(lo.FromPtr(output.File))
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for file", err)
+		}
+		closer.Add(saver)
+
+		return saver, nil
+	case config.SQLOutputType:
+		saver, err := sqldump.New(lo.FromPtr(output.SQL))
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for sql", err)
+		}
+		closer.Add(saver)
+
+		return saver, nil
+	case config.ParquetOutputType:
+		saver, err := parquet.New(lo.FromPtr(output.Parquet))
+		if err != nil {
+			return nil, fmt.Errorf("%w: get saver for parquet", err)
+		}
+		closer.Add(saver)
+
+		return saver, nil
+	default:
+		return nil, fmt.Errorf("%w: get saver %s", ErrUnknownOutputType, output.Type)
+	}
+}
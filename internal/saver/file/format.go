@@ -0,0 +1,121 @@
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/saver/encode"
+)
+
+type csvWriter struct {
+	delimiter string
+	quoteAll  bool
+	null      string
+}
+
+func (c csvWriter) header(w *bufio.Writer, columns []string) error {
+	for i, column := range columns {
+		if i > 0 {
+			_, _ = w.WriteString(c.delimiter)
+		}
+		c.field(w, column)
+	}
+
+	if err := w.WriteByte('\n'); err != nil {
+		return fmt.Errorf("%w: csv header", err)
+	}
+
+	return nil
+}
+
+func (c csvWriter) row(w *bufio.Writer, _ []string, row []any) error {
+	const fnName = "csv row"
+
+	for i, value := range row {
+		if i > 0 {
+			_, _ = w.WriteString(c.delimiter)
+		}
+
+		if value == nil {
+			_, _ = w.WriteString(c.null)
+
+			continue
+		}
+
+		text, err := encode.Text(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+		c.field(w, text)
+	}
+
+	if err := w.WriteByte('\n'); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+// field writes a non null value, quoting it whenever it could be confused with null
+// or break the line apart.
+func (c csvWriter) field(w *bufio.Writer, text string) {
+	needQuote := c.quoteAll ||
+		text == c.null ||
+		strings.Contains(text, c.delimiter) ||
+		strings.ContainsAny(text, "\"\r\n")
+	if !needQuote {
+		_, _ = w.WriteString(text)
+
+		return
+	}
+
+	_ = w.WriteByte('"')
+	_, _ = w.WriteString(strings.ReplaceAll(text, `"`, `""`))
+	_ = w.WriteByte('"')
+}
+
+type jsonlWriter struct{}
+
+func (jsonlWriter) header(_ *bufio.Writer, _ []string) error {
+	return nil
+}
+
+// row keeps the column order of the table, which a map based encoding would lose.
+func (jsonlWriter) row(w *bufio.Writer, columns []string, row []any) error {
+	const fnName = "jsonl row"
+
+	_ = w.WriteByte('{')
+	for i, value := range row {
+		if i > 0 {
+			_ = w.WriteByte(',')
+		}
+
+		key, err := json.Marshal(columns[i])
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		jsonValue, err := encode.JSON(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		data, err := json.Marshal(jsonValue)
+		if err != nil {
+			return fmt.Errorf("%w: %s %s", err, columns[i], fnName)
+		}
+
+		_, _ = w.Write(key)
+		_ = w.WriteByte(':')
+		_, _ = w.Write(data)
+	}
+	_ = w.WriteByte('}')
+
+	if err := w.WriteByte('\n'); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+)
+
+var (
+	ErrUnknownFormat    = errors.New("unknown file format")
+	ErrUnknownQuote     = errors.New("unknown csv quote mode")
+	ErrInvalidDelimiter = errors.New("csv delimiter must not be empty or contain quotes and line breaks")
+)
+
+type rowWriter interface {
+	header(w *bufio.Writer, columns []string) error
+	row(w *bufio.Writer, columns []string, row []any) error
+}
+
+type tableFile struct {
+	file *os.File
+	buf  *bufio.Writer
+}
+
+// Saver writes batches to per table files instead of a database.
+type Saver struct {
+	dir       string
+	ext       string
+	writer    rowWriter
+	header    bool
+	mu        sync.Mutex
+	files     map[model.TableName]*tableFile
+	closeOnce sync.Once
+}
+
+func New(cfg config.FileOutput) (*Saver, error) {
+	const fnName = "file: new"
+
+	saver := &Saver{
+		dir:       cfg.Dir,
+		ext:       string(cfg.Format),
+		writer:    nil,
+		header:    false,
+		mu:        sync.Mutex{},
+		files:     make(map[model.TableName]*tableFile),
+		closeOnce: sync.Once{},
+	}
+
+	switch cfg.Format {
+	case config.FileFormatCSV:
+		csv, err := newCSVWriter(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		saver.writer = csv
+		saver.header = lo.FromPtrOr(cfg.Header, true)
+	case config.FileFormatJSONL:
+		saver.writer = jsonlWriter{}
+	default:
+		return nil, fmt.Errorf("%w: %q %s", ErrUnknownFormat, cfg.Format, fnName)
+	}
+
+	if saver.dir == "" {
+		saver.dir = "."
+	}
+
+	if err := os.MkdirAll(saver.dir, 0o755); err != nil { //nolint:mnd // usual permissions
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return saver, nil
+}
+
+func newCSVWriter(cfg config.FileOutput) (csvWriter, error) {
+	const fnName = "new csv writer"
+
+	delimiter := cfg.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+	}
+	if strings.ContainsAny(delimiter, "\"\r\n") {
+		return csvWriter{}, fmt.Errorf("%w: %s", ErrInvalidDelimiter, fnName)
+	}
+
+	quote := cfg.Quote
+	if quote == "" {
+		quote = config.CSVQuoteMinimal
+	}
+	if quote != config.CSVQuoteMinimal && quote != config.CSVQuoteAll {
+		return csvWriter{}, fmt.Errorf("%w: %q %s", ErrUnknownQuote, cfg.Quote, fnName)
+	}
+
+	return csvWriter{
+		delimiter: delimiter,
+		quoteAll:  quote == config.CSVQuoteAll,
+		null:      cfg.Null,
+	}, nil
+}
+
+func (s *Saver) PrepareHints(_ context.Context, _ model.DatasetSchema) *model.SavingHints {
+	return model.NewSavingHints()
+}
+
+func (s *Saver) Save(_ context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	const fnName = "file: save"
+
+	columns := lo.Map(batch.Schema.Columns, func(ct model.TargetType, _ int) string {
+		return ct.SourceName.AsArgument()
+	})
+
+	tf, err := s.tableFile(batch.Schema.TableName, columns)
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	for _, row := range batch.Data {
+		if err := s.writer.row(tf.buf, columns, row); err != nil {
+			return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
+	// flushing every batch keeps the file in line with what the limiter has seen
+	if err := tf.buf.Flush(); err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.SavedBatch{
+		Stat: model.SaveReport{
+			RowsSaved:           len(batch.Data),
+			ConstraintViolation: 0,
+		},
+		Batch: batch,
+	}, nil
+}
+
+func (s *Saver) tableFile(table model.TableName, columns []string) (*tableFile, error) {
+	const fnName = "table file"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tf, ok := s.files[table]; ok {
+		return tf, nil
+	}
+
+	name := table.Schema.AsArgument() + "." + table.Table.AsArgument() + "." + s.ext
+
+	f, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	tf := &tableFile{file: f, buf: bufio.NewWriter(f)}
+	if s.header {
+		if err := s.writer.header(tf.buf, columns); err != nil {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+	s.files[table] = tf
+
+	return tf, nil
+}
+
+func (s *Saver) Close(_ context.Context) error {
+	const fnName = "file: close"
+
+	var err error
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		errs := make([]error, 0)
+		for _, tf := range s.files {
+			errs = append(errs, tf.buf.Flush(), tf.file.Close())
+		}
+		err = errors.Join(errs...)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
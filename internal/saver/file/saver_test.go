@@ -0,0 +1,113 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/file"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func testBatch() model.SaveBatch {
+	schema := model.DatasetSchema{
+		TableName: model.TableName{
+			Schema: model.PGIdentifier("public"),
+			Table:  model.PGIdentifier("users"),
+		},
+		Columns: []model.TargetType{
+			{SourceName: model.PGIdentifier("id")},
+			{SourceName: model.PGIdentifier("name")},
+			{SourceName: model.PGIdentifier("tags")},
+			{SourceName: model.PGIdentifier("created")},
+		},
+		UniqueConstraints: nil,
+	}
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return model.SaveBatch{
+		Schema: schema,
+		Data: [][]any{
+			{int64(1), "plain", []any{"a", "b"}, created},
+			{int64(2), "with, comma", []any{`q"uote`, nil}, nil},
+			{int64(3), "", nil, created},
+		},
+		SavingHints: model.NewSavingHints(),
+		Invalid:     make([]bool, 3),
+	}
+}
+
+func saveAndRead(t *testing.T, cfg config.FileOutput) string {
+	t.Helper()
+
+	cfg.Dir = t.TempDir()
+	saver, err := file.New(cfg)
+	require.NoError(t, err)
+
+	batch := testBatch()
+	saved, err := saver.Save(t.Context(), batch)
+	require.NoError(t, err)
+	require.Equal(t, 3, saved.Stat.RowsSaved)
+	require.NoError(t, saver.Close(t.Context()))
+
+	data, err := os.ReadFile(filepath.Join(cfg.Dir, "public.users."+string(cfg.Format)))
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func Test_CSVDefaults(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // defaults are tested
+	out := saveAndRead(t, config.FileOutput{Format: config.FileFormatCSV})
+
+	require.Equal(t, `id,name,tags,created
+1,plain,"{""a"",""b""}",2024-01-02 03:04:05Z
+2,"with, comma","{""q\""uote"",NULL}",
+3,"",,2024-01-02 03:04:05Z
+`, out)
+}
+
+func Test_CSVOptions(t *testing.T) {
+	t.Parallel()
+
+	out := saveAndRead(t, config.FileOutput{
+		Dir:       "",
+		Format:    config.FileFormatCSV,
+		Delimiter: "\t",
+		Header:    lo.ToPtr(false),
+		Quote:     config.CSVQuoteAll,
+		Null:      `\N`,
+	})
+
+	require.Equal(t, "\"1\"\t\"plain\"\t\"{\"\"a\"\",\"\"b\"\"}\"\t\"2024-01-02 03:04:05Z\"\n"+
+		"\"2\"\t\"with, comma\"\t\"{\"\"q\\\"\"uote\"\",NULL}\"\t\\N\n"+
+		"\"3\"\t\"\"\t\\N\t\"2024-01-02 03:04:05Z\"\n", out)
+}
+
+func Test_JSONL(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // defaults are tested
+	out := saveAndRead(t, config.FileOutput{Format: config.FileFormatJSONL})
+
+	require.Equal(t, `{"id":1,"name":"plain","tags":["a","b"],"created":"2024-01-02T03:04:05Z"}
+{"id":2,"name":"with, comma","tags":["q\"uote",null],"created":null}
+{"id":3,"name":"","tags":null,"created":"2024-01-02T03:04:05Z"}
+`, out)
+}
+
+func Test_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // only format matters
+	_, err := file.New(config.FileOutput{Dir: t.TempDir(), Format: "xml"})
+	require.ErrorIs(t, err, file.ErrUnknownFormat)
+}
@@ -0,0 +1,165 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/common"
+
+	_ "github.com/go-sql-driver/mysql" // register driver
+	"github.com/samber/lo"
+)
+
+const (
+	insertThresholdRowSize = 10
+	// maxPlaceholders is the limit of the mysql prepared statement protocol.
+	maxPlaceholders = 65535
+)
+
+type DB struct {
+	db *sql.DB
+}
+
+func New(dsn string) (*DB, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new", err)
+	}
+
+	return &DB{db: db}, nil
+}
+
+func (d *DB) PrepareHints(_ context.Context, schema model.DatasetSchema) *model.SavingHints {
+	hints := model.NewSavingHints()
+	hints.AddString("insert_query_hint", insertQuery(schema, 1))
+
+	return hints
+}
+
+func (d *DB) Save(ctx context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	report := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	insQuery, err := batch.SavingHints.GetString("insert_query_hint")
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+	}
+
+	columns := max(len(batch.Schema.Columns), 1)
+	parts := []common.DataPartitionerMut{common.NewDataPartionerMut(batch.Data)}
+	for len(parts) > 0 {
+		curPart := parts[0]
+		parts = parts[1:]
+
+		if curPart.Len() < insertThresholdRowSize {
+			saved, err := d.insert(ctx, insQuery, batch, curPart)
+			if err != nil {
+				return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+			}
+			report = report.Add(saved)
+
+			continue
+		}
+
+		if curPart.Len()*columns > maxPlaceholders {
+			before, after := curPart.Split()
+			parts = append(parts, before, after)
+
+			continue
+		}
+
+		saved, err := d.multiInsert(ctx, batch.Schema, curPart.Data())
+		switch {
+		case err == nil:
+			report = report.Add(saved)
+		case IsConstraintViolatesErr(err):
+			before, after := curPart.Split()
+			parts = append(parts, before, after)
+		default:
+			return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+		}
+	}
+
+	return model.SavedBatch{
+		Stat:  report,
+		Batch: batch,
+	}, nil
+}
+
+// multiInsert saves rows with a single statement, it either saves all of them or none.
+func (d *DB) multiInsert(
+	ctx context.Context,
+	schema model.DatasetSchema,
+	data [][]any,
+) (model.SaveReport, error) {
+	args := make([]any, 0, len(data)*len(schema.Columns))
+	for _, row := range data {
+		args = append(args, row...)
+	}
+
+	if _, err := d.db.ExecContext(ctx, insertQuery(schema, len(data)), args...); err != nil {
+		return model.SaveReport{}, fmt.Errorf("%w: multi insert", err)
+	}
+
+	return model.SaveReport{
+		ConstraintViolation: 0,
+		RowsSaved:           len(data),
+	}, nil
+}
+
+func insertQuery(schema model.DatasetSchema, rows int) string {
+	columns := lo.Map(schema.Columns, func(ct model.TargetType, _ int) string {
+		return ct.SourceName.Quoted()
+	})
+	values := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s",
+		schema.TableName.Quoted(), strings.Join(columns, ","),
+		strings.TrimSuffix(strings.Repeat(values+",", rows), ","),
+	)
+}
+
+func (d *DB) insert(
+	ctx context.Context,
+	query string,
+	batch model.SaveBatch,
+	partioner common.DataPartitionerMut,
+) (model.SaveReport, error) {
+	collected := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	data := partioner.Data()
+	for i, row := range data {
+		_, err := d.db.ExecContext(ctx, query, row...)
+		if err != nil {
+			if IsConstraintViolatesErr(err) {
+				collected.ConstraintViolation++
+				batch.MakeInvalid(partioner.RealIndex(i))
+
+				continue
+			}
+
+			return model.SaveReport{}, fmt.Errorf("%w: insert", err)
+		}
+
+		collected.RowsSaved++
+	}
+
+	return collected, nil
+}
+
+func (d *DB) Close(_ context.Context) error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("%w: mysql: close", err)
+	}
+
+	return nil
+}
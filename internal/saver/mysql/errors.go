@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// server error numbers, named after ER_* codes.
+const (
+	errDupEntry         = 1062
+	errNoReferencedRow  = 1216
+	errNoReferencedRow2 = 1452
+	errCheckConstraint  = 3819
+)
+
+func IsConstraintViolatesErr(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case errDupEntry, errNoReferencedRow, errNoReferencedRow2, errCheckConstraint:
+			return true
+		}
+	}
+
+	return false
+}
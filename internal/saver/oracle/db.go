@@ -0,0 +1,182 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/common"
+
+	"github.com/samber/lo"
+	_ "github.com/sijms/go-ora/v2" // register driver
+)
+
+const (
+	insertThresholdRowSize = 10
+	// maxArrayRows is the largest array a single execution binds.
+	maxArrayRows = 65535
+)
+
+type DB struct {
+	db *sql.DB
+}
+
+func New(dsn string) (*DB, error) {
+	db, err := sql.Open("oracle", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new", err)
+	}
+
+	return &DB{db: db}, nil
+}
+
+func (d *DB) PrepareHints(_ context.Context, schema model.DatasetSchema) *model.SavingHints {
+	hints := model.NewSavingHints()
+	hints.AddString("insert_query_hint", insertQuery(schema))
+
+	return hints
+}
+
+func (d *DB) Save(ctx context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	report := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	insQuery, err := batch.SavingHints.GetString("insert_query_hint")
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+	}
+
+	parts := []common.DataPartitionerMut{common.NewDataPartionerMut(batch.Data)}
+	for len(parts) > 0 {
+		curPart := parts[0]
+		parts = parts[1:]
+
+		if curPart.Len() < insertThresholdRowSize {
+			saved, err := d.insert(ctx, insQuery, batch, curPart)
+			if err != nil {
+				return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+			}
+			report = report.Add(saved)
+
+			continue
+		}
+
+		if curPart.Len() > maxArrayRows {
+			before, after := curPart.Split()
+			parts = append(parts, before, after)
+
+			continue
+		}
+
+		saved, err := d.arrayInsert(ctx, insQuery, len(batch.Schema.Columns), curPart.Data())
+		switch {
+		case err == nil:
+			report = report.Add(saved)
+		case IsConstraintViolatesErr(err):
+			before, after := curPart.Split()
+			parts = append(parts, before, after)
+		default:
+			return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+		}
+	}
+
+	return model.SavedBatch{
+		Stat:  report,
+		Batch: batch,
+	}, nil
+}
+
+// arrayInsert binds a column of values per placeholder, so rows are sent within a single round trip.
+// Oracle keeps rows inserted before a failed one, the transaction makes it all or nothing.
+func (d *DB) arrayInsert(ctx context.Context, query string, columns int, data [][]any) (model.SaveReport, error) {
+	const fnName = "array insert"
+
+	args := make([]any, columns)
+	for col := range columns {
+		values := make([]any, len(data))
+		for row := range data {
+			values[row] = data[row][col]
+		}
+		args[col] = values
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.SaveReport{
+		ConstraintViolation: 0,
+		RowsSaved:           len(data),
+	}, nil
+}
+
+func insertQuery(schema model.DatasetSchema) string {
+	columns := lo.Map(schema.Columns, func(ct model.TargetType, _ int) string {
+		return ct.SourceName.Quoted()
+	})
+	placeholders := lo.Map(schema.Columns, func(_ model.TargetType, idx int) string {
+		return fmt.Sprintf(":%d", idx+1)
+	})
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		schema.TableName.Quoted(), strings.Join(columns, ","), strings.Join(placeholders, ","),
+	)
+}
+
+func (d *DB) insert(
+	ctx context.Context,
+	query string,
+	batch model.SaveBatch,
+	partioner common.DataPartitionerMut,
+) (model.SaveReport, error) {
+	collected := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	data := partioner.Data()
+	for i, row := range data {
+		_, err := d.db.ExecContext(ctx, query, row...)
+		if err != nil {
+			if IsConstraintViolatesErr(err) {
+				collected.ConstraintViolation++
+				batch.MakeInvalid(partioner.RealIndex(i))
+
+				continue
+			}
+
+			return model.SaveReport{}, fmt.Errorf("%w: insert", err)
+		}
+
+		collected.RowsSaved++
+	}
+
+	return collected, nil
+}
+
+func (d *DB) Close(_ context.Context) error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("%w: oracle: close", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package oracle_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	testoracle "github.com/jmozgit/datagen/internal/pkg/testconn/oracle"
+	"github.com/jmozgit/datagen/internal/saver/oracle"
+
+	"github.com/stretchr/testify/require"
+)
+
+type saveSetup struct {
+	testConn *testoracle.Conn
+	connect  *oracle.DB
+	table    model.TableName
+}
+
+func newSaveSetup(t *testing.T, opts ...options.CreateTableOption) *saveSetup {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATAGEN_ORACLE_CONN")
+	if dsn == "" {
+		t.Skipf("test oracle env host isn't set")
+	}
+
+	tmpConn, err := testoracle.New(t, dsn)
+	require.NoError(t, err)
+
+	table := model.TableName{
+		Schema: model.OracleIdentifier(tmpConn.Schema()),
+		Table:  model.OracleIdentifier("test_with_pk"),
+	}
+	err = tmpConn.CreateTable(t.Context(), model.Table{
+		Name: table,
+		Columns: []model.Column{
+			{Name: model.OracleIdentifier("id"), Type: "number(10)", IsNullable: false, FixedSize: 8},
+		},
+	}, opts...)
+	require.NoError(t, err)
+
+	connect, err := oracle.New(tmpConn.SQLConnection().ConnString("oracle"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, connect.Close(t.Context())) })
+
+	return &saveSetup{
+		testConn: tmpConn,
+		connect:  connect,
+		table:    table,
+	}
+}
+
+func (s *saveSetup) save(t *testing.T, data [][]any) (model.SaveReport, int) {
+	t.Helper()
+
+	schema := model.DatasetSchema{
+		TableName: s.table,
+		Columns: []model.TargetType{
+			//nolint:exhaustruct // ok for tests
+			{SourceName: model.OracleIdentifier("id"), SourceType: "number"},
+		},
+		UniqueConstraints: nil,
+	}
+	batch := model.SaveBatch{
+		SavingHints: s.connect.PrepareHints(t.Context(), schema),
+		Schema:      schema,
+		Data:        data,
+		Invalid:     make([]bool, len(data)),
+	}
+
+	saved, err := s.connect.Save(t.Context(), batch)
+	require.NoError(t, err)
+
+	cntInvalid := 0
+	for _, d := range batch.Invalid {
+		if d {
+			cntInvalid++
+		}
+	}
+
+	return saved.Stat, cntInvalid
+}
+
+func Test_DbSaveNoErrors(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t)
+
+	data := make([][]any, 23)
+	for i := range data {
+		data[i] = []any{int64(i)}
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 0, RowsSaved: len(data)}, report)
+	require.Equal(t, 0, invalid)
+}
+
+func Test_DbSaveManyDuplicates(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t, options.WithPKs([]string{"id"}))
+
+	data := make([][]any, 0, 26)
+	for i := range cap(data) / 2 {
+		data = append(data, []any{int64(i)}, []any{int64(i)})
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 13, RowsSaved: 13}, report)
+	require.Equal(t, 13, invalid)
+}
+
+func Test_OnlyOneUniqueRow(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t, options.WithPKs([]string{"id"}))
+
+	data := make([][]any, 0, 26)
+	for range cap(data) {
+		data = append(data, []any{int64(10)})
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 25, RowsSaved: 1}, report)
+	require.Equal(t, 25, invalid)
+}
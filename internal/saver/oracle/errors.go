@@ -0,0 +1,26 @@
+package oracle
+
+import (
+	"errors"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+// ORA-* error numbers.
+const (
+	errUniqueConstraint = 1
+	errCheckConstraint  = 2290
+	errParentKeyMissing = 2291
+)
+
+func IsConstraintViolatesErr(err error) bool {
+	var oraErr *network.OracleError
+	if errors.As(err, &oraErr) {
+		switch oraErr.ErrCode {
+		case errUniqueConstraint, errCheckConstraint, errParentKeyMissing:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+)
+
+var ErrUnknownCompression = errors.New("unknown parquet compression")
+
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += uint64(n)
+
+	return n, err //nolint:wrapcheck // transparent writer
+}
+
+type tableWriter struct {
+	schema  *parquetgo.Schema
+	columns []column
+	part    int
+
+	file    *os.File
+	counter *countingWriter
+	writer  *parquetgo.Writer
+}
+
+// Saver writes every batch as a parquet row group.
+type Saver struct {
+	dir         string
+	maxFileSize uint64
+	codec       compress.Codec
+	mu          sync.Mutex
+	tables      map[model.TableName]*tableWriter
+}
+
+func New(cfg config.ParquetOutput) (*Saver, error) {
+	const fnName = "parquet: new"
+
+	codec, err := compressionCodec(cfg.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd // usual permissions
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return &Saver{
+		dir:         dir,
+		maxFileSize: cfg.MaxFileSize.Bytes(),
+		codec:       codec,
+		mu:          sync.Mutex{},
+		tables:      make(map[model.TableName]*tableWriter),
+	}, nil
+}
+
+func compressionCodec(name string) (compress.Codec, error) {
+	switch name {
+	case "", "snappy":
+		return &parquetgo.Snappy, nil
+	case "none":
+		return &parquetgo.Uncompressed, nil
+	case "gzip":
+		return &parquetgo.Gzip, nil
+	case "zstd":
+		return &parquetgo.Zstd, nil
+	case "lz4":
+		return &parquetgo.Lz4Raw, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCompression, name)
+	}
+}
+
+func (s *Saver) PrepareHints(_ context.Context, _ model.DatasetSchema) *model.SavingHints {
+	return model.NewSavingHints()
+}
+
+func (s *Saver) Save(_ context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	const fnName = "parquet: save"
+
+	tw := s.tableWriter(batch.Schema)
+
+	var err error
+	rows := make([]parquetgo.Row, len(batch.Data))
+	for i, data := range batch.Data {
+		rows[i], err = tw.row(data)
+		if err != nil {
+			return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
+	if err := tw.write(s.dir, batch.Schema.TableName, s.maxFileSize, s.codec, rows); err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.SavedBatch{
+		Stat: model.SaveReport{
+			RowsSaved:           len(batch.Data),
+			ConstraintViolation: 0,
+		},
+		Batch: batch,
+	}, nil
+}
+
+func (s *Saver) tableWriter(dataset model.DatasetSchema) *tableWriter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tw, ok := s.tables[dataset.TableName]; ok {
+		return tw
+	}
+
+	schema, columns := buildSchema(dataset)
+	tw := &tableWriter{
+		schema:  schema,
+		columns: columns,
+		part:    0,
+		file:    nil,
+		counter: nil,
+		writer:  nil,
+	}
+	s.tables[dataset.TableName] = tw
+
+	return tw
+}
+
+func (t *tableWriter) row(data []any) (parquetgo.Row, error) {
+	byIndex := make([][]parquetgo.Value, len(t.columns))
+	for i, col := range t.columns {
+		values, err := col.values(data[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: row", err)
+		}
+
+		byIndex[col.index] = values
+	}
+
+	return parquetgo.Row(slices.Concat(byIndex...)), nil
+}
+
+func (t *tableWriter) write(
+	dir string,
+	table model.TableName,
+	maxFileSize uint64,
+	codec compress.Codec,
+	rows []parquetgo.Row,
+) error {
+	const fnName = "write"
+
+	if t.writer == nil {
+		name := table.String() + ".parquet"
+		if maxFileSize > 0 {
+			name = fmt.Sprintf("%s.%05d.parquet", table.String(), t.part)
+		}
+
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		t.file = f
+		t.counter = &countingWriter{w: f, n: 0}
+		// row groups are written whole, so buffering gains little and would hide the file size
+		t.writer = parquetgo.NewWriter(
+			t.counter, t.schema,
+			parquetgo.Compression(codec),
+			parquetgo.WriteBufferSize(0),
+		)
+	}
+
+	if _, err := t.writer.WriteRows(rows); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := t.writer.Flush(); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if maxFileSize > 0 && t.counter.n >= maxFileSize {
+		if err := t.close(); err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+		t.part++
+	}
+
+	return nil
+}
+
+func (t *tableWriter) close() error {
+	if t.writer == nil {
+		return nil
+	}
+
+	err := errors.Join(t.writer.Close(), t.file.Close())
+	t.writer, t.file, t.counter = nil, nil, nil
+
+	return err
+}
+
+func (s *Saver) Close(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	errs := make([]error, 0, len(s.tables))
+	for _, tw := range s.tables {
+		errs = append(errs, tw.close())
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: parquet: close", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package parquet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/parquet"
+
+	"github.com/c2h5oh/datasize"
+	gouuid "github.com/gofrs/uuid"
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID      int32     `parquet:"id"`
+	Amount  *float64  `parquet:"amount"`
+	Name    *string   `parquet:"name"`
+	Created time.Time `parquet:"created,timestamp(microsecond)"`
+	Day     *int32    `parquet:"day"`
+	UUID    []byte    `parquet:"uuid"`
+	Tags    []*int64  `parquet:"tags,list"`
+}
+
+func testSchema() model.DatasetSchema {
+	return model.DatasetSchema{
+		TableName: model.TableName{
+			Schema: model.PGIdentifier("public"),
+			Table:  model.PGIdentifier("orders"),
+		},
+		//nolint:exhaustruct // only types matter
+		Columns: []model.TargetType{
+			{SourceName: model.PGIdentifier("id"), Type: model.Integer, FixedSize: 4},
+			{SourceName: model.PGIdentifier("amount"), Type: model.Float, FixedSize: 8, IsNullable: true},
+			{SourceName: model.PGIdentifier("name"), Type: model.Text, FixedSize: -1, IsNullable: true},
+			{SourceName: model.PGIdentifier("created"), Type: model.Timestamp, FixedSize: 8},
+			{SourceName: model.PGIdentifier("day"), Type: model.Date, FixedSize: 4, IsNullable: true},
+			{SourceName: model.PGIdentifier("uuid"), Type: model.UUID, FixedSize: 16},
+			{
+				SourceName: model.PGIdentifier("tags"),
+				Type:       model.Array,
+				FixedSize:  -1,
+				IsNullable: true,
+				ArrayElem:  model.ArrayInfo{ElemType: model.Integer, ElemSize: 8, SourceType: "int8"},
+			},
+		},
+		UniqueConstraints: nil,
+	}
+}
+
+func batch(data [][]any) model.SaveBatch {
+	return model.SaveBatch{
+		Schema:      testSchema(),
+		Data:        data,
+		SavingHints: model.NewSavingHints(),
+		Invalid:     make([]bool, len(data)),
+	}
+}
+
+func Test_SaveAndRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	saver, err := parquet.New(config.ParquetOutput{Dir: dir, MaxFileSize: 0, Compression: ""})
+	require.NoError(t, err)
+
+	created := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	id := gouuid.Must(gouuid.NewV4())
+
+	_, err = saver.Save(t.Context(), batch([][]any{
+		{int64(1), 1.5, "first", created, created, id, []any{int64(1), nil, int64(3)}},
+		{int32(2), nil, nil, created, nil, id, nil},
+	}))
+	require.NoError(t, err)
+	_, err = saver.Save(t.Context(), batch([][]any{
+		{int64(3), 2.5, "third", created, created, id, [][]any{{int64(4)}, {int64(5)}}},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, saver.Close(t.Context()))
+
+	f, err := os.Open(filepath.Join(dir, "public.orders.parquet"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	stat, err := f.Stat()
+	require.NoError(t, err)
+
+	pf, err := parquetgo.OpenFile(f, stat.Size())
+	require.NoError(t, err)
+	require.Len(t, pf.RowGroups(), 2)
+
+	rows, err := parquetgo.Read[record](f, stat.Size())
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	one, three, four, five := int64(1), int64(3), int64(4), int64(5)
+	days := int32(created.Unix() / 86400)
+
+	require.Equal(t, int32(1), rows[0].ID)
+	require.InDelta(t, 1.5, *rows[0].Amount, 0)
+	require.Equal(t, "first", *rows[0].Name)
+	require.True(t, created.Equal(rows[0].Created))
+	require.Equal(t, days, *rows[0].Day)
+	require.Equal(t, id.Bytes(), rows[0].UUID)
+	require.Equal(t, []*int64{&one, nil, &three}, rows[0].Tags)
+
+	require.Nil(t, rows[1].Amount)
+	require.Nil(t, rows[1].Name)
+	require.Nil(t, rows[1].Day)
+	require.Empty(t, rows[1].Tags)
+
+	require.Equal(t, []*int64{&four, &five}, rows[2].Tags)
+}
+
+func Test_Rotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	saver, err := parquet.New(config.ParquetOutput{Dir: dir, MaxFileSize: datasize.B, Compression: "none"})
+	require.NoError(t, err)
+
+	created := time.Now()
+	id := gouuid.Must(gouuid.NewV4())
+	for i := range 3 {
+		_, err = saver.Save(t.Context(), batch([][]any{
+			{int64(i), nil, nil, created, nil, id, nil},
+		}))
+		require.NoError(t, err)
+	}
+	require.NoError(t, saver.Close(t.Context()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "public.orders.*.parquet"))
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+}
+
+func Test_UnknownCompression(t *testing.T) {
+	t.Parallel()
+
+	_, err := parquet.New(config.ParquetOutput{Dir: t.TempDir(), MaxFileSize: 0, Compression: "rar"})
+	require.ErrorIs(t, err, parquet.ErrUnknownCompression)
+}
@@ -0,0 +1,328 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/encode"
+
+	gouuid "github.com/gofrs/uuid"
+	parquetgo "github.com/parquet-go/parquet-go"
+)
+
+var ErrUnexpectedValue = errors.New("value doesn't fit the parquet column")
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindBytes
+	kindBool
+	kindInt32
+	kindInt64
+	kindFloat
+	kindDouble
+	kindTimestamp
+	kindDate
+	kindUUID
+)
+
+const (
+	int16Size = 2
+	int32Size = 4
+	uuidSize  = 16
+)
+
+type column struct {
+	name     string
+	index    int
+	kind     kind
+	optional bool
+	list     bool
+}
+
+// leaf maps a column type to the parquet one, types without a close match are stored as text.
+func leaf(tp model.CommonType, sourceType string, size int64) (parquetgo.Node, kind) {
+	switch tp { //nolint:exhaustive // the rest is text
+	case model.Integer:
+		switch size {
+		case int16Size:
+			return parquetgo.Int(16), kindInt32 //nolint:mnd // bit width
+		case int32Size:
+			return parquetgo.Int(32), kindInt32 //nolint:mnd // bit width
+		default:
+			return parquetgo.Int(64), kindInt64 //nolint:mnd // bit width
+		}
+	case model.Float:
+		switch {
+		case sourceType == "numeric":
+			// numeric precision is arbitrary, text keeps all the digits
+			return parquetgo.String(), kindString
+		case size == int32Size:
+			return parquetgo.Leaf(parquetgo.FloatType), kindFloat
+		default:
+			return parquetgo.Leaf(parquetgo.DoubleType), kindDouble
+		}
+	case model.Timestamp:
+		return parquetgo.Timestamp(parquetgo.Microsecond), kindTimestamp
+	case model.Date:
+		return parquetgo.Date(), kindDate
+	case model.UUID:
+		return parquetgo.UUID(), kindUUID
+	}
+
+	switch sourceType {
+	case "bool":
+		return parquetgo.Leaf(parquetgo.BooleanType), kindBool
+	case "bytea":
+		return parquetgo.Leaf(parquetgo.ByteArrayType), kindBytes
+	default:
+		return parquetgo.String(), kindString
+	}
+}
+
+func buildSchema(dataset model.DatasetSchema) (*parquetgo.Schema, []column) {
+	group := make(parquetgo.Group, len(dataset.Columns))
+	columns := make([]column, len(dataset.Columns))
+
+	for i, tt := range dataset.Columns {
+		col := column{
+			name:     tt.SourceName.AsArgument(),
+			index:    0,
+			kind:     kindString,
+			optional: tt.IsNullable,
+			list:     tt.Type == model.Array,
+		}
+
+		var node parquetgo.Node
+		if col.list {
+			var elem parquetgo.Node
+			elem, col.kind = leaf(tt.ArrayElem.ElemType, tt.ArrayElem.SourceType, tt.ArrayElem.ElemSize)
+			node = parquetgo.List(parquetgo.Optional(elem))
+		} else {
+			node, col.kind = leaf(tt.Type, tt.SourceType, int64(tt.FixedSize))
+		}
+		if col.optional {
+			node = parquetgo.Optional(node)
+		}
+
+		group[col.name] = node
+		columns[i] = col
+	}
+
+	schema := parquetgo.NewSchema(dataset.TableName.String(), group)
+	for i := range columns {
+		path := []string{columns[i].name}
+		if columns[i].list {
+			path = append(path, "list", "element")
+		}
+
+		leafColumn, _ := schema.Lookup(path...)
+		columns[i].index = leafColumn.ColumnIndex
+	}
+
+	return schema, columns
+}
+
+// values returns the leveled parquet values of one cell, see the dremel encoding.
+func (c column) values(cell any) ([]parquetgo.Value, error) {
+	const fnName = "values"
+
+	if !c.list {
+		if cell == nil {
+			if !c.optional {
+				return nil, fmt.Errorf("%w: null in not null column %s %s", ErrUnexpectedValue, c.name, fnName)
+			}
+
+			return []parquetgo.Value{parquetgo.NullValue().Level(0, 0, c.index)}, nil
+		}
+
+		val, err := convert(c.kind, cell)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s", err, c.name, fnName)
+		}
+
+		return []parquetgo.Value{val.Level(0, c.definitionBase(), c.index)}, nil
+	}
+
+	if cell == nil {
+		if !c.optional {
+			return nil, fmt.Errorf("%w: null in not null column %s %s", ErrUnexpectedValue, c.name, fnName)
+		}
+
+		return []parquetgo.Value{parquetgo.NullValue().Level(0, 0, c.index)}, nil
+	}
+
+	// multidimensional arrays are flattened, the shape can't be known from the table schema
+	elems := flatten(reflect.ValueOf(cell), nil)
+	if len(elems) == 0 {
+		return []parquetgo.Value{parquetgo.NullValue().Level(0, c.definitionBase(), c.index)}, nil
+	}
+
+	out := make([]parquetgo.Value, len(elems))
+	for i, elem := range elems {
+		repetition := 1
+		if i == 0 {
+			repetition = 0
+		}
+
+		if elem == nil {
+			out[i] = parquetgo.NullValue().Level(repetition, c.definitionBase()+1, c.index)
+
+			continue
+		}
+
+		val, err := convert(c.kind, elem)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s", err, c.name, fnName)
+		}
+		out[i] = val.Level(repetition, c.definitionBase()+2, c.index) //nolint:mnd // repeated and optional element
+	}
+
+	return out, nil
+}
+
+func (c column) definitionBase() int {
+	if c.optional {
+		return 1
+	}
+
+	return 0
+}
+
+func flatten(rv reflect.Value, out []any) []any {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return append(out, rv.Interface())
+	}
+
+	for i := range rv.Len() {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				out = append(out, nil)
+
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		if _, isBytes := elem.Interface().([]byte); isBytes || isUUID(elem) {
+			out = append(out, elem.Interface())
+
+			continue
+		}
+
+		out = flatten(elem, out)
+	}
+
+	return out
+}
+
+func isUUID(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Array && rv.Len() == uuidSize && rv.Type().Elem().Kind() == reflect.Uint8
+}
+
+//nolint:cyclop // a case per kind
+func convert(k kind, v any) (parquetgo.Value, error) {
+	const fnName = "convert"
+
+	switch k {
+	case kindInt32, kindInt64:
+		rv := reflect.ValueOf(v)
+
+		var i int64
+		switch {
+		case rv.CanInt():
+			i = rv.Int()
+		case rv.CanUint() && rv.Uint() <= math.MaxInt64:
+			i = int64(rv.Uint())
+		default:
+			return parquetgo.Value{}, fmt.Errorf("%w: %T %s", ErrUnexpectedValue, v, fnName)
+		}
+
+		if k == kindInt32 {
+			return parquetgo.Int32Value(int32(i)), nil
+		}
+
+		return parquetgo.Int64Value(i), nil
+	case kindFloat, kindDouble:
+		rv := reflect.ValueOf(v)
+
+		var f float64
+		switch {
+		case rv.CanFloat():
+			f = rv.Float()
+		case rv.CanInt():
+			f = float64(rv.Int())
+		default:
+			return parquetgo.Value{}, fmt.Errorf("%w: %T %s", ErrUnexpectedValue, v, fnName)
+		}
+
+		if k == kindFloat {
+			return parquetgo.FloatValue(float32(f)), nil
+		}
+
+		return parquetgo.DoubleValue(f), nil
+	case kindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return parquetgo.Value{}, fmt.Errorf("%w: %T %s", ErrUnexpectedValue, v, fnName)
+		}
+
+		return parquetgo.BooleanValue(b), nil
+	case kindTimestamp, kindDate:
+		t, ok := v.(time.Time)
+		if !ok {
+			return parquetgo.Value{}, fmt.Errorf("%w: %T %s", ErrUnexpectedValue, v, fnName)
+		}
+
+		if k == kindDate {
+			day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+			return parquetgo.Int32Value(int32(day.Unix() / int64((24 * time.Hour).Seconds()))), nil
+		}
+
+		return parquetgo.Int64Value(t.UnixMicro()), nil
+	case kindUUID:
+		return uuidValue(v)
+	case kindBytes:
+		if b, ok := v.([]byte); ok {
+			return parquetgo.ByteArrayValue(b), nil
+		}
+	case kindString:
+	}
+
+	text, err := encode.Text(v)
+	if err != nil {
+		return parquetgo.Value{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return parquetgo.ByteArrayValue([]byte(text)), nil
+}
+
+func uuidValue(v any) (parquetgo.Value, error) {
+	const fnName = "uuid value"
+
+	if rv := reflect.ValueOf(v); isUUID(rv) {
+		b := make([]byte, uuidSize)
+		reflect.Copy(reflect.ValueOf(b), rv)
+
+		return parquetgo.FixedLenByteArrayValue(b), nil
+	}
+
+	text, err := encode.Text(v)
+	if err != nil {
+		return parquetgo.Value{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	id, err := gouuid.FromString(text)
+	if err != nil {
+		return parquetgo.Value{}, fmt.Errorf("%w: %w %s", ErrUnexpectedValue, err, fnName)
+	}
+
+	return parquetgo.FixedLenByteArrayValue(id.Bytes()), nil
+}
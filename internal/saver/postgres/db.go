@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/saver/common"
@@ -17,18 +19,65 @@ const copyThresholdRowSize = 10
 
 type DB struct {
 	pool *pgxpool.Pool
+
+	// types are composite types every connection registers, records can't be copied without them
+	typesMu sync.Mutex
+	types   []string
 }
 
 func New(ctx context.Context, connStr string) (*DB, error) {
-	pool, err := pgxpool.New(ctx, connStr)
+	cfg, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new", err)
+	}
+
+	d := &DB{pool: nil, typesMu: sync.Mutex{}, types: nil}
+	cfg.AfterConnect = d.registerTypes
+
+	d.pool, err = pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%w: new", err)
 	}
 
-	return &DB{pool: pool}, nil
+	return d, nil
+}
+
+func (d *DB) registerTypes(ctx context.Context, conn *pgx.Conn) error {
+	d.typesMu.Lock()
+	names := slices.Clone(d.types)
+	d.typesMu.Unlock()
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	if _, err := conn.LoadTypes(ctx, names); err != nil {
+		return fmt.Errorf("%w: register types", err)
+	}
+
+	return nil
+}
+
+// addTypes remembers composite types of the dataset, connections opened without them are reset.
+func (d *DB) addTypes(schema model.DatasetSchema) {
+	d.typesMu.Lock()
+	added := false
+	for _, col := range schema.Columns {
+		if col.Type == model.Composite && !slices.Contains(d.types, col.SourceType) {
+			d.types = append(d.types, col.SourceType)
+			added = true
+		}
+	}
+	d.typesMu.Unlock()
+
+	if added {
+		d.pool.Reset()
+	}
 }
 
 func (d *DB) PrepareHints(ctx context.Context, schema model.DatasetSchema) *model.SavingHints {
+	d.addTypes(schema)
+
 	tableName := pgx.Identifier{schema.TableName.Schema.AsArgument(), schema.TableName.Table.AsArgument()}
 	columns := lo.Map(schema.Columns, func(ct model.TargetType, _ int) string {
 		return ct.SourceName.AsArgument()
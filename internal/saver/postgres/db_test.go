@@ -71,7 +71,7 @@ func Test_DbSaveNoErrors(t *testing.T) {
 
 	data := make([][]any, 23)
 	for i := range data {
-		data[i] = []any{i, xrand.LowerCaseString(10)}
+		data[i] = []any{i, xrand.LowerCaseString(xrand.Unseeded(), 10)}
 	}
 
 	schema := model.DatasetSchema{
@@ -291,7 +291,7 @@ func Test_StringOfString(t *testing.T) {
 		}
 		for i := range str {
 			for j := range str[i] {
-				str[i][j] = xrand.LowerCaseString(10)
+				str[i][j] = xrand.LowerCaseString(xrand.Unseeded(), 10)
 			}
 		}
 		return str
@@ -9,7 +9,8 @@ import (
 func IsConstraintViolatesErr(err error) bool {
 	var pgxErr *pgconn.PgError
 	if errors.As(err, &pgxErr) {
-		return pgxErr.Code == "23514" || pgxErr.Code == "23505"
+		// check, unique and exclusion violations
+		return pgxErr.Code == "23514" || pgxErr.Code == "23505" || pgxErr.Code == "23P01"
 	}
 
 	return false
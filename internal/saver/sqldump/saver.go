@@ -0,0 +1,339 @@
+package sqldump
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/encode"
+
+	"github.com/samber/lo"
+)
+
+var (
+	ErrUnknownMode       = errors.New("unknown sql dump mode")
+	ErrEmptyPath         = errors.New("sql dump path is empty")
+	ErrLargeObjectInCopy = errors.New("large object can't be written with copy")
+)
+
+type tablePart struct {
+	file    *os.File
+	buf     *bufio.Writer
+	copying bool
+}
+
+// Saver collects rows of every table in its own part file and glues the parts
+// into a single script on close, once the order of tables is known.
+type Saver struct {
+	path    string
+	partDir string
+	insert  bool
+
+	mu    sync.Mutex
+	order []model.TableName
+	parts map[model.TableName]*tablePart
+}
+
+func New(cfg config.SQLOutput) (*Saver, error) {
+	const fnName = "sqldump: new"
+
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyPath, fnName)
+	}
+
+	var insert bool
+	switch cfg.Mode {
+	case "", config.SQLDumpCopy:
+		insert = false
+	case config.SQLDumpInsert:
+		insert = true
+	default:
+		return nil, fmt.Errorf("%w: %q %s", ErrUnknownMode, cfg.Mode, fnName)
+	}
+
+	partDir, err := os.MkdirTemp(filepath.Dir(cfg.Path), ".datagen-dump-*")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return &Saver{
+		path:    cfg.Path,
+		partDir: partDir,
+		insert:  insert,
+		mu:      sync.Mutex{},
+		order:   nil,
+		parts:   make(map[model.TableName]*tablePart),
+	}, nil
+}
+
+// SetTableOrder sets the order of tables in the script, referenced tables have to go first.
+func (s *Saver) SetTableOrder(order []model.TableName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order = order
+}
+
+func (s *Saver) PrepareHints(_ context.Context, _ model.DatasetSchema) *model.SavingHints {
+	return model.NewSavingHints()
+}
+
+func (s *Saver) Save(_ context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	const fnName = "sqldump: save"
+
+	part, err := s.part(batch)
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if part.copying {
+		err = writeCopyRows(part.buf, batch.Data)
+	} else {
+		err = writeInsert(part.buf, batch)
+	}
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := part.buf.Flush(); err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.SavedBatch{
+		Stat: model.SaveReport{
+			RowsSaved:           len(batch.Data),
+			ConstraintViolation: 0,
+		},
+		Batch: batch,
+	}, nil
+}
+
+func (s *Saver) part(batch model.SaveBatch) (*tablePart, error) {
+	const fnName = "part"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table := batch.Schema.TableName
+	if part, ok := s.parts[table]; ok {
+		return part, nil
+	}
+
+	f, err := os.Create(filepath.Join(s.partDir, table.String()+".sql"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// COPY can't call lo_from_bytea, so such tables are written as inserts
+	part := &tablePart{
+		file:    f,
+		buf:     bufio.NewWriter(f),
+		copying: !s.insert && !hasLargeObjects(batch.Schema),
+	}
+	if part.copying {
+		_, _ = fmt.Fprintf(part.buf, "COPY %s (%s) FROM stdin;\n", table.Quoted(), columnList(batch.Schema))
+	}
+	s.parts[table] = part
+
+	return part, nil
+}
+
+func hasLargeObjects(schema model.DatasetSchema) bool {
+	return slices.ContainsFunc(schema.Columns, func(ct model.TargetType) bool {
+		return ct.SourceType == "oid"
+	})
+}
+
+func columnList(schema model.DatasetSchema) string {
+	return strings.Join(lo.Map(schema.Columns, func(ct model.TargetType, _ int) string {
+		return ct.SourceName.Quoted()
+	}), ", ")
+}
+
+// Close writes the script: tables in the order set by SetTableOrder, the rest by name.
+func (s *Saver) Close(_ context.Context) error {
+	const fnName = "sqldump: close"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	defer os.RemoveAll(s.partDir)
+
+	// every part is closed, a failed one doesn't leave the others open
+	errs := make([]error, 0, len(s.parts))
+	for table, part := range s.parts {
+		var endErr error
+		if part.copying {
+			_, endErr = part.buf.WriteString("\\.\n")
+		}
+
+		if err := errors.Join(endErr, part.buf.Flush(), part.file.Close()); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s", err, table.String()))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := s.assemble(); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func (s *Saver) assemble() error {
+	const fnName = "assemble"
+
+	tables := lo.Filter(s.order, func(t model.TableName, _ int) bool {
+		_, ok := s.parts[t]
+
+		return ok
+	})
+	rest := lo.Filter(lo.Keys(s.parts), func(t model.TableName, _ int) bool {
+		return !slices.Contains(tables, t)
+	})
+	slices.SortFunc(rest, func(a, b model.TableName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	tables = append(tables, rest...)
+
+	out, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer os.Remove(out.Name())
+
+	w := bufio.NewWriter(out)
+	_, _ = w.WriteString("-- generated by datagen\n\nBEGIN;\n")
+	for _, table := range tables {
+		if err := appendPart(w, s.parts[table].file.Name()); err != nil {
+			_ = out.Close()
+
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+	_, _ = w.WriteString("\nCOMMIT;\n")
+
+	if err := errors.Join(w.Flush(), out.Close()); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := os.Rename(out.Name(), s.path); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func appendPart(w *bufio.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: append part", err)
+	}
+	defer f.Close()
+
+	_ = w.WriteByte('\n')
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("%w: append part", err)
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func writeCopyRows(w *bufio.Writer, data [][]any) error {
+	for _, row := range data {
+		for i, value := range row {
+			if i > 0 {
+				_ = w.WriteByte('\t')
+			}
+
+			if value == nil {
+				_, _ = w.WriteString(`\N`)
+
+				continue
+			}
+			if _, ok := value.(model.LargeObject); ok {
+				return fmt.Errorf("%w: write copy rows", ErrLargeObjectInCopy)
+			}
+
+			text, err := encode.Text(value)
+			if err != nil {
+				return fmt.Errorf("%w: write copy rows", err)
+			}
+			_, _ = copyEscaper.WriteString(w, text)
+		}
+		_ = w.WriteByte('\n')
+	}
+
+	return nil
+}
+
+func writeInsert(w *bufio.Writer, batch model.SaveBatch) error {
+	if len(batch.Data) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "INSERT INTO %s (%s) VALUES\n", batch.Schema.TableName.Quoted(), columnList(batch.Schema))
+	for i, row := range batch.Data {
+		_, _ = w.WriteString("(")
+		for j, value := range row {
+			if j > 0 {
+				_, _ = w.WriteString(", ")
+			}
+
+			literal, err := sqlLiteral(value)
+			if err != nil {
+				return fmt.Errorf("%w: write insert", err)
+			}
+			_, _ = w.WriteString(literal)
+		}
+
+		if i < len(batch.Data)-1 {
+			_, _ = w.WriteString("),\n")
+		} else {
+			_, _ = w.WriteString(");\n")
+		}
+	}
+
+	return nil
+}
+
+// sqlLiteral quotes every value as a string, postgres casts it to the column type.
+func sqlLiteral(value any) (string, error) {
+	switch val := value.(type) {
+	case nil:
+		return "NULL", nil
+	case model.LargeObject:
+		text, err := encode.Text(val.Data)
+		if err != nil {
+			return "", fmt.Errorf("%w: sql literal", err)
+		}
+
+		return "lo_from_bytea(0, " + quote(text) + ")", nil
+	default:
+		text, err := encode.Text(val)
+		if err != nil {
+			return "", fmt.Errorf("%w: sql literal", err)
+		}
+
+		return quote(text), nil
+	}
+}
+
+func quote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
@@ -0,0 +1,122 @@
+package sqldump_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/sqldump"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tableName(name string) model.TableName {
+	return model.TableName{
+		Schema: model.PGIdentifier("public"),
+		Table:  model.PGIdentifier(name),
+	}
+}
+
+func batch(table string, columns map[string]string, order []string, data [][]any) model.SaveBatch {
+	schema := model.DatasetSchema{
+		TableName:         tableName(table),
+		Columns:           make([]model.TargetType, 0, len(order)),
+		UniqueConstraints: nil,
+	}
+	for _, name := range order {
+		//nolint:exhaustruct // only names and types matter
+		schema.Columns = append(schema.Columns, model.TargetType{
+			SourceName: model.PGIdentifier(name),
+			SourceType: columns[name],
+		})
+	}
+
+	return model.SaveBatch{
+		Schema:      schema,
+		Data:        data,
+		SavingHints: model.NewSavingHints(),
+		Invalid:     make([]bool, len(data)),
+	}
+}
+
+func Test_CopyInTableOrder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	saver, err := sqldump.New(config.SQLOutput{Path: path, Mode: config.SQLDumpCopy})
+	require.NoError(t, err)
+
+	saver.SetTableOrder([]model.TableName{tableName("users"), tableName("orders")})
+
+	types := map[string]string{"id": "int4", "note": "text"}
+	_, err = saver.Save(t.Context(), batch("orders", types, []string{"id", "note"}, [][]any{
+		{int64(1), "tab\there"},
+		{int64(2), nil},
+	}))
+	require.NoError(t, err)
+	_, err = saver.Save(t.Context(), batch("users", types, []string{"id", "note"}, [][]any{
+		{int64(7), `back\slash`},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, saver.Close(t.Context()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `-- generated by datagen
+
+BEGIN;
+
+COPY "public"."users" ("id", "note") FROM stdin;
+7	back\\slash
+\.
+
+COPY "public"."orders" ("id", "note") FROM stdin;
+1	tab\there
+2	\N
+\.
+
+COMMIT;
+`, string(data))
+}
+
+func Test_InsertWithLargeObjects(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	saver, err := sqldump.New(config.SQLOutput{Path: path, Mode: config.SQLDumpCopy})
+	require.NoError(t, err)
+
+	types := map[string]string{"id": "int4", "note": "text", "content": "oid"}
+	_, err = saver.Save(t.Context(), batch("files", types, []string{"id", "note", "content"}, [][]any{
+		{int64(1), "it's", model.LargeObject{Data: []byte{0xde, 0xad}}},
+		{int64(2), nil, nil},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, saver.Close(t.Context()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `-- generated by datagen
+
+BEGIN;
+
+INSERT INTO "public"."files" ("id", "note", "content") VALUES
+('1', 'it''s', lo_from_bytea(0, '\xdead')),
+('2', NULL, NULL);
+
+COMMIT;
+`, string(data))
+
+	parts, err := filepath.Glob(filepath.Join(filepath.Dir(path), ".datagen-dump-*"))
+	require.NoError(t, err)
+	require.Empty(t, parts)
+}
+
+func Test_UnknownMode(t *testing.T) {
+	t.Parallel()
+
+	_, err := sqldump.New(config.SQLOutput{Path: filepath.Join(t.TempDir(), "dump.sql"), Mode: "merge"})
+	require.ErrorIs(t, err, sqldump.ErrUnknownMode)
+}
@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/saver/common"
+
+	"github.com/samber/lo"
+	_ "modernc.org/sqlite" // register driver
+)
+
+const insertThresholdRowSize = 10
+
+type DB struct {
+	db *sql.DB
+}
+
+func New(dsn string) (*DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new", err)
+	}
+	// sqlite has a single writer, more connections only wait for each other's locks
+	db.SetMaxOpenConns(1)
+
+	return &DB{db: db}, nil
+}
+
+func (d *DB) PrepareHints(_ context.Context, schema model.DatasetSchema) *model.SavingHints {
+	hints := model.NewSavingHints()
+	hints.AddString("insert_query_hint", insertQuery(schema))
+
+	return hints
+}
+
+func (d *DB) Save(ctx context.Context, batch model.SaveBatch) (model.SavedBatch, error) {
+	report := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	insQuery, err := batch.SavingHints.GetString("insert_query_hint")
+	if err != nil {
+		return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+	}
+
+	parts := []common.DataPartitionerMut{common.NewDataPartionerMut(batch.Data)}
+	for len(parts) > 0 {
+		curPart := parts[0]
+		parts = parts[1:]
+
+		if curPart.Len() < insertThresholdRowSize {
+			saved, err := d.insert(ctx, insQuery, batch, curPart)
+			if err != nil {
+				return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+			}
+			report = report.Add(saved)
+
+			continue
+		}
+
+		saved, err := d.txInsert(ctx, insQuery, curPart.Data())
+		switch {
+		case err == nil:
+			report = report.Add(saved)
+		case IsConstraintViolatesErr(err):
+			before, after := curPart.Split()
+			parts = append(parts, before, after)
+		default:
+			return model.SavedBatch{}, fmt.Errorf("%w: save", err)
+		}
+	}
+
+	return model.SavedBatch{
+		Stat:  report,
+		Batch: batch,
+	}, nil
+}
+
+// txInsert saves rows within a single transaction, it either saves all of them or none.
+func (d *DB) txInsert(ctx context.Context, query string, data [][]any) (model.SaveReport, error) {
+	const fnName = "tx insert"
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := execRows(ctx, tx, query, data); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.SaveReport{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return model.SaveReport{
+		ConstraintViolation: 0,
+		RowsSaved:           len(data),
+	}, nil
+}
+
+func execRows(ctx context.Context, tx *sql.Tx, query string, data [][]any) error {
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%w: prepare", err)
+	}
+	defer stmt.Close()
+
+	for _, row := range data {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("%w: exec", err)
+		}
+	}
+
+	return nil
+}
+
+func insertQuery(schema model.DatasetSchema) string {
+	columns := lo.Map(schema.Columns, func(ct model.TargetType, _ int) string {
+		return ct.SourceName.Quoted()
+	})
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		schema.TableName.Quoted(), strings.Join(columns, ","),
+		strings.TrimSuffix(strings.Repeat("?,", len(columns)), ","),
+	)
+}
+
+func (d *DB) insert(
+	ctx context.Context,
+	query string,
+	batch model.SaveBatch,
+	partioner common.DataPartitionerMut,
+) (model.SaveReport, error) {
+	collected := model.SaveReport{
+		RowsSaved:           0,
+		ConstraintViolation: 0,
+	}
+
+	data := partioner.Data()
+	for i, row := range data {
+		_, err := d.db.ExecContext(ctx, query, row...)
+		if err != nil {
+			if IsConstraintViolatesErr(err) {
+				collected.ConstraintViolation++
+				batch.MakeInvalid(partioner.RealIndex(i))
+
+				continue
+			}
+
+			return model.SaveReport{}, fmt.Errorf("%w: insert", err)
+		}
+
+		collected.RowsSaved++
+	}
+
+	return collected, nil
+}
+
+func (d *DB) Close(_ context.Context) error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("%w: sqlite: close", err)
+	}
+
+	return nil
+}
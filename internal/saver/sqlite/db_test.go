@@ -0,0 +1,147 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	testsqlite "github.com/jmozgit/datagen/internal/pkg/testconn/sqlite"
+	"github.com/jmozgit/datagen/internal/saver/sqlite"
+
+	"github.com/stretchr/testify/require"
+)
+
+type saveSetup struct {
+	testConn *testsqlite.Conn
+	connect  *sqlite.DB
+	table    model.TableName
+}
+
+func newSaveSetup(t *testing.T, opts ...options.CreateTableOption) *saveSetup {
+	t.Helper()
+
+	tmpConn, err := testsqlite.New(t)
+	require.NoError(t, err)
+
+	table := model.TableName{
+		Schema: model.SQLiteIdentifier("main"),
+		Table:  model.SQLiteIdentifier("test_with_pk"),
+	}
+	err = tmpConn.CreateTable(t.Context(), model.Table{
+		Name: table,
+		Columns: []model.Column{
+			{Name: model.SQLiteIdentifier("id"), Type: "int", IsNullable: false, FixedSize: 4},
+		},
+	}, opts...)
+	require.NoError(t, err)
+
+	connect, err := sqlite.New(tmpConn.SQLiteDatabase().DSN())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, connect.Close(t.Context())) })
+
+	return &saveSetup{
+		testConn: tmpConn,
+		connect:  connect,
+		table:    table,
+	}
+}
+
+func (s *saveSetup) save(t *testing.T, data [][]any) (model.SaveReport, int) {
+	t.Helper()
+
+	schema := model.DatasetSchema{
+		TableName: s.table,
+		Columns: []model.TargetType{
+			//nolint:exhaustruct // ok for tests
+			{SourceName: model.SQLiteIdentifier("id"), SourceType: "int"},
+		},
+		UniqueConstraints: nil,
+	}
+	batch := model.SaveBatch{
+		SavingHints: s.connect.PrepareHints(t.Context(), schema),
+		Schema:      schema,
+		Data:        data,
+		Invalid:     make([]bool, len(data)),
+	}
+
+	saved, err := s.connect.Save(t.Context(), batch)
+	require.NoError(t, err)
+
+	cntInvalid := 0
+	for _, d := range batch.Invalid {
+		if d {
+			cntInvalid++
+		}
+	}
+
+	return saved.Stat, cntInvalid
+}
+
+func Test_DbSaveNoErrors(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t)
+
+	data := make([][]any, 23)
+	for i := range data {
+		data[i] = []any{i}
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 0, RowsSaved: len(data)}, report)
+	require.Equal(t, 0, invalid)
+}
+
+func Test_DbSaveManyDuplicates(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t, options.WithPKs([]string{"id"}))
+
+	data := make([][]any, 0, 26)
+	for i := range cap(data) / 2 {
+		data = append(data, []any{i}, []any{i})
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 13, RowsSaved: 13}, report)
+	require.Equal(t, 13, invalid)
+}
+
+func Test_OnlyOneUniqueRow(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t, options.WithPKs([]string{"id"}))
+
+	data := make([][]any, 0, 26)
+	for range cap(data) {
+		data = append(data, []any{10})
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 25, RowsSaved: 1}, report)
+	require.Equal(t, 25, invalid)
+}
+
+func Test_ForeignKeyViolations(t *testing.T) {
+	t.Parallel()
+
+	setup := newSaveSetup(t, options.WithForeignKey("foreign key (id) references parent(id)"))
+	err := setup.testConn.CreateTable(t.Context(), model.Table{
+		Name: model.TableName{Schema: model.SQLiteIdentifier("main"), Table: model.SQLiteIdentifier("parent")},
+		Columns: []model.Column{
+			{Name: model.SQLiteIdentifier("id"), Type: "integer primary key", IsNullable: false, FixedSize: 8},
+		},
+	})
+	require.NoError(t, err)
+	_, err = setup.testConn.Raw().ExecContext(t.Context(), "INSERT INTO parent VALUES (1), (2), (3)")
+	require.NoError(t, err)
+
+	data := make([][]any, 0, 30)
+	for i := range cap(data) {
+		data = append(data, []any{i%10 + 1})
+	}
+
+	report, invalid := setup.save(t, data)
+	require.Equal(t, model.SaveReport{ConstraintViolation: 21, RowsSaved: 9}, report)
+	require.Equal(t, 21, invalid)
+}
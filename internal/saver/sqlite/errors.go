@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"errors"
+
+	"modernc.org/sqlite"
+)
+
+// extended result codes, named after SQLITE_CONSTRAINT_*.
+const (
+	errConstraintCheck      = 275
+	errConstraintForeignKey = 787
+	errConstraintPrimaryKey = 1555
+	errConstraintUnique     = 2067
+)
+
+func IsConstraintViolatesErr(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case errConstraintCheck, errConstraintForeignKey, errConstraintPrimaryKey, errConstraintUnique:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+package scaffold
+
+import (
+	"math"
+
+	"github.com/jmozgit/datagen/internal/acceptor/check"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// textLenFrom and textLenTo are the lengths of texts without a declared one.
+	textLenFrom = 20
+	textLenTo   = 100
+)
+
+// Column is the generator stanza of a column along with why it looks like that.
+type Column struct {
+	Generator config.Generator
+	Note      string
+}
+
+// Columns describe generators datagen picks by itself for the columns of the table, as settings
+// which can be tweaked. Columns it fills from sequences, references and the database are only named.
+func Columns(dataset model.DatasetSchema, details []model.ColumnDetails) []Column {
+	byColumn := lo.KeyBy(details, func(d model.ColumnDetails) model.Identifier { return d.Column })
+
+	return lo.Map(dataset.Columns, func(col model.TargetType, _ int) Column {
+		return column(col, byColumn[col.SourceName])
+	})
+}
+
+func column(col model.TargetType, details model.ColumnDetails) Column {
+	//nolint:exhaustruct // settings are set below
+	gen := config.Generator{Column: col.SourceName.AsArgument()}
+
+	switch {
+	case col.Generated:
+		return Column{Generator: gen, Note: "generated by the database, left out of inserts"}
+	case details.References != nil:
+		return Column{Generator: gen, Note: "references " + details.References.String() + ", takes values saved there"}
+	case details.Sequence:
+		gen.Type = config.GeneratorTypeDefault
+
+		return Column{Generator: gen, Note: "takes values of its sequence"}
+	case len(details.EnumValues) > 0:
+		gen.Type, gen.ListProbability = config.GeneratorTypeProbabilityList, list(details.EnumValues)
+
+		return Column{Generator: gen, Note: "enum " + col.SourceType}
+	}
+
+	if values, ok := checkedValues(col); ok {
+		gen.Type, gen.ListProbability = config.GeneratorTypeProbabilityList, values
+
+		return Column{Generator: gen, Note: "values allowed by the check"}
+	}
+
+	note := col.SourceType
+	if col.IsNullable {
+		note += ", nullable"
+	}
+
+	switch col.Type { //nolint:exhaustive // the rest is generated by its type
+	case model.Integer:
+		gen.Type, gen.Integer = config.GeneratorTypeInteger, integer(col)
+	case model.Float:
+		gen.Type = config.GeneratorTypeFloat
+	case model.Timestamp, model.Date:
+		gen.Type = config.GeneratorTypeTimestamp
+	case model.UUID:
+		gen.Type, gen.UUID = config.GeneratorTypeUUID, &config.UUID{Version: lo.ToPtr("v4")}
+	case model.Text:
+		gen.Type, gen.Text = config.GeneratorTypeText, text(col, details.MaxLength)
+	default:
+		note = "generated by its type " + note
+	}
+
+	return Column{Generator: gen, Note: note}
+}
+
+func checkedValues(col model.TargetType) (*config.ListProbability, bool) {
+	switch col.Type { //nolint:exhaustive // checks of other types aren't listed
+	case model.Integer:
+		return checkedList(col.Check, check.ParseInt)
+	case model.Float:
+		return checkedList(col.Check, check.ParseFloat)
+	case model.Text:
+		return checkedList(col.Check, check.ParseText)
+	default:
+		return nil, false
+	}
+}
+
+func checkedList[T any](c *model.ColumnCheck, parse func(string) (T, error)) (*config.ListProbability, bool) {
+	values, ok, err := check.Values(c, parse)
+	if err != nil || !ok {
+		return nil, false
+	}
+
+	return list(values), true
+}
+
+// list picks values equally often.
+func list[T any](values []T) *config.ListProbability {
+	return &config.ListProbability{
+		Values:       lo.ToAnySlice(values),
+		Distribution: lo.Map(values, func(T, int) int { return 1 }),
+	}
+}
+
+// integer bounds values by the size of the column and its check.
+func integer(col model.TargetType) *config.Integer {
+	const (
+		int8Size  = 1
+		int16Size = 2
+		int64Size = 8
+	)
+
+	var minV, maxV int64 = math.MinInt32, math.MaxInt32
+	switch col.FixedSize {
+	case int8Size:
+		minV, maxV = math.MinInt8, math.MaxInt8
+	case int16Size:
+		minV, maxV = math.MinInt16, math.MaxInt16
+	case int64Size:
+		minV, maxV = math.MinInt64, math.MaxInt64
+	}
+
+	if checkedMin, checkedMax, err := check.IntRange(col.Check, minV, maxV); err == nil {
+		minV, maxV = checkedMin, checkedMax
+	}
+
+	//nolint:exhaustruct // random values in range
+	return &config.Integer{MinValue: &minV, MaxValue: &maxV}
+}
+
+// text keeps values within declared lengths, values of bpchar are as long as the column.
+func text(col model.TargetType, maxLength int) *config.Text {
+	from, to := textLenFrom, textLenTo
+	switch {
+	case maxLength > 0 && col.SourceType == "bpchar":
+		from, to = maxLength, maxLength
+	case maxLength > 0:
+		from, to = min(from, maxLength), min(to, maxLength)
+	}
+
+	if checkedFrom, checkedTo, err := check.LengthRange(col.Check, from, to); err == nil {
+		from, to = checkedFrom, min(checkedTo, to)
+	}
+
+	return &config.Text{CharLenFrom: from, CharLenTo: to}
+}
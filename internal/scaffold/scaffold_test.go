@@ -0,0 +1,128 @@
+package scaffold_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/scaffold"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func column(name string, tp model.CommonType, sourceType string) model.TargetType {
+	//nolint:exhaustruct // ok for test
+	return model.TargetType{
+		SourceName: model.PGIdentifier(name),
+		Type:       tp,
+		SourceType: sourceType,
+	}
+}
+
+//nolint:funlen // a table of cases
+func Test_Columns(t *testing.T) {
+	qty := column("qty", model.Integer, "int2")
+	qty.FixedSize = 2
+	qty.Check = &model.ColumnCheck{Min: &model.CheckBound{Value: "0", Inclusive: true}} //nolint:exhaustruct // ok
+
+	size := column("size", model.Text, "text")
+	size.Check = &model.ColumnCheck{In: []string{"s", "m"}} //nolint:exhaustruct // ok
+
+	total := column("total", model.Integer, "int4")
+	total.Generated = true
+
+	//nolint:exhaustruct // ok for test
+	dataset := model.DatasetSchema{
+		Columns: []model.TargetType{
+			column("id", model.Integer, "int4"),
+			column("customer_id", model.Integer, "int4"),
+			column("status", model.DriverSpecified, "order_status"),
+			qty,
+			size,
+			column("code", model.Text, "bpchar"),
+			column("email", model.Text, "varchar"),
+			column("tag", model.Text, "varchar"),
+			column("note", model.Text, "text"),
+			column("active", model.DriverSpecified, "bool"),
+			total,
+		},
+	}
+
+	//nolint:exhaustruct // ok for test
+	details := []model.ColumnDetails{
+		{Column: model.PGIdentifier("id"), Sequence: true},
+		{
+			Column: model.PGIdentifier("customer_id"),
+			References: &model.TableName{
+				Schema: model.PGIdentifier("public"), Table: model.PGIdentifier("customers"),
+			},
+		},
+		{Column: model.PGIdentifier("status"), EnumValues: []string{"new", "paid"}},
+		{Column: model.PGIdentifier("code"), MaxLength: 3},
+		{Column: model.PGIdentifier("email"), MaxLength: 255},
+		{Column: model.PGIdentifier("tag"), MaxLength: 10},
+	}
+
+	//nolint:exhaustruct // ok for test
+	expected := []scaffold.Column{
+		{Generator: config.Generator{Column: "id", Type: config.GeneratorTypeDefault}, Note: "takes values of its sequence"},
+		{
+			Generator: config.Generator{Column: "customer_id"},
+			Note:      "references public.customers, takes values saved there",
+		},
+		{
+			Generator: config.Generator{
+				Column:          "status",
+				Type:            config.GeneratorTypeProbabilityList,
+				ListProbability: &config.ListProbability{Values: []any{"new", "paid"}, Distribution: []int{1, 1}},
+			},
+			Note: "enum order_status",
+		},
+		{
+			Generator: config.Generator{
+				Column:  "qty",
+				Type:    config.GeneratorTypeInteger,
+				Integer: &config.Integer{MinValue: lo.ToPtr(int64(0)), MaxValue: lo.ToPtr(int64(math.MaxInt16))},
+			},
+			Note: "int2",
+		},
+		{
+			Generator: config.Generator{
+				Column:          "size",
+				Type:            config.GeneratorTypeProbabilityList,
+				ListProbability: &config.ListProbability{Values: []any{"s", "m"}, Distribution: []int{1, 1}},
+			},
+			Note: "values allowed by the check",
+		},
+		{
+			Generator: config.Generator{
+				Column: "code", Type: config.GeneratorTypeText, Text: &config.Text{CharLenFrom: 3, CharLenTo: 3},
+			},
+			Note: "bpchar",
+		},
+		{
+			Generator: config.Generator{
+				Column: "email", Type: config.GeneratorTypeText, Text: &config.Text{CharLenFrom: 20, CharLenTo: 100},
+			},
+			Note: "varchar",
+		},
+		{
+			Generator: config.Generator{
+				Column: "tag", Type: config.GeneratorTypeText, Text: &config.Text{CharLenFrom: 10, CharLenTo: 10},
+			},
+			Note: "varchar",
+		},
+		{
+			Generator: config.Generator{
+				Column: "note", Type: config.GeneratorTypeText, Text: &config.Text{CharLenFrom: 20, CharLenTo: 100},
+			},
+			Note: "text",
+		},
+		{Generator: config.Generator{Column: "active"}, Note: "generated by its type bool"},
+		{Generator: config.Generator{Column: "total"}, Note: "generated by the database, left out of inserts"},
+	}
+
+	require.Equal(t, expected, scaffold.Columns(dataset, details))
+}
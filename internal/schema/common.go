@@ -8,4 +8,6 @@ var (
 	ErrUnsupportedType       = errors.New("unsupported type")
 	ErrTooManyTablesMatched  = errors.New("too many tables matched")
 	ErrTooManyColumnsMatched = errors.New("too many tables matched")
+	ErrNotPartitioned        = errors.New("table isn't partitioned")
+	ErrUnsupportedPartitions = errors.New("unsupported partitions")
 )
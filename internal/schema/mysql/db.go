@@ -0,0 +1,313 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
+
+	_ "github.com/go-sql-driver/mysql" // register driver
+	"github.com/samber/lo"
+)
+
+type connect struct {
+	dsn string
+}
+
+func newConnect(dsn string) *connect {
+	return &connect{dsn: dsn}
+}
+
+func (c *connect) open() (*sql.DB, error) {
+	db, err := sql.Open("mysql", c.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: open", err)
+	}
+
+	return db, nil
+}
+
+func (c *connect) Table(ctx context.Context, name model.TableName) (model.Table, error) {
+	db, err := c.open()
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table", err)
+	}
+	defer db.Close()
+
+	exists, err := c.doesTableExist(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	if !exists {
+		return model.Table{}, fmt.Errorf("%w: table %s", schema.ErrEntityNotFound, name.Quoted())
+	}
+
+	columns, err := c.selectTableColumns(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	uniqueIndexes, err := c.selectUniqueConstraints(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	return model.Table{
+		Name:          name,
+		Columns:       columns,
+		UniqueIndexes: uniqueIndexes,
+		Checks:        nil,
+	}, nil
+}
+
+func (c *connect) doesTableExist(ctx context.Context, db *sql.DB, name model.TableName) (bool, error) {
+	const query = `
+		SELECT
+			EXISTS (
+				SELECT 1 FROM
+					information_schema.tables
+				WHERE
+					table_schema = ? AND table_name = ? AND table_type = 'BASE TABLE'
+			)
+	`
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument()).Scan(&exists); err != nil {
+		return false, fmt.Errorf("%w: does table exist", err)
+	}
+
+	return exists, nil
+}
+
+// fixedSizes holds byte sizes of types generators rely on, mediumint is generated as int.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var fixedSizes = map[string]int{
+	"tinyint": 1, "smallint": 2, "mediumint": 4, "int": 4, "bigint": 8,
+	"float": 4, "double": 8,
+	"date": 4, "datetime": 8, "timestamp": 8,
+}
+
+func (c *connect) selectTableColumns(ctx context.Context, db *sql.DB, name model.TableName) ([]model.Column, error) {
+	const fnName = "select table columns"
+
+	const query = `
+		SELECT
+			column_name, is_nullable, data_type,
+			extra LIKE '%VIRTUAL GENERATED%' OR extra LIKE '%STORED GENERATED%' AS is_generated,
+			column_default IS NOT NULL OR extra LIKE '%auto_increment%' AS has_default
+		FROM
+			information_schema.columns
+		WHERE
+			table_schema = ? AND table_name = ?
+		ORDER BY
+			ordinal_position
+	`
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columns := make([]model.Column, 0)
+	for rows.Next() {
+		var (
+			columnName, isNullable, dataType string
+			isGenerated, hasDefault          bool
+		)
+		if err := rows.Scan(&columnName, &isNullable, &dataType, &isGenerated, &hasDefault); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		columns = append(columns, model.Column{
+			Name:         model.MySQLIdentifier(columnName),
+			IsNullable:   isNullable == "YES",
+			Type:         dataType,
+			FixedSize:    lo.ValueOr(fixedSizes, dataType, -1),
+			ElemSizeByte: sql.NullInt64{Int64: 0, Valid: false},
+			Generated:    isGenerated,
+			HasDefault:   hasDefault,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columns, nil
+}
+
+func (c *connect) selectUniqueConstraints(
+	ctx context.Context,
+	db *sql.DB,
+	name model.TableName,
+) ([][]model.Identifier, error) {
+	const fnName = "select unique constraints"
+
+	const query = `
+		SELECT
+			index_name, column_name
+		FROM
+			information_schema.statistics
+		WHERE
+			table_schema = ? AND table_name = ? AND non_unique = 0 AND column_name IS NOT NULL
+		ORDER BY
+			index_name, seq_in_index
+	`
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	var (
+		indexes [][]model.Identifier
+		last    string
+	)
+	for rows.Next() {
+		var indexName, columnName string
+		if err := rows.Scan(&indexName, &columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if len(indexes) == 0 || indexName != last {
+			indexes = append(indexes, nil)
+			last = indexName
+		}
+		indexes[len(indexes)-1] = append(indexes[len(indexes)-1], model.MySQLIdentifier(columnName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return indexes, nil
+}
+
+// ResolveTableNames looks the table up in the given database, the current one if it's empty.
+func (c *connect) ResolveTableNames(ctx context.Context, name, database string) ([]model.TableName, error) {
+	const fnName = "resolve table names"
+	const query = `
+		SELECT
+			table_schema, table_name
+		FROM
+			information_schema.tables
+		WHERE
+			table_name = ? AND table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND table_type = 'BASE TABLE'
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, name, database)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.MySQLIdentifier(tableSchema),
+			Table:  model.MySQLIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+// SchemaTables lists base tables of the database, the current one if it's empty.
+func (c *connect) SchemaTables(ctx context.Context, database string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+	const query = `
+		SELECT
+			table_schema, table_name
+		FROM
+			information_schema.tables
+		WHERE
+			table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND table_type = 'BASE TABLE'
+		ORDER BY
+			table_name
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, database)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.MySQLIdentifier(tableSchema),
+			Table:  model.MySQLIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+func (c *connect) ResolveColumnNames(ctx context.Context, name model.TableName, column string) ([]model.Identifier, error) {
+	const fnName = "resolve column names"
+	const query = "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?"
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument(), column)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columns := make([]model.Identifier, 0)
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		columns = append(columns, model.MySQLIdentifier(columnName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,355 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
+
+	_ "github.com/sijms/go-ora/v2" // register driver
+)
+
+type connect struct {
+	dsn string
+}
+
+func newConnect(dsn string) *connect {
+	return &connect{dsn: dsn}
+}
+
+func (c *connect) open() (*sql.DB, error) {
+	db, err := sql.Open("oracle", c.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: open", err)
+	}
+
+	return db, nil
+}
+
+func (c *connect) Table(ctx context.Context, name model.TableName) (model.Table, error) {
+	db, err := c.open()
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table", err)
+	}
+	defer db.Close()
+
+	exists, err := c.doesTableExist(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	if !exists {
+		return model.Table{}, fmt.Errorf("%w: table %s", schema.ErrEntityNotFound, name.Quoted())
+	}
+
+	columns, err := c.selectTableColumns(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	uniqueIndexes, err := c.selectUniqueConstraints(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	return model.Table{
+		Name:          name,
+		Columns:       columns,
+		UniqueIndexes: uniqueIndexes,
+		Checks:        nil,
+	}, nil
+}
+
+func (c *connect) doesTableExist(ctx context.Context, db *sql.DB, name model.TableName) (bool, error) {
+	const query = `SELECT COUNT(*) FROM all_tables WHERE owner = :1 AND table_name = :2`
+
+	var cnt int
+	if err := db.QueryRowContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument()).Scan(&cnt); err != nil {
+		return false, fmt.Errorf("%w: does table exist", err)
+	}
+
+	return cnt > 0, nil
+}
+
+// fixedSizes holds byte sizes of types generators rely on,
+// integer numbers are generated as int64 unless their precision is known.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var fixedSizes = map[string]int{
+	"binary_float": 4, "binary_double": 8,
+	"date": 7, "timestamp": 8, "timestamp with time zone": 8, "timestamp with local time zone": 8,
+}
+
+var precisionModifier = regexp.MustCompile(`\(\d+\)`)
+
+// BaseType lowercases a data type and strips its precision, TIMESTAMP(6) WITH TIME ZONE becomes
+// timestamp with time zone.
+func BaseType(dataType string) string {
+	return strings.ToLower(precisionModifier.ReplaceAllString(dataType, ""))
+}
+
+// IsIntegerNumber tells whether a NUMBER column can hold integers only, INTEGER is NUMBER(*,0).
+func IsIntegerNumber(baseType string, scale sql.NullInt64) bool {
+	return baseType == "number" && scale.Valid && scale.Int64 == 0
+}
+
+// selectTableColumns skips columns the database fills itself, they can't be inserted.
+// all_tab_cols is all_tab_columns with hidden and virtual columns flagged.
+func (c *connect) selectTableColumns(ctx context.Context, db *sql.DB, name model.TableName) ([]model.Column, error) {
+	const fnName = "select table columns"
+
+	const query = `
+		SELECT
+			c.column_name, c.nullable, c.data_type, c.data_scale,
+			CASE WHEN c.default_length > 0 OR i.generation_type IS NOT NULL THEN 1 ELSE 0 END AS has_default
+		FROM
+			all_tab_cols c
+		LEFT JOIN
+			all_tab_identity_cols i
+				ON i.owner = c.owner AND i.table_name = c.table_name AND i.column_name = c.column_name
+		WHERE
+			c.owner = :1 AND c.table_name = :2
+			AND c.hidden_column = 'NO' AND c.virtual_column = 'NO'
+			AND (i.generation_type IS NULL OR i.generation_type <> 'ALWAYS')
+		ORDER BY
+			c.column_id
+	`
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columns := make([]model.Column, 0)
+	for rows.Next() {
+		var (
+			columnName, nullable, dataType string
+			scale                          sql.NullInt64
+			hasDefault                     int
+		)
+		if err := rows.Scan(&columnName, &nullable, &dataType, &scale, &hasDefault); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		baseType := BaseType(dataType)
+		fixedSize, ok := fixedSizes[baseType]
+		if !ok {
+			fixedSize = -1
+		}
+		if IsIntegerNumber(baseType, scale) {
+			fixedSize = 8
+		}
+
+		columns = append(columns, model.Column{
+			Name:         model.OracleIdentifier(columnName),
+			IsNullable:   nullable == "Y",
+			Type:         baseType,
+			FixedSize:    fixedSize,
+			ElemSizeByte: sql.NullInt64{Int64: 0, Valid: false},
+			Generated:    false,
+			HasDefault:   hasDefault == 1,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columns, nil
+}
+
+// selectUniqueConstraints reads unique indexes, primary and unique keys are enforced by them too.
+func (c *connect) selectUniqueConstraints(
+	ctx context.Context,
+	db *sql.DB,
+	name model.TableName,
+) ([][]model.Identifier, error) {
+	const fnName = "select unique constraints"
+
+	// indexes on expressions refer to hidden columns, they can't be checked by column values
+	const query = `
+		SELECT
+			ic.index_name, ic.column_name
+		FROM
+			all_indexes i
+		JOIN
+			all_ind_columns ic ON ic.index_owner = i.owner AND ic.index_name = i.index_name
+		WHERE
+			i.table_owner = :1 AND i.table_name = :2 AND i.uniqueness = 'UNIQUE'
+			AND i.index_type NOT LIKE 'FUNCTION-BASED%'
+		ORDER BY
+			ic.index_name, ic.column_position
+	`
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	var (
+		indexes [][]model.Identifier
+		last    string
+	)
+	for rows.Next() {
+		var indexName, columnName string
+		if err := rows.Scan(&indexName, &columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if len(indexes) == 0 || indexName != last {
+			indexes = append(indexes, nil)
+			last = indexName
+		}
+		indexes[len(indexes)-1] = append(indexes[len(indexes)-1], model.OracleIdentifier(columnName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return indexes, nil
+}
+
+// ResolveTableNames looks the table up in the given schema, the current one if it's empty.
+// Unquoted oracle names are stored in upper case, so both spellings are matched.
+func (c *connect) ResolveTableNames(ctx context.Context, name, owner string) ([]model.TableName, error) {
+	const fnName = "resolve table names"
+	const query = `
+		SELECT
+			owner, table_name
+		FROM
+			all_tables
+		WHERE
+			owner IN (COALESCE(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')), UPPER(:2))
+			AND table_name IN (:3, UPPER(:4))
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	// oracle treats empty strings as NULL
+	rows, err := db.QueryContext(ctx, query, owner, owner, name, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableOwner, tableName string
+		if err := rows.Scan(&tableOwner, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.OracleIdentifier(tableOwner),
+			Table:  model.OracleIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+// SchemaTables lists tables of the owner, the current schema if it's empty.
+// Nested, temporary and dropped tables are skipped as well as materialized view containers.
+func (c *connect) SchemaTables(ctx context.Context, owner string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+	const query = `
+		SELECT
+			t.owner, t.table_name
+		FROM
+			all_tables t
+		WHERE
+			t.owner IN (COALESCE(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')), UPPER(:2))
+			AND t.nested = 'NO' AND t.temporary = 'N' AND t.dropped = 'NO'
+			AND NOT EXISTS (
+				SELECT 1 FROM all_mviews m WHERE m.owner = t.owner AND m.container_name = t.table_name
+			)
+		ORDER BY
+			t.table_name
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, owner, owner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableOwner, tableName string
+		if err := rows.Scan(&tableOwner, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.OracleIdentifier(tableOwner),
+			Table:  model.OracleIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+func (c *connect) ResolveColumnNames(ctx context.Context, name model.TableName, column string) ([]model.Identifier, error) {
+	const fnName = "resolve column names"
+	const query = `
+		SELECT
+			column_name
+		FROM
+			all_tab_columns
+		WHERE
+			owner = :1 AND table_name = :2 AND column_name IN (:3, UPPER(:4))
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument(), column, column)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columns := make([]model.Identifier, 0)
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		columns = append(columns, model.OracleIdentifier(columnName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columns, nil
+}
@@ -1,33 +1,139 @@
 package oracle
 
 import (
-	_ "github.com/sijms/go-ora/v2"
+	"context"
+	"fmt"
 
-	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
 )
 
+// Inspector reads table definitions from the data dictionary, a schema is an owner.
 type Inspector struct {
-	connect db.Connect
+	connect *connect
 }
 
-// func NewInspector(ctx context.Context, connCfg *config.SQLConnection) (*Inspector, error) {
-// 	const fnName = "oracle: new inspector"
+func NewInspector(conn *config.SQLConnection) *Inspector {
+	return &Inspector{connect: newConnect(conn.ConnString("oracle"))}
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var oracleRegistryTypes = map[string]model.CommonType{
+	"float": model.Float, "binary_float": model.Float, "binary_double": model.Float,
+	"date": model.Date, "timestamp": model.Timestamp,
+	"timestamp with time zone": model.Timestamp, "timestamp with local time zone": model.Timestamp,
+	"char": model.Text, "nchar": model.Text, "varchar2": model.Text, "nvarchar2": model.Text,
+	"clob": model.Text, "nclob": model.Text,
+}
 
-// 	dsn := connCfg.ConnString("oracle")
-// 	connect, err := goora.NewAdapterPool(ctx, dsn)
-// 	if err != nil {
-// 		return nil, fmt.Errorf("%w: %s", err, fnName)
-// 	}
+func (i *Inspector) TableIdentifier(ctx context.Context, table *config.Table) (model.TableName, error) {
+	const fnName = "table identifier"
 
-// 	return &Inspector{connect: connect}, nil
-// }
+	matchedTables, err := i.connect.ResolveTableNames(ctx, table.Table, table.Schema)
+	if err != nil {
+		return model.TableName{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	switch {
+	case len(matchedTables) == 0:
+		return model.TableName{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s",
+			schema.ErrEntityNotFound, fnName,
+			table.Schema, table.Table,
+		)
+	case len(matchedTables) > 1:
+		return model.TableName{}, fmt.Errorf(
+			"%w: %s try to specify schema for %s",
+			schema.ErrTooManyTablesMatched, fnName, table.Table,
+		)
+	default:
+		return matchedTables[0], nil
+	}
+}
 
-// func (i *Inspector) Close(ctx context.Context) error {
-// 	const fnName = "oracle: close"
+func (i *Inspector) SchemaTables(ctx context.Context, schemaName string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+
+	tables, err := i.connect.SchemaTables(ctx, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%w: %s schema: %s", schema.ErrEntityNotFound, fnName, schemaName)
+	}
+
+	return tables, nil
+}
 
-// 	if err := i.connect.Close(ctx); err != nil {
-// 		return fmt.Errorf("%w: %s", err, fnName)
-// 	}
+func (i *Inspector) ColumnIdentifier(ctx context.Context, tableName model.TableName, column string) (model.Identifier, error) {
+	const fnName = "column identifier"
 
-// 	return nil
-// }
+	columns, err := i.connect.ResolveColumnNames(ctx, tableName, column)
+	if err != nil {
+		return model.Identifier{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	switch {
+	case len(columns) == 0:
+		return model.Identifier{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s column: %s",
+			schema.ErrEntityNotFound, fnName,
+			tableName.Schema.AsArgument(), tableName.Table.AsArgument(), column,
+		)
+	case len(columns) > 1:
+		return model.Identifier{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s column: %s",
+			schema.ErrTooManyColumnsMatched, fnName,
+			tableName.Schema.AsArgument(), tableName.Table.AsArgument(), column,
+		)
+	default:
+		return columns[0], nil
+	}
+}
+
+func (i *Inspector) Table(ctx context.Context, name model.TableName) (model.DatasetSchema, error) {
+	const fnName = "table"
+
+	table, err := i.connect.Table(ctx, name)
+	if err != nil {
+		return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	dataTypes := make([]model.TargetType, len(table.Columns))
+	for i, col := range table.Columns {
+		tp, ok := oracleRegistryTypes[col.Type]
+		switch {
+		case col.Type == "number" && col.FixedSize > 0:
+			// only integer numbers have a fixed size
+			tp = model.Integer
+		case col.Type == "number":
+			tp = model.Float
+		case !ok:
+			tp = model.DriverSpecified
+		}
+
+		dataTypes[i] = model.TargetType{
+			SourceName: col.Name,
+			SourceType: col.Type,
+			Type:       tp,
+			IsNullable: col.IsNullable,
+			FixedSize:  col.FixedSize,
+			ArrayElem:  model.ArrayInfo{ElemType: 0, SourceType: "", ElemSize: 0},
+			Composite:  model.CompositeInfo{Name: model.TableName{}, Fields: nil},
+			Generated:  col.Generated,
+			HasDefault: col.HasDefault,
+			Check:      nil,
+			Partitions: nil,
+		}
+	}
+
+	return model.DatasetSchema{
+		TableName:         name,
+		Columns:           dataTypes,
+		UniqueConstraints: table.UniqueIndexes,
+		ColumnOrders:      nil,
+		UnsupportedChecks: nil,
+	}, nil
+}
@@ -0,0 +1,592 @@
+package postgres
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+)
+
+// Check constraints are parsed from pg_get_constraintdef, which prints them in a normalized form:
+// CHECK ((price > (0)::numeric)), CHECK (((status)::text = ANY ((ARRAY['a'::character varying])::text[]))).
+// Only conjunctions of simple comparisons are understood, anything else leaves the constraint unsupported.
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	// quoted identifiers are never keywords
+	quoted bool
+}
+
+func (t token) is(kind tokenKind, val string) bool {
+	return t.kind == kind && !t.quoted && strings.EqualFold(t.val, val)
+}
+
+func (t token) isKeyword(val string) bool {
+	return t.is(tokenIdent, val)
+}
+
+type checkTermKind int
+
+const (
+	termBound checkTermKind = iota
+	termIn
+	termLength
+	termOrder
+	termNotNull
+)
+
+// checkTerm is a single understood comparison of a check constraint.
+type checkTerm struct {
+	kind   checkTermKind
+	column string
+	// op is one of <, <=, >, >=, = with the column on the left side
+	op     string
+	values []string
+	// other is the right column of an order term
+	other string
+}
+
+// parseCheck splits the constraint into terms, ok is false if some part isn't understood.
+func parseCheck(def string) ([]checkTerm, bool) {
+	tokens, ok := tokenize(def)
+	if !ok || len(tokens) < 2 || !tokens[0].isKeyword("CHECK") {
+		return nil, false
+	}
+
+	tokens = tokens[1:]
+	if n := len(tokens); n > 2 && tokens[n-2].isKeyword("NOT") && tokens[n-1].isKeyword("VALID") {
+		tokens = tokens[:n-2]
+	}
+	if n := len(tokens); n > 2 && tokens[n-2].isKeyword("NO") && tokens[n-1].isKeyword("INHERIT") {
+		tokens = tokens[:n-2]
+	}
+
+	tokens = simplify(dropCasts(foldNow(tokens)))
+
+	terms := make([]checkTerm, 0)
+	ok = true
+	for _, conjunct := range splitAnd(stripParens(tokens)) {
+		term, parsed := parseTerm(stripParens(conjunct))
+		if !parsed {
+			ok = false
+
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, ok
+}
+
+//nolint:gocognit,cyclop // a plain hand written lexer
+func tokenize(def string) ([]token, bool) {
+	const operatorChars = "+-*/<>=~!@#%^&|`?"
+
+	runes := []rune(def)
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			closed := false
+			for j < len(runes) {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						sb.WriteRune(r)
+						j += 2
+
+						continue
+					}
+					closed = true
+
+					break
+				}
+				sb.WriteRune(runes[j])
+				j++
+			}
+			if !closed {
+				return nil, false
+			}
+
+			kind := tokenString
+			if r == '"' {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, token{kind: kind, val: sb.String(), quoted: r == '"'})
+			i = j + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == 'e' || runes[j] == 'E' ||
+				((runes[j] == '+' || runes[j] == '-') && (runes[j-1] == 'e' || runes[j-1] == 'E'))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, val: string(runes[i:j]), quoted: false})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, val: string(runes[i:j]), quoted: false})
+			i = j
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			tokens = append(tokens, token{kind: tokenPunct, val: "::", quoted: false})
+			i += 2
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, token{kind: tokenPunct, val: string(r), quoted: false})
+			i++
+		case strings.ContainsRune(operatorChars, r):
+			j := i
+			for j < len(runes) && strings.ContainsRune(operatorChars, runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenOperator, val: string(runes[i:j]), quoted: false})
+			i = j
+		default:
+			return nil, false
+		}
+	}
+
+	return mergeUnaryMinus(tokens), true
+}
+
+func mergeUnaryMinus(tokens []token) []token {
+	out := make([]token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.is(tokenOperator, "-") && i+1 < len(tokens) && tokens[i+1].kind == tokenNumber {
+			prevOperand := len(out) > 0 &&
+				(out[len(out)-1].kind != tokenOperator && out[len(out)-1].kind != tokenPunct ||
+					out[len(out)-1].val == ")" || out[len(out)-1].val == "]")
+			if !prevOperand {
+				out = append(out, token{kind: tokenNumber, val: "-" + tokens[i+1].val, quoted: false})
+				i++
+
+				continue
+			}
+		}
+		out = append(out, t)
+	}
+
+	return out
+}
+
+// foldNow turns now() calls into the CURRENT_TIMESTAMP keyword.
+func foldNow(tokens []token) []token {
+	out := make([]token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].isKeyword("now") && i+2 < len(tokens) &&
+			tokens[i+1].is(tokenPunct, "(") && tokens[i+2].is(tokenPunct, ")") {
+			out = append(out, token{kind: tokenIdent, val: "CURRENT_TIMESTAMP", quoted: false})
+			i += 2
+
+			continue
+		}
+		out = append(out, tokens[i])
+	}
+
+	return out
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var typeTailWords = []string{"varying", "precision", "without", "with", "time", "zone"}
+
+// dropCasts removes ::type suffixes, they don't change the meaning of the literals.
+func dropCasts(tokens []token) []token {
+	out := make([]token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].is(tokenPunct, "::") {
+			out = append(out, tokens[i])
+
+			continue
+		}
+
+		j := i + 1
+		if j < len(tokens) && tokens[j].kind == tokenIdent {
+			j++
+		}
+		for j < len(tokens) && tokens[j].kind == tokenIdent && isTypeTailWord(tokens[j].val) {
+			j++
+		}
+		if j+2 < len(tokens) && tokens[j].is(tokenPunct, "(") && tokens[j+1].kind == tokenNumber &&
+			tokens[j+2].is(tokenPunct, ")") {
+			j += 3
+		}
+		for j+1 < len(tokens) && tokens[j].is(tokenPunct, "[") && tokens[j+1].is(tokenPunct, "]") {
+			j += 2
+		}
+		i = j - 1
+	}
+
+	return out
+}
+
+func isTypeTailWord(word string) bool {
+	for _, w := range typeTailWords {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchParen returns the index of the parenthesis closing the one at open, -1 if there is none.
+func matchParen(tokens []token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokens[i].is(tokenPunct, "("):
+			depth++
+		case tokens[i].is(tokenPunct, ")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// simplify removes parentheses around single operands and doubled ones, function calls keep theirs.
+func simplify(tokens []token) []token {
+	for changed := true; changed; {
+		changed = false
+		for i := range tokens {
+			if !tokens[i].is(tokenPunct, "(") {
+				continue
+			}
+
+			isCall := i > 0 && tokens[i-1].kind == tokenIdent && !tokens[i-1].quoted &&
+				!tokens[i-1].isKeyword("AND") && !tokens[i-1].isKeyword("OR") && !tokens[i-1].isKeyword("NOT")
+			closing := matchParen(tokens, i)
+			if closing == -1 {
+				return tokens
+			}
+
+			single := closing == i+2 && !isCall
+			doubled := tokens[i+1].is(tokenPunct, "(") && matchParen(tokens, i+1) == closing-1
+			if single || doubled {
+				next := make([]token, 0, len(tokens)-2)
+				next = append(next, tokens[:i]...)
+				next = append(next, tokens[i+1:closing]...)
+				next = append(next, tokens[closing+1:]...)
+				tokens = next
+				changed = true
+
+				break
+			}
+		}
+	}
+
+	return tokens
+}
+
+func stripParens(tokens []token) []token {
+	for len(tokens) > 1 && tokens[0].is(tokenPunct, "(") && matchParen(tokens, 0) == len(tokens)-1 {
+		tokens = tokens[1 : len(tokens)-1]
+	}
+
+	return tokens
+}
+
+func splitAnd(tokens []token) [][]token {
+	parts := make([][]token, 0)
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.is(tokenPunct, "("):
+			depth++
+		case t.is(tokenPunct, ")"):
+			depth--
+		case depth == 0 && t.isKeyword("AND"):
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, tokens[start:])
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var flippedOps = map[string]string{"<": ">", "<=": ">=", ">": "<", ">=": "<=", "=": "="}
+
+//nolint:cyclop // a list of recognized shapes
+func parseTerm(tokens []token) (checkTerm, bool) {
+	isColumn := func(t token) bool {
+		return t.kind == tokenIdent && (t.quoted || !isLiteralKeyword(t.val))
+	}
+
+	switch {
+	case len(tokens) == 3 && isComparison(tokens[1]):
+		left, op, right := tokens[0], tokens[1].val, tokens[2]
+		switch {
+		case isColumn(left) && isColumn(right):
+			return checkTerm{kind: termOrder, column: left.val, op: op, values: nil, other: right.val}, op != "="
+		case isColumn(left) && isLiteral(right):
+			return checkTerm{kind: termBound, column: left.val, op: op, values: []string{literal(right)}, other: ""}, true
+		case isLiteral(left) && isColumn(right):
+			return checkTerm{kind: termBound, column: right.val, op: flippedOps[op], values: []string{literal(left)}, other: ""}, true
+		}
+	case len(tokens) == 4 && isColumn(tokens[0]) && tokens[1].isKeyword("IS") &&
+		tokens[2].isKeyword("NOT") && tokens[3].isKeyword("NULL"):
+		return checkTerm{kind: termNotNull, column: tokens[0].val, op: "", values: nil, other: ""}, true
+	case len(tokens) > 4 && isColumn(tokens[0]) && tokens[1].is(tokenOperator, "=") && tokens[2].isKeyword("ANY") &&
+		tokens[3].is(tokenPunct, "(") && tokens[4].isKeyword("ARRAY") && tokens[len(tokens)-1].is(tokenPunct, ")"):
+		values, ok := parseList(tokens[5:len(tokens)-1], "[", "]")
+
+		return checkTerm{kind: termIn, column: tokens[0].val, op: "=", values: values, other: ""}, ok
+	case len(tokens) > 3 && isColumn(tokens[0]) && tokens[1].isKeyword("IN"):
+		values, ok := parseList(tokens[2:], "(", ")")
+
+		return checkTerm{kind: termIn, column: tokens[0].val, op: "=", values: values, other: ""}, ok
+	case len(tokens) == 6 && isLengthFunc(tokens[0]) && tokens[1].is(tokenPunct, "(") && isColumn(tokens[2]) &&
+		tokens[3].is(tokenPunct, ")") && isComparison(tokens[4]) && tokens[5].kind == tokenNumber:
+		return checkTerm{kind: termLength, column: tokens[2].val, op: tokens[4].val, values: []string{tokens[5].val}, other: ""},
+			isCount(tokens[5].val)
+	case len(tokens) == 6 && tokens[0].kind == tokenNumber && isComparison(tokens[1]) && isLengthFunc(tokens[2]) &&
+		tokens[3].is(tokenPunct, "(") && isColumn(tokens[4]) && tokens[5].is(tokenPunct, ")"):
+		return checkTerm{
+			kind: termLength, column: tokens[4].val, op: flippedOps[tokens[1].val], values: []string{tokens[0].val}, other: "",
+		}, isCount(tokens[0].val)
+	}
+
+	return checkTerm{}, false
+}
+
+func parseList(tokens []token, open, closing string) ([]string, bool) {
+	if len(tokens) < 3 || !tokens[0].is(tokenPunct, open) || !tokens[len(tokens)-1].is(tokenPunct, closing) {
+		return nil, false
+	}
+
+	values := make([]string, 0)
+	for i, t := range tokens[1 : len(tokens)-1] {
+		if i%2 == 1 {
+			if !t.is(tokenPunct, ",") {
+				return nil, false
+			}
+
+			continue
+		}
+
+		if !isLiteral(t) || t.isKeyword("NULL") {
+			return nil, false
+		}
+		values = append(values, literal(t))
+	}
+
+	return values, len(values) > 0
+}
+
+func isComparison(t token) bool {
+	_, ok := flippedOps[t.val]
+
+	return t.kind == tokenOperator && ok
+}
+
+func isLengthFunc(t token) bool {
+	return t.isKeyword("length") || t.isKeyword("char_length") || t.isKeyword("character_length")
+}
+
+func isCount(val string) bool {
+	n, err := strconv.Atoi(val)
+
+	return err == nil && n >= 0
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var literalKeywords = []string{"NULL", "TRUE", "FALSE", "CURRENT_TIMESTAMP", "CURRENT_DATE", "LOCALTIMESTAMP"}
+
+func isLiteralKeyword(val string) bool {
+	for _, kw := range literalKeywords {
+		if strings.EqualFold(kw, val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isLiteral(t token) bool {
+	return t.kind == tokenString || t.kind == tokenNumber || (t.kind == tokenIdent && !t.quoted && isLiteralKeyword(t.val))
+}
+
+// literal returns the literal text, the moment of generation stands for current timestamps.
+func literal(t token) string {
+	if t.kind == tokenIdent {
+		upper := strings.ToUpper(t.val)
+		if upper == "CURRENT_TIMESTAMP" || upper == "CURRENT_DATE" || upper == "LOCALTIMESTAMP" {
+			return "now"
+		}
+
+		return upper
+	}
+
+	return t.val
+}
+
+//nolint:gochecknoglobals // more convenient that constants here
+var (
+	boundedTypes = []model.CommonType{model.Integer, model.Float, model.Timestamp, model.Date}
+	listedTypes  = []model.CommonType{model.Integer, model.Float, model.Timestamp, model.Date, model.Text}
+)
+
+// applyChecks folds understood checks into the columns, the rest is returned as unsupported.
+func applyChecks(defs []string, columns []model.TargetType) ([]model.ColumnOrder, []string) {
+	orders := make([]model.ColumnOrder, 0)
+	unsupported := make([]string, 0)
+
+	for _, def := range defs {
+		terms, ok := parseCheck(def)
+		for _, term := range terms {
+			order, applied := applyTerm(term, columns)
+			if !applied {
+				ok = false
+
+				continue
+			}
+
+			if order != nil {
+				orders = append(orders, *order)
+			}
+		}
+
+		if !ok {
+			unsupported = append(unsupported, def)
+		}
+	}
+
+	return orders, unsupported
+}
+
+// applyDomainChecks folds checks of the column's domains in, VALUE stands for the column there.
+func applyDomainChecks(column model.Identifier, defs []string, columns []model.TargetType) []string {
+	unsupported := make([]string, 0)
+
+	for _, def := range defs {
+		terms, ok := parseCheck(def)
+		for _, term := range terms {
+			if term.kind == termOrder || !strings.EqualFold(term.column, "VALUE") {
+				ok = false
+
+				continue
+			}
+
+			term.column = column.AsArgument()
+			if _, applied := applyTerm(term, columns); !applied {
+				ok = false
+			}
+		}
+
+		if !ok {
+			unsupported = append(unsupported, def+" of "+column.AsArgument())
+		}
+	}
+
+	return unsupported
+}
+
+//nolint:cyclop // one branch per term kind
+func applyTerm(term checkTerm, columns []model.TargetType) (*model.ColumnOrder, bool) {
+	idx := slices.IndexFunc(columns, func(c model.TargetType) bool { return c.SourceName.AsArgument() == term.column })
+	if idx == -1 {
+		return nil, false
+	}
+
+	col := &columns[idx]
+	check := func() *model.ColumnCheck {
+		if col.Check == nil {
+			col.Check = &model.ColumnCheck{Min: nil, Max: nil, In: nil, MinLength: nil, MaxLength: nil}
+		}
+
+		return col.Check
+	}
+
+	switch term.kind {
+	case termNotNull:
+		return nil, true
+	case termBound:
+		types := boundedTypes
+		if term.op == "=" {
+			types = listedTypes
+		}
+		if !slices.Contains(types, col.Type) {
+			return nil, false
+		}
+
+		value := term.values[0]
+		switch term.op {
+		case ">", ">=":
+			check().Min = &model.CheckBound{Value: value, Inclusive: term.op == ">="}
+		case "<", "<=":
+			check().Max = &model.CheckBound{Value: value, Inclusive: term.op == "<="}
+		default:
+			check().In = []string{value}
+		}
+
+		return nil, true
+	case termIn:
+		if !slices.Contains(listedTypes, col.Type) {
+			return nil, false
+		}
+		check().In = term.values
+
+		return nil, true
+	case termLength:
+		if col.Type != model.Text {
+			return nil, false
+		}
+
+		n, _ := strconv.Atoi(term.values[0])
+		switch term.op {
+		case ">":
+			check().MinLength = lo.ToPtr(n + 1)
+		case ">=":
+			check().MinLength = lo.ToPtr(n)
+		case "<":
+			check().MaxLength = lo.ToPtr(n - 1)
+		case "<=":
+			check().MaxLength = lo.ToPtr(n)
+		default:
+			check().MinLength, check().MaxLength = lo.ToPtr(n), lo.ToPtr(n)
+		}
+
+		return nil, true
+	case termOrder:
+		other := slices.IndexFunc(columns, func(c model.TargetType) bool { return c.SourceName.AsArgument() == term.other })
+		if other == -1 || columns[other].Type != col.Type || !slices.Contains(listedTypes, col.Type) {
+			return nil, false
+		}
+
+		order := model.ColumnOrder{Lesser: col.SourceName, Greater: columns[other].SourceName, Strict: len(term.op) == 1}
+		if term.op[0] == '>' {
+			order.Lesser, order.Greater = order.Greater, order.Lesser
+		}
+
+		return &order, true
+	}
+
+	return nil, false
+}
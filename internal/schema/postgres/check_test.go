@@ -0,0 +1,144 @@
+package postgres //nolint:testpackage // parser isn't exported
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyChecks(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	newColumns := func() []model.TargetType {
+		return []model.TargetType{
+			{SourceName: model.PGIdentifier("price"), Type: model.Float, SourceType: "numeric"},
+			{SourceName: model.PGIdentifier("qty"), Type: model.Integer, SourceType: "int4"},
+			{SourceName: model.PGIdentifier("status"), Type: model.Text, SourceType: "varchar"},
+			{SourceName: model.PGIdentifier("Code"), Type: model.Text, SourceType: "text"},
+			{SourceName: model.PGIdentifier("starts_at"), Type: model.Timestamp, SourceType: "timestamp"},
+			{SourceName: model.PGIdentifier("ends_at"), Type: model.Timestamp, SourceType: "timestamp"},
+			{SourceName: model.PGIdentifier("flag"), Type: model.DriverSpecified, SourceType: "bool"},
+		}
+	}
+	noCheck := model.ColumnCheck{Min: nil, Max: nil, In: nil, MinLength: nil, MaxLength: nil}
+
+	testCases := []struct {
+		desc string
+		def  string
+
+		column      string
+		expected    model.ColumnCheck
+		orders      []model.ColumnOrder
+		unsupported bool
+	}{
+		{
+			desc:   "numeric_lower_bound",
+			def:    "CHECK ((price > (0)::numeric))",
+			column: "price",
+			expected: model.ColumnCheck{
+				Min: &model.CheckBound{Value: "0", Inclusive: false}, Max: nil, In: nil, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:   "between",
+			def:    "CHECK (((qty >= 1) AND (qty <= 10))) NOT VALID",
+			column: "qty",
+			expected: model.ColumnCheck{
+				Min: &model.CheckBound{Value: "1", Inclusive: true}, Max: &model.CheckBound{Value: "10", Inclusive: true},
+				In: nil, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:   "negative_flipped",
+			def:    "CHECK (('-5'::integer < qty))",
+			column: "qty",
+			expected: model.ColumnCheck{
+				Min: &model.CheckBound{Value: "-5", Inclusive: false}, Max: nil, In: nil, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:   "in_list_varchar",
+			def:    "CHECK (((status)::text = ANY ((ARRAY['a'::character varying, 'it''s'::character varying])::text[])))",
+			column: "status",
+			expected: model.ColumnCheck{
+				Min: nil, Max: nil, In: []string{"a", "it's"}, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:   "text_equality",
+			def:    "CHECK (((status)::text = 'new'::text))",
+			column: "status",
+			expected: model.ColumnCheck{
+				Min: nil, Max: nil, In: []string{"new"}, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:   "length_quoted_column",
+			def:    `CHECK (((length("Code") > 2) AND (char_length(("Code")::text) <= 8)))`,
+			column: "Code",
+			expected: model.ColumnCheck{
+				Min: nil, Max: nil, In: nil, MinLength: lo.ToPtr(3), MaxLength: lo.ToPtr(8),
+			},
+		},
+		{
+			desc:   "timestamp_now",
+			def:    "CHECK ((starts_at <= now()))",
+			column: "starts_at",
+			expected: model.ColumnCheck{
+				Min: nil, Max: &model.CheckBound{Value: "now", Inclusive: true}, In: nil, MinLength: nil, MaxLength: nil,
+			},
+		},
+		{
+			desc:     "column_order",
+			def:      "CHECK ((ends_at > starts_at))",
+			column:   "ends_at",
+			expected: noCheck,
+			orders: []model.ColumnOrder{
+				{Lesser: model.PGIdentifier("starts_at"), Greater: model.PGIdentifier("ends_at"), Strict: true},
+			},
+		},
+		{
+			desc:        "or_is_unsupported",
+			def:         "CHECK (((qty > 0) OR (qty IS NULL)))",
+			column:      "qty",
+			expected:    noCheck,
+			unsupported: true,
+		},
+		{
+			desc:   "partly_supported",
+			def:    "CHECK (((qty > 0) AND ((qty % 2) = 0)))",
+			column: "qty",
+			expected: model.ColumnCheck{
+				Min: &model.CheckBound{Value: "0", Inclusive: false}, Max: nil, In: nil, MinLength: nil, MaxLength: nil,
+			},
+			unsupported: true,
+		},
+		{
+			desc:        "unsupported_type",
+			def:         "CHECK ((flag = true))",
+			column:      "flag",
+			expected:    noCheck,
+			unsupported: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			columns := newColumns()
+			orders, unsupported := applyChecks([]string{tC.def}, columns)
+
+			col := columns[lo.IndexOf(lo.Map(columns, func(c model.TargetType, _ int) string {
+				return c.SourceName.AsArgument()
+			}), tC.column)]
+			require.Equal(t, tC.expected, lo.FromPtrOr(col.Check, noCheck))
+			require.Equal(t, lo.CoalesceSliceOrEmpty(tC.orders), orders)
+			require.Equal(t, tC.unsupported, len(unsupported) == 1)
+		})
+	}
+}
@@ -49,10 +49,16 @@ func (c *connect) Table(ctx context.Context, name model.TableName) (model.Table,
 		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
 	}
 
+	checks, err := c.selectCheckConstraints(ctx, conn, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
 	return model.Table{
 		Name:          name,
 		Columns:       columns,
 		UniqueIndexes: uniqueIndexes,
+		Checks:        checks,
 	}, nil
 }
 
@@ -86,7 +92,9 @@ func (c *connect) selectTableColumns(
 ) ([]model.Column, error) {
 	const query = `
 		SELECT 
-			c.column_name, c.is_nullable, c.udt_name, t.typlen, elem.typlen AS element_size_bytes 
+			c.column_name, c.is_nullable, c.udt_name, t.typlen, elem.typlen AS element_size_bytes,
+			c.is_generated = 'ALWAYS' OR COALESCE(c.identity_generation = 'ALWAYS', false) AS is_generated,
+			c.column_default IS NOT NULL OR c.is_identity = 'YES' OR c.is_generated = 'ALWAYS' AS has_default
 		FROM 
 			information_schema.columns c
 		LEFT JOIN pg_type t
@@ -105,6 +113,8 @@ func (c *connect) selectTableColumns(
 		UdtName       string        `db:"udt_name"`
 		TypeLen       int           `db:"typlen"` //nolint:tagliatelle // ok here
 		ElemSizeBytes sql.NullInt64 `db:"element_size_bytes"`
+		IsGenerated   bool          `db:"is_generated"`
+		HasDefault    bool          `db:"has_default"`
 	}
 
 	var columns []Column
@@ -119,6 +129,8 @@ func (c *connect) selectTableColumns(
 			Type:         c.UdtName,
 			FixedSize:    c.TypeLen,
 			ElemSizeByte: c.ElemSizeBytes,
+			Generated:    c.IsGenerated,
+			HasDefault:   c.HasDefault,
 		}
 	}), nil
 }
@@ -172,6 +184,34 @@ func (c *connect) selectUniqueConstraints(
 	}), nil
 }
 
+func (c *connect) selectCheckConstraints(
+	ctx context.Context,
+	conn *pgx.Conn,
+	name model.TableName,
+) ([]string, error) {
+	const query = `
+		SELECT
+			pg_get_constraintdef(con.oid)
+		FROM
+			pg_constraint con
+		JOIN
+			pg_class t ON t.oid = con.conrelid
+		JOIN
+			pg_namespace n ON n.oid = t.relnamespace
+		WHERE
+			n.nspname = $1 AND t.relname = $2 AND con.contype = 'c'
+		ORDER BY
+			con.conname
+	`
+
+	var checks []string
+	if err := pgxscan.Select(ctx, conn, &checks, query, name.Schema.AsArgument(), name.Table.AsArgument()); err != nil {
+		return nil, fmt.Errorf("%w: selectCheckConstraints", err)
+	}
+
+	return checks, nil
+}
+
 func (c *connect) ResolveTableNames(ctx context.Context, name, schema string) ([]model.TableName, error) {
 	const fnName = "resolve table names"
 	const queryWithoutSchema = "SELECT schemaname, tablename FROM pg_catalog.pg_tables WHERE tablename = $1"
@@ -207,6 +247,46 @@ func (c *connect) ResolveTableNames(ctx context.Context, name, schema string) ([
 	}), nil
 }
 
+// SchemaTables lists base tables of the schema, the current one if it's empty; partitions are left to their parents.
+func (c *connect) SchemaTables(ctx context.Context, schema string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+	const query = `
+		SELECT
+			n.nspname AS schemaname, c.relname AS tablename
+		FROM
+			pg_catalog.pg_class c
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE
+			c.relkind IN ('r', 'p') AND NOT c.relispartition
+			AND n.nspname = COALESCE(NULLIF($1::text, ''), current_schema())
+		ORDER BY
+			c.relname
+	`
+
+	type Row struct {
+		SchemaName string `db:"schemaname"`
+		TableName  string `db:"tablename"`
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer conn.Close(ctx)
+
+	var rows []Row
+	if err := pgxscan.Select(ctx, conn, &rows, query, schema); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return lo.Map(rows, func(row Row, _ int) model.TableName {
+		return model.TableName{
+			Schema: model.PGIdentifier(row.SchemaName),
+			Table:  model.PGIdentifier(row.TableName),
+		}
+	}), nil
+}
+
 func (c *connect) ResolveColumnNames(ctx context.Context, name model.TableName, column string) ([]model.Identifier, error) {
 	const fnName = "resolve column names"
 	const query = "SELECT column_name FROM information_schema.columns WHERE table_name = $1 AND table_schema = $2 AND column_name = $3"
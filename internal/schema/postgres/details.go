@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+)
+
+// ColumnDetails reads lengths, enum labels, sequences and references of the table columns from the catalog.
+func (c *connect) ColumnDetails(ctx context.Context, name model.TableName) ([]model.ColumnDetails, error) {
+	const fnName = "column details"
+
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer conn.Close(ctx)
+
+	const query = `
+		SELECT
+			a.attname,
+			COALESCE(c.character_maximum_length, 0) AS max_length,
+			COALESCE(
+				(SELECT array_agg(e.enumlabel::text ORDER BY e.enumsortorder) FROM pg_enum e WHERE e.enumtypid = a.atttypid),
+				'{}'
+			) AS enum_values,
+			pg_get_serial_sequence(format('%I.%I', n.nspname, t.relname), a.attname) IS NOT NULL AS sequence,
+			ref.nspname AS ref_schema,
+			ref.relname AS ref_table
+		FROM
+			pg_attribute a
+		JOIN pg_class t ON t.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN information_schema.columns c
+			ON c.table_schema = n.nspname AND c.table_name = t.relname AND c.column_name = a.attname
+		LEFT JOIN LATERAL (
+			SELECT rn.nspname, rt.relname
+			FROM pg_constraint con
+			JOIN pg_class rt ON rt.oid = con.confrelid
+			JOIN pg_namespace rn ON rn.oid = rt.relnamespace
+			WHERE con.contype = 'f' AND con.conrelid = t.oid AND a.attnum = ANY(con.conkey)
+			LIMIT 1
+		) ref ON true
+		WHERE
+			n.nspname = $1 AND t.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY
+			a.attnum
+	`
+
+	type details struct {
+		Column     string   `db:"attname"`
+		MaxLength  int      `db:"max_length"`
+		EnumValues []string `db:"enum_values"`
+		Sequence   bool     `db:"sequence"`
+		RefSchema  *string  `db:"ref_schema"`
+		RefTable   *string  `db:"ref_table"`
+	}
+
+	var rows []details
+	if err := pgxscan.Select(ctx, conn, &rows, query, name.Schema.AsArgument(), name.Table.AsArgument()); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return lo.Map(rows, func(d details, _ int) model.ColumnDetails {
+		var references *model.TableName
+		if d.RefSchema != nil && d.RefTable != nil {
+			references = &model.TableName{
+				Schema: model.PGIdentifier(*d.RefSchema),
+				Table:  model.PGIdentifier(*d.RefTable),
+			}
+		}
+
+		return model.ColumnDetails{
+			Column:     model.PGIdentifier(d.Column),
+			MaxLength:  d.MaxLength,
+			EnumValues: d.EnumValues,
+			Sequence:   d.Sequence,
+			References: references,
+		}
+	}), nil
+}
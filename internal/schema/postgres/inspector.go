@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/jmozgit/datagen/internal/config"
@@ -68,6 +69,21 @@ func (i *Inspector) TableIdentifier(ctx context.Context, table *config.Table) (m
 	}
 }
 
+func (i *Inspector) SchemaTables(ctx context.Context, schemaName string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+
+	tables, err := i.connect.SchemaTables(ctx, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%w: %s schema: %s", schema.ErrEntityNotFound, fnName, schemaName)
+	}
+
+	return tables, nil
+}
+
 func (i *Inspector) ColumnIdentifier(ctx context.Context, tableName model.TableName, column string) (model.Identifier, error) {
 	const fnName = "column identifier"
 
@@ -102,6 +118,22 @@ func (i *Inspector) Table(ctx context.Context, name model.TableName) (model.Data
 		return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
 	}
 
+	partitioning, err := i.connect.Partitioning(ctx, name)
+	if err != nil {
+		return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	userTypes, err := i.connect.UserTypes(ctx, name)
+	if err != nil {
+		return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	checks := table.Checks
+	// rows written to a partition directly have to satisfy its bound like any other check
+	if partitioning.Bound != "" {
+		checks = append(slices.Clone(checks), "CHECK ("+partitioning.Bound+")")
+	}
+
 	dataTypes := make([]model.TargetType, len(table.Columns))
 	for i, col := range table.Columns {
 		var arrInfo model.ArrayInfo
@@ -132,12 +164,113 @@ func (i *Inspector) Table(ctx context.Context, name model.TableName) (model.Data
 			IsNullable: col.IsNullable,
 			FixedSize:  col.FixedSize,
 			ArrayElem:  arrInfo,
+			Composite:  model.CompositeInfo{Name: model.TableName{}, Fields: nil},
+			Generated:  col.Generated,
+			HasDefault: col.HasDefault,
+			Check:      nil,
+			Partitions: nil,
 		}
 	}
 
+	unsupported := make([]string, 0)
+	for i := range dataTypes {
+		if ut, ok := userTypes[dataTypes[i].SourceName.AsArgument()]; ok {
+			dataTypes[i] = withUserType(dataTypes[i], ut)
+			unsupported = append(unsupported, applyUserTypeChecks(dataTypes[i].SourceName, ut, dataTypes)...)
+		}
+	}
+
+	// table checks come last, they are the more specific ones
+	orders, tableUnsupported := applyChecks(checks, dataTypes)
+	unsupported = append(unsupported, tableUnsupported...)
+	unsupported = append(unsupported, applyPartitioning(partitioning, dataTypes)...)
+
 	return model.DatasetSchema{
 		TableName:         name,
 		Columns:           dataTypes,
 		UniqueConstraints: table.UniqueIndexes,
+		ColumnOrders:      orders,
+		UnsupportedChecks: unsupported,
 	}, nil
 }
+
+// ColumnStats are the statistics ANALYZE gathered, columns it hasn't seen are missing.
+func (i *Inspector) ColumnStats(ctx context.Context, name model.TableName) ([]model.ColumnStats, error) {
+	stats, err := i.connect.ColumnStats(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: inspector column stats", err)
+	}
+
+	return stats, nil
+}
+
+// ColumnDetails are what the catalog tells about columns beyond their types.
+func (i *Inspector) ColumnDetails(ctx context.Context, name model.TableName) ([]model.ColumnDetails, error) {
+	details, err := i.connect.ColumnDetails(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: inspector column details", err)
+	}
+
+	return details, nil
+}
+
+func (i *Inspector) CreatePartitions(
+	ctx context.Context,
+	name model.TableName,
+	cfg *config.CreatePartitions,
+	dryRun bool,
+) ([]string, error) {
+	created, err := i.connect.CreatePartitions(ctx, name, cfg, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%w: create partitions", err)
+	}
+
+	return created, nil
+}
+
+// withUserType replaces the column type by its domain base type or composite attributes.
+func withUserType(tt model.TargetType, ut userType) model.TargetType {
+	tt.SourceType = ut.BaseType
+	tt.FixedSize = ut.TypeLen
+	tt.IsNullable = tt.IsNullable && !ut.NotNull
+
+	switch {
+	case ut.CompositeSchema != "":
+		name := model.TableName{Schema: model.PGIdentifier(ut.CompositeSchema), Table: model.PGIdentifier(ut.BaseType)}
+		tt.Type = model.Composite
+		// the saver registers the type by this name
+		tt.SourceType = name.String()
+		tt.Composite = model.CompositeInfo{Name: name, Fields: make([]model.TargetType, len(ut.Fields))}
+		for i, field := range ut.Fields {
+			//nolint:exhaustruct // the rest is filled from the field type
+			fieldType := model.TargetType{SourceName: model.PGIdentifier(field.Name), IsNullable: true}
+			tt.Composite.Fields[i] = withUserType(fieldType, field)
+		}
+	case ut.ElemType != "":
+		tt.Type = model.Array
+		tt.ArrayElem = model.ArrayInfo{
+			ElemType:   getTypeOrDefault(ut.ElemType),
+			SourceType: ut.ElemType,
+			ElemSize:   ut.ElemLen.Int64,
+		}
+	default:
+		tt.Type = getTypeOrDefault(ut.BaseType)
+	}
+
+	return tt
+}
+
+// applyUserTypeChecks folds domain checks into the column and its composite fields.
+func applyUserTypeChecks(column model.Identifier, ut userType, columns []model.TargetType) []string {
+	unsupported := applyDomainChecks(column, ut.Checks, columns)
+
+	idx := slices.IndexFunc(columns, func(c model.TargetType) bool { return c.SourceName == column })
+	for _, field := range ut.Fields {
+		fieldID := model.PGIdentifier(field.Name)
+		for _, def := range applyUserTypeChecks(fieldID, field, columns[idx].Composite.Fields) {
+			unsupported = append(unsupported, def+" of "+column.AsArgument())
+		}
+	}
+
+	return unsupported
+}
@@ -0,0 +1,449 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Partitions are read from pg_partitioned_table and pg_inherits. Range and list bounds are taken as
+// pg_get_partition_constraintdef prints them, so they go through the check parser:
+// ((created_at IS NOT NULL) AND (created_at >= '2024-01-01 00:00:00'::timestamp without time zone) AND ...).
+// Only single column keys are understood.
+
+var ErrCreatePartitionsConfig = errors.New("invalid create partitions config")
+
+const (
+	strategyRange = "r"
+	strategyList  = "l"
+	strategyHash  = "h"
+
+	defaultBound = "DEFAULT"
+
+	// maxCreatedPartitions stops a mistyped step from flooding the database with partitions.
+	maxCreatedPartitions = 10000
+)
+
+type partition struct {
+	Name          string `db:"name"`
+	Bound         string `db:"bound"`
+	Constraint    string `db:"constraint_def"`
+	IsPartitioned bool   `db:"is_partitioned"`
+}
+
+type partitioning struct {
+	OID uint32 `db:"oid"`
+	// Strategy is r, l or h for partitioned tables and empty for plain ones.
+	Strategy  string `db:"strategy"`
+	KeyDef    string `db:"key_def"`
+	KeyColumn string `db:"key_column"`
+	KeyType   string `db:"key_type"`
+	// Bound is the partition constraint of the table itself, empty if it isn't a partition.
+	Bound      string      `db:"bound"`
+	Partitions []partition `db:"-"`
+}
+
+func (c *connect) Partitioning(ctx context.Context, name model.TableName) (partitioning, error) {
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return partitioning{}, fmt.Errorf("%w: partitioning", err)
+	}
+	defer conn.Close(ctx)
+
+	p, err := c.selectPartitioning(ctx, conn, name)
+	if err != nil {
+		return partitioning{}, fmt.Errorf("%w: partitioning", err)
+	}
+
+	return p, nil
+}
+
+func (c *connect) selectPartitioning(
+	ctx context.Context,
+	conn *pgx.Conn,
+	name model.TableName,
+) (partitioning, error) {
+	const fnName = "select partitioning"
+	const query = `
+		SELECT
+			c.oid,
+			COALESCE(pt.partstrat::text, '') AS strategy,
+			COALESCE(pg_get_partkeydef(c.oid), '') AS key_def,
+			COALESCE(a.attname::text, '') AS key_column,
+			COALESCE(format_type(a.atttypid, a.atttypmod), '') AS key_type,
+			COALESCE(pg_get_partition_constraintdef(c.oid), '') AS bound
+		FROM
+			pg_class c
+		JOIN
+			pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN
+			pg_partitioned_table pt ON pt.partrelid = c.oid
+		LEFT JOIN
+			pg_attribute a ON a.attrelid = c.oid AND pt.partnatts = 1 AND a.attnum = pt.partattrs[0]
+		WHERE
+			n.nspname = $1 AND c.relname = $2
+	`
+	const partitionsQuery = `
+		SELECT
+			child.relname AS name,
+			pg_get_expr(child.relpartbound, child.oid) AS bound,
+			COALESCE(pg_get_partition_constraintdef(child.oid), '') AS constraint_def,
+			child.relkind = 'p' AS is_partitioned
+		FROM
+			pg_inherits i
+		JOIN
+			pg_class child ON child.oid = i.inhrelid
+		WHERE
+			i.inhparent = $1
+		ORDER BY
+			child.relname
+	`
+
+	var p partitioning
+	err := pgxscan.Get(ctx, conn, &p, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return partitioning{}, fmt.Errorf("%w: table %s %s", schema.ErrEntityNotFound, name.Quoted(), fnName)
+	case err != nil:
+		return partitioning{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if p.Strategy == "" {
+		return p, nil
+	}
+
+	if err := pgxscan.Select(ctx, conn, &p.Partitions, partitionsQuery, p.OID); err != nil {
+		return partitioning{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return p, nil
+}
+
+// applyPartitioning narrows the partition key column to the partitions,
+// it returns the partitioning if generated rows may still find no partition.
+func applyPartitioning(p partitioning, columns []model.TargetType) []string {
+	if p.Strategy == "" {
+		return nil
+	}
+
+	key := "PARTITION BY " + p.KeyDef
+	idx := slices.IndexFunc(columns, func(c model.TargetType) bool { return c.SourceName.AsArgument() == p.KeyColumn })
+	if idx == -1 {
+		return []string{key}
+	}
+
+	if len(p.Partitions) == 0 {
+		columns[idx].Partitions = []model.ColumnCheck{}
+
+		return nil
+	}
+
+	unsupported := make([]string, 0)
+	for _, part := range p.Partitions {
+		if part.IsPartitioned {
+			unsupported = append(unsupported, "partitions of "+part.Name)
+		}
+	}
+
+	if slices.ContainsFunc(p.Partitions, func(part partition) bool { return part.Bound == defaultBound }) {
+		return unsupported
+	}
+
+	if p.Strategy == strategyHash {
+		bounds, ok := parseHashBounds(p.Partitions)
+		if _, missing := missingRemainders(bounds); !ok || len(missing) > 0 {
+			unsupported = append(unsupported, key)
+		}
+
+		return unsupported
+	}
+
+	checks := make([]model.ColumnCheck, 0, len(p.Partitions))
+	for _, part := range p.Partitions {
+		if c, ok := partitionCheck(part.Constraint, p.KeyColumn, columns); ok {
+			checks = append(checks, c)
+		}
+	}
+
+	// partitions left out only miss generated rows, though nothing is known if none is understood
+	if len(checks) == 0 {
+		return append(unsupported, key)
+	}
+	columns[idx].Partitions = checks
+
+	return unsupported
+}
+
+// partitionCheck is what the partition constraint tells about the key column, ok is false if it isn't understood.
+// Constraints of sub-partitions repeat the bounds of their ancestors, those are checks of the table itself.
+func partitionCheck(def, key string, columns []model.TargetType) (model.ColumnCheck, bool) {
+	if def == "" {
+		return model.ColumnCheck{}, false
+	}
+
+	cols := slices.Clone(columns)
+	for i := range cols {
+		cols[i].Check = nil
+	}
+
+	orders, unsupported := applyChecks([]string{"CHECK (" + def + ")"}, cols)
+	idx := slices.IndexFunc(cols, func(c model.TargetType) bool { return c.SourceName.AsArgument() == key })
+	if len(orders) > 0 || len(unsupported) > 0 || idx == -1 || cols[idx].Check == nil {
+		return model.ColumnCheck{}, false
+	}
+
+	return *cols[idx].Check, true
+}
+
+type hashBound struct {
+	modulus   int
+	remainder int
+}
+
+func parseHashBounds(partitions []partition) ([]hashBound, bool) {
+	bounds := make([]hashBound, 0, len(partitions))
+	for _, part := range partitions {
+		var b hashBound
+		_, err := fmt.Sscanf(part.Bound, "FOR VALUES WITH (modulus %d, remainder %d)", &b.modulus, &b.remainder)
+		if err != nil {
+			return nil, false
+		}
+		bounds = append(bounds, b)
+	}
+
+	return bounds, true
+}
+
+// missingRemainders returns the greatest modulus and its remainders no partition takes.
+// Postgres requires every modulus to divide the greater ones, so the greatest one is enough to check.
+func missingRemainders(bounds []hashBound) (int, []int) {
+	modulus := 0
+	for _, b := range bounds {
+		modulus = max(modulus, b.modulus)
+	}
+
+	missing := make([]int, 0)
+	for r := range modulus {
+		taken := slices.ContainsFunc(bounds, func(b hashBound) bool { return r%b.modulus == b.remainder })
+		if !taken {
+			missing = append(missing, r)
+		}
+	}
+
+	return modulus, missing
+}
+
+// CreatePartitions creates the partitions the config describes and returns their names.
+// A dry run creates them in a transaction which is rolled back, so overlaps with existing
+// partitions are still left out of the names, though nothing is kept.
+func (c *connect) CreatePartitions(
+	ctx context.Context,
+	name model.TableName,
+	cfg *config.CreatePartitions,
+	dryRun bool,
+) ([]string, error) {
+	const fnName = "create partitions"
+
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer conn.Close(ctx)
+
+	p, err := c.selectPartitioning(ctx, conn, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var created []string
+	switch p.Strategy {
+	case strategyRange:
+		created, err = c.createRangePartitions(ctx, tx, name, p, cfg)
+	case strategyHash:
+		created, err = c.createHashPartitions(ctx, tx, name, p, cfg)
+	case strategyList:
+		return nil, fmt.Errorf("%w: list partitions of %s %s", schema.ErrUnsupportedPartitions, name.Quoted(), fnName)
+	default:
+		return nil, fmt.Errorf("%w: %s %s", schema.ErrNotPartitioned, name.Quoted(), fnName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if !dryRun {
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
+	return created, nil
+}
+
+func (c *connect) createRangePartitions(
+	ctx context.Context,
+	tx pgx.Tx,
+	name model.TableName,
+	p partitioning,
+	cfg *config.CreatePartitions,
+) ([]string, error) {
+	const fnName = "create range partitions"
+
+	if p.KeyColumn == "" {
+		return nil, fmt.Errorf("%w: %s %s", schema.ErrUnsupportedPartitions, p.KeyDef, fnName)
+	}
+
+	if cfg.From == "" || cfg.To == "" || cfg.Step == "" {
+		return nil, fmt.Errorf("%w: from, to and step are required for %s %s", ErrCreatePartitionsConfig, p.KeyDef, fnName)
+	}
+
+	stepType, suffix := p.KeyType, "replace(replace(g::text, '-', 'm'), '.', '_')"
+	if isTimeType(p.KeyType) {
+		stepType, suffix = "interval", `replace(to_char(g, 'YYYYMMDD"_"HH24MISS'), '_000000', '')`
+	}
+
+	//nolint:gosec // the types come from format_type, not from the user
+	query := fmt.Sprintf(`
+		SELECT
+			g::%[1]s::text AS lower, (g + $3::%[2]s)::%[1]s::text AS upper, %[3]s AS suffix
+		FROM
+			generate_series($1::%[1]s, $2::%[1]s, $3::%[2]s) g
+		WHERE
+			g < $2::%[1]s
+		LIMIT %[4]d
+	`, p.KeyType, stepType, suffix, maxCreatedPartitions+1)
+
+	type Range struct {
+		Lower  string `db:"lower"`
+		Upper  string `db:"upper"`
+		Suffix string `db:"suffix"`
+	}
+
+	var ranges []Range
+	if err := pgxscan.Select(ctx, tx, &ranges, query, cfg.From, cfg.To, cfg.Step); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(ranges) > maxCreatedPartitions {
+		return nil, fmt.Errorf(
+			"%w: more than %d partitions from %s to %s by %s %s",
+			ErrCreatePartitionsConfig, maxCreatedPartitions, cfg.From, cfg.To, cfg.Step, fnName,
+		)
+	}
+
+	created := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		partName := model.TableName{Schema: name.Schema, Table: model.PGIdentifier(name.Table.AsArgument() + "_" + r.Suffix)}
+		bound := fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", quoteLiteral(r.Lower), quoteLiteral(r.Upper))
+
+		ok, err := createPartition(ctx, tx, name, partName, bound)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+		if ok {
+			created = append(created, partName.String())
+		}
+	}
+
+	return created, nil
+}
+
+func (c *connect) createHashPartitions(
+	ctx context.Context,
+	tx pgx.Tx,
+	name model.TableName,
+	p partitioning,
+	cfg *config.CreatePartitions,
+) ([]string, error) {
+	const fnName = "create hash partitions"
+
+	bounds, ok := parseHashBounds(p.Partitions)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", schema.ErrUnsupportedPartitions, p.KeyDef, fnName)
+	}
+
+	modulus, missing := missingRemainders(bounds)
+	if modulus == 0 {
+		if _, err := fmt.Sscanf(cfg.Step, "%d", &modulus); err != nil || modulus <= 0 {
+			return nil, fmt.Errorf("%w: step must be the modulus for %s %s", ErrCreatePartitionsConfig, p.KeyDef, fnName)
+		}
+
+		missing = make([]int, modulus)
+		for r := range missing {
+			missing[r] = r
+		}
+	}
+
+	created := make([]string, 0, len(missing))
+	for _, r := range missing {
+		partName := model.TableName{
+			Schema: name.Schema,
+			Table:  model.PGIdentifier(fmt.Sprintf("%s_m%d_r%d", name.Table.AsArgument(), modulus, r)),
+		}
+		bound := fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", modulus, r)
+
+		ok, err := createPartition(ctx, tx, name, partName, bound)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+		if ok {
+			created = append(created, partName.String())
+		}
+	}
+
+	return created, nil
+}
+
+// createPartition returns false if the partition overlaps an existing one or its name is taken,
+// the savepoint keeps the transaction going after such a failure.
+func createPartition(ctx context.Context, tx pgx.Tx, parent, name model.TableName, bound string) (bool, error) {
+	const (
+		duplicateTable          = "42P07"
+		invalidObjectDefinition = "42P17"
+	)
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: create partition %s", err, name.Quoted())
+	}
+
+	_, err = savepoint.Exec(ctx, fmt.Sprintf("CREATE TABLE %s PARTITION OF %s %s", name.Quoted(), parent.Quoted(), bound))
+	if err == nil {
+		if err := savepoint.Commit(ctx); err != nil {
+			return false, fmt.Errorf("%w: create partition %s", err, name.Quoted())
+		}
+
+		return true, nil
+	}
+	_ = savepoint.Rollback(ctx)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == duplicateTable || pgErr.Code == invalidObjectDefinition) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%w: create partition %s", err, name.Quoted())
+}
+
+func isTimeType(tp string) bool {
+	return tp == "date" || strings.HasPrefix(tp, "timestamp")
+}
+
+func quoteLiteral(val string) string {
+	return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+}
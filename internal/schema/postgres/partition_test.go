@@ -0,0 +1,237 @@
+package postgres //nolint:testpackage // partitions are applied by unexported functions
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyPartitioning(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	newColumns := func() []model.TargetType {
+		return []model.TargetType{
+			{SourceName: model.PGIdentifier("created_at"), Type: model.Timestamp, SourceType: "timestamp"},
+			{SourceName: model.PGIdentifier("region"), Type: model.Text, SourceType: "text"},
+		}
+	}
+
+	monthly := func(name, from, to string) partition {
+		return partition{
+			Name:  name,
+			Bound: "FOR VALUES FROM ('" + from + "') TO ('" + to + "')",
+			Constraint: "((created_at IS NOT NULL) AND (created_at >= '" + from + "'::timestamp without time zone) " +
+				"AND (created_at < '" + to + "'::timestamp without time zone))",
+			IsPartitioned: false,
+		}
+	}
+	hash := func(bound string) partition {
+		return partition{Name: "h", Bound: bound, Constraint: "satisfies_hash_partition(...)", IsPartitioned: false}
+	}
+
+	//nolint:exhaustruct // ok for tests
+	testCases := []struct {
+		desc         string
+		partitioning partitioning
+
+		column      string
+		expected    []model.ColumnCheck
+		unsupported []string
+	}{
+		{
+			desc:         "plain_table",
+			partitioning: partitioning{Strategy: ""},
+			column:       "created_at",
+			expected:     nil,
+			unsupported:  nil,
+		},
+		{
+			desc: "range",
+			partitioning: partitioning{
+				Strategy: strategyRange, KeyDef: "RANGE (created_at)", KeyColumn: "created_at",
+				Partitions: []partition{
+					monthly("events_202401", "2024-01-01 00:00:00", "2024-02-01 00:00:00"),
+					monthly("events_202402", "2024-02-01 00:00:00", "2024-03-01 00:00:00"),
+				},
+			},
+			column: "created_at",
+			expected: []model.ColumnCheck{
+				{
+					Min: &model.CheckBound{Value: "2024-01-01 00:00:00", Inclusive: true},
+					Max: &model.CheckBound{Value: "2024-02-01 00:00:00", Inclusive: false},
+				},
+				{
+					Min: &model.CheckBound{Value: "2024-02-01 00:00:00", Inclusive: true},
+					Max: &model.CheckBound{Value: "2024-03-01 00:00:00", Inclusive: false},
+				},
+			},
+			unsupported: []string{},
+		},
+		{
+			desc: "list",
+			partitioning: partitioning{
+				Strategy: strategyList, KeyDef: "LIST (region)", KeyColumn: "region",
+				Partitions: []partition{
+					{
+						Name:          "events_eu",
+						Bound:         "FOR VALUES IN ('de', 'fr')",
+						Constraint:    "((region IS NOT NULL) AND (region = ANY (ARRAY['de'::text, 'fr'::text])))",
+						IsPartitioned: false,
+					},
+					{
+						Name:          "events_us",
+						Bound:         "FOR VALUES IN ('us')",
+						Constraint:    "((region IS NOT NULL) AND (region = 'us'::text))",
+						IsPartitioned: false,
+					},
+				},
+			},
+			column:      "region",
+			expected:    []model.ColumnCheck{{In: []string{"de", "fr"}}, {In: []string{"us"}}},
+			unsupported: []string{},
+		},
+		{
+			desc: "default_partition",
+			partitioning: partitioning{
+				Strategy: strategyRange, KeyDef: "RANGE (created_at)", KeyColumn: "created_at",
+				Partitions: []partition{
+					monthly("events_202401", "2024-01-01 00:00:00", "2024-02-01 00:00:00"),
+					{Name: "events_default", Bound: defaultBound, Constraint: "(NOT (...))", IsPartitioned: false},
+				},
+			},
+			column:      "created_at",
+			expected:    nil,
+			unsupported: []string{},
+		},
+		{
+			desc: "no_partitions",
+			partitioning: partitioning{
+				Strategy: strategyRange, KeyDef: "RANGE (created_at)", KeyColumn: "created_at", Partitions: nil,
+			},
+			column:      "created_at",
+			expected:    []model.ColumnCheck{},
+			unsupported: nil,
+		},
+		{
+			desc: "hash_covered",
+			partitioning: partitioning{
+				Strategy: strategyHash, KeyDef: "HASH (region)", KeyColumn: "region",
+				Partitions: []partition{
+					hash("FOR VALUES WITH (modulus 2, remainder 0)"),
+					hash("FOR VALUES WITH (modulus 4, remainder 1)"),
+					hash("FOR VALUES WITH (modulus 4, remainder 3)"),
+				},
+			},
+			column:      "region",
+			expected:    nil,
+			unsupported: []string{},
+		},
+		{
+			desc: "hash_missing_remainder",
+			partitioning: partitioning{
+				Strategy: strategyHash, KeyDef: "HASH (region)", KeyColumn: "region",
+				Partitions: []partition{
+					hash("FOR VALUES WITH (modulus 4, remainder 0)"),
+					hash("FOR VALUES WITH (modulus 4, remainder 1)"),
+				},
+			},
+			column:      "region",
+			expected:    nil,
+			unsupported: []string{"PARTITION BY HASH (region)"},
+		},
+		{
+			desc: "expression_key",
+			partitioning: partitioning{
+				Strategy: strategyRange, KeyDef: "RANGE (lower(region))", KeyColumn: "",
+				Partitions: []partition{monthly("events_202401", "2024-01-01 00:00:00", "2024-02-01 00:00:00")},
+			},
+			column:      "region",
+			expected:    nil,
+			unsupported: []string{"PARTITION BY RANGE (lower(region))"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			columns := newColumns()
+			unsupported := applyPartitioning(tC.partitioning, columns)
+			require.Equal(t, tC.unsupported, unsupported)
+
+			for _, col := range columns {
+				if col.SourceName.AsArgument() == tC.column {
+					require.Equal(t, tC.expected, col.Partitions)
+				}
+				require.Nil(t, col.Check)
+			}
+		})
+	}
+}
+
+func Test_missingRemainders(t *testing.T) {
+	t.Parallel()
+
+	modulus, missing := missingRemainders([]hashBound{{modulus: 2, remainder: 1}, {modulus: 8, remainder: 2}})
+	require.Equal(t, 8, modulus)
+	require.Equal(t, []int{0, 4, 6}, missing)
+
+	modulus, missing = missingRemainders(nil)
+	require.Zero(t, modulus)
+	require.Empty(t, missing)
+}
+
+func Test_CreateRangePartitions(t *testing.T) {
+	t.Parallel()
+
+	table := model.Table{
+		Name: model.TableName{
+			Schema: model.PGIdentifier("public"),
+			Table:  model.PGIdentifier("events"),
+		},
+		Columns: []model.Column{
+			{Name: model.PGIdentifier("id"), Type: "int8", IsNullable: true, FixedSize: 8},
+			{Name: model.PGIdentifier("created_at"), Type: "timestamp", IsNullable: false, FixedSize: 8},
+		},
+	}
+
+	setup := newPgInspectorTestSetup(t,
+		&table,
+		options.WithRangePartitions("created_at", [2]string{"2024-02-01", "2024-03-01"}),
+	)
+	ctx := t.Context()
+
+	cfg := &config.CreatePartitions{
+		From: "2024-01-01",
+		To:   "2024-04-01",
+		Step: "1 month",
+	}
+
+	// a dry run tells the same names and keeps none of them
+	planned, err := setup.connect.CreatePartitions(ctx, table.Name, cfg, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"public.events_20240101", "public.events_20240301"}, planned)
+
+	p, err := setup.connect.Partitioning(ctx, table.Name)
+	require.NoError(t, err)
+	require.Len(t, p.Partitions, 1)
+
+	created, err := setup.connect.CreatePartitions(ctx, table.Name, cfg, false)
+	require.NoError(t, err)
+	// the february partition overlaps the existing one
+	require.Equal(t, []string{"public.events_20240101", "public.events_20240301"}, created)
+
+	p, err = setup.connect.Partitioning(ctx, table.Name)
+	require.NoError(t, err)
+	require.Len(t, p.Partitions, 3)
+
+	//nolint:exhaustruct // ok for tests
+	columns := []model.TargetType{{SourceName: model.PGIdentifier("created_at"), Type: model.Timestamp}}
+	require.Empty(t, applyPartitioning(p, columns))
+	require.Len(t, columns[0].Partitions, 3)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+)
+
+// ColumnStats reads pg_stats of the table, statistics of the whole inheritance tree win over
+// the ones of the parent alone.
+func (c *connect) ColumnStats(ctx context.Context, name model.TableName) ([]model.ColumnStats, error) {
+	const fnName = "column stats"
+
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer conn.Close(ctx)
+
+	const query = `
+		SELECT DISTINCT ON (s.attname)
+			s.attname,
+			s.null_frac,
+			s.n_distinct,
+			COALESCE(s.most_common_vals::text::text[], '{}') AS most_common_vals,
+			COALESCE(s.most_common_freqs::float8[], '{}') AS most_common_freqs,
+			COALESCE(s.histogram_bounds::text::text[], '{}') AS histogram_bounds,
+			s.avg_width,
+			COALESCE(s.correlation, 0)::float8 AS correlation,
+			COALESCE(c.character_maximum_length, 0) AS max_length,
+			EXISTS (
+				SELECT FROM pg_constraint con
+				JOIN pg_attribute a
+					ON a.attrelid = con.conrelid AND a.attnum = ANY(con.conkey)
+				WHERE
+					con.contype = 'f'
+					AND con.conrelid = format('%I.%I', s.schemaname, s.tablename)::regclass
+					AND a.attname = s.attname
+			) AS foreign_key
+		FROM
+			pg_stats s
+		LEFT JOIN information_schema.columns c
+			ON c.table_schema = s.schemaname AND c.table_name = s.tablename AND c.column_name = s.attname
+		WHERE
+			s.schemaname = $1 AND s.tablename = $2
+		ORDER BY
+			s.attname, s.inherited DESC
+	`
+
+	type stats struct {
+		Column          string    `db:"attname"`
+		NullFrac        float64   `db:"null_frac"`
+		NDistinct       float64   `db:"n_distinct"`
+		MostCommonVals  []string  `db:"most_common_vals"`
+		MostCommonFreqs []float64 `db:"most_common_freqs"`
+		HistogramBounds []string  `db:"histogram_bounds"`
+		AvgWidth        int       `db:"avg_width"`
+		Correlation     float64   `db:"correlation"`
+		MaxLength       int       `db:"max_length"`
+		ForeignKey      bool      `db:"foreign_key"`
+	}
+
+	var rows []stats
+	if err := pgxscan.Select(ctx, conn, &rows, query, name.Schema.AsArgument(), name.Table.AsArgument()); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return lo.Map(rows, func(s stats, _ int) model.ColumnStats {
+		return model.ColumnStats{
+			Column:          model.PGIdentifier(s.Column),
+			NullFrac:        s.NullFrac,
+			NDistinct:       s.NDistinct,
+			MostCommonVals:  s.MostCommonVals,
+			MostCommonFreqs: s.MostCommonFreqs,
+			HistogramBounds: s.HistogramBounds,
+			AvgWidth:        s.AvgWidth,
+			Correlation:     s.Correlation,
+			MaxLength:       s.MaxLength,
+			ForeignKey:      s.ForeignKey,
+		}
+	}), nil
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrTooDeepType = errors.New("type nests too deep")
+
+// maxTypeDepth bounds domains over domains and composites inside composites.
+const maxTypeDepth = 16
+
+// userType is a type with its domains resolved down to the base type.
+type userType struct {
+	// Name is the attribute name of a composite field, empty for columns
+	Name     string
+	BaseType string
+	TypeLen  int
+	ElemType string
+	ElemLen  sql.NullInt64
+	// NotNull is set by a NOT NULL domain or attribute
+	NotNull bool
+	// Checks are the CHECK constraints of every domain on the way, VALUE stands for the value there
+	Checks []string
+	// CompositeSchema is set for composite base types, Fields are their attributes then
+	CompositeSchema string
+	Fields          []userType
+}
+
+// UserTypes resolves columns typed with domains and composites, or arrays of them, by column name.
+func (c *connect) UserTypes(ctx context.Context, name model.TableName) (map[string]userType, error) {
+	const fnName = "user types"
+
+	conn, err := pgx.ConnectConfig(ctx, c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer conn.Close(ctx)
+
+	const query = `
+		SELECT
+			a.attname, a.atttypid
+		FROM
+			pg_attribute a
+		JOIN
+			pg_class c ON c.oid = a.attrelid
+		JOIN
+			pg_namespace n ON n.oid = c.relnamespace
+		JOIN
+			pg_type t ON t.oid = a.atttypid
+		LEFT JOIN
+			pg_type e ON e.oid = t.typelem AND t.typcategory = 'A'
+		WHERE
+			n.nspname = $1 AND c.relname = $2
+			AND a.attnum > 0 AND NOT a.attisdropped
+			AND (t.typtype IN ('d', 'c') OR e.typtype IN ('d', 'c'))
+	`
+
+	rows, err := conn.Query(ctx, query, name.Schema.AsArgument(), name.Table.AsArgument())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columnTypes := make(map[string]uint32)
+	for rows.Next() {
+		var (
+			column string
+			oid    uint32
+		)
+		if err := rows.Scan(&column, &oid); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		columnTypes[column] = oid
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	resolved := make(map[string]userType, len(columnTypes))
+	for column, oid := range columnTypes {
+		ut, err := c.resolveType(ctx, conn, oid, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s", err, column, fnName)
+		}
+
+		resolved[column] = ut
+	}
+
+	return resolved, nil
+}
+
+func (c *connect) resolveType(ctx context.Context, conn *pgx.Conn, oid uint32, depth int) (userType, error) {
+	const fnName = "resolve type"
+
+	const query = `
+		SELECT
+			t.typname, t.typtype, t.typbasetype, t.typnotnull, t.typlen, t.typrelid,
+			CASE WHEN t.typcategory = 'A' THEN t.typelem ELSE 0::oid END AS elem,
+			n.nspname,
+			ARRAY(
+				SELECT pg_get_constraintdef(con.oid) FROM pg_constraint con
+				WHERE con.contypid = t.oid AND con.contype = 'c' ORDER BY con.conname
+			) AS checks
+		FROM
+			pg_type t
+		JOIN
+			pg_namespace n ON n.oid = t.typnamespace
+		WHERE
+			t.oid = $1
+	`
+
+	var ut userType
+	for ; depth < maxTypeDepth; depth++ {
+		var (
+			typName, typType, nspName string
+			baseOID, relID, elemOID   uint32
+			notNull                   bool
+			typLen                    int
+			checks                    []string
+		)
+		err := conn.QueryRow(ctx, query, oid).
+			Scan(&typName, &typType, &baseOID, &notNull, &typLen, &relID, &elemOID, &nspName, &checks)
+		if err != nil {
+			return userType{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if typType == "d" {
+			ut.NotNull = ut.NotNull || notNull
+			ut.Checks = append(ut.Checks, checks...)
+			oid = baseOID
+
+			continue
+		}
+
+		ut.BaseType, ut.TypeLen = typName, typLen
+		switch {
+		case typType == "c":
+			fields, err := c.resolveFields(ctx, conn, relID, depth+1)
+			if err != nil {
+				return userType{}, fmt.Errorf("%w: %s %s", err, typName, fnName)
+			}
+
+			ut.CompositeSchema, ut.Fields = nspName, fields
+		case elemOID != 0:
+			elem, err := c.resolveType(ctx, conn, elemOID, depth+1)
+			if err != nil {
+				return userType{}, fmt.Errorf("%w: %s %s", err, typName, fnName)
+			}
+
+			// the array type of the base type is named after it with a leading underscore
+			ut.BaseType = "_" + elem.BaseType
+			ut.ElemType, ut.ElemLen = elem.BaseType, sql.NullInt64{Int64: int64(elem.TypeLen), Valid: true}
+		}
+
+		return ut, nil
+	}
+
+	return userType{}, fmt.Errorf("%w: %s", ErrTooDeepType, fnName)
+}
+
+func (c *connect) resolveFields(ctx context.Context, conn *pgx.Conn, relID uint32, depth int) ([]userType, error) {
+	const fnName = "resolve fields"
+
+	const query = `
+		SELECT
+			attname, atttypid, attnotnull
+		FROM
+			pg_attribute
+		WHERE
+			attrelid = $1 AND attnum > 0 AND NOT attisdropped
+		ORDER BY
+			attnum
+	`
+
+	rows, err := conn.Query(ctx, query, relID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	type attribute struct {
+		name    string
+		oid     uint32
+		notNull bool
+	}
+
+	attributes := make([]attribute, 0)
+	for rows.Next() {
+		var attr attribute
+		if err := rows.Scan(&attr.name, &attr.oid, &attr.notNull); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		attributes = append(attributes, attr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	fields := make([]userType, 0, len(attributes))
+	for _, attr := range attributes {
+		field, err := c.resolveType(ctx, conn, attr.oid, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s", err, attr.name, fnName)
+		}
+
+		field.Name = attr.name
+		field.NotNull = field.NotNull || attr.notNull
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,68 @@
+package postgres //nolint:testpackage // user types are applied by unexported functions
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_withUserType(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	column := func(name string) model.TargetType {
+		return model.TargetType{
+			SourceName: model.PGIdentifier(name), Type: model.DriverSpecified, SourceType: name, IsNullable: true,
+		}
+	}
+
+	//nolint:exhaustruct // ok for tests
+	address := userType{
+		BaseType: "address", TypeLen: -1, CompositeSchema: "public",
+		Fields: []userType{
+			{Name: "street", BaseType: "varchar", TypeLen: -1, Checks: []string{"CHECK ((length((VALUE)::text) > 3))"}},
+			{Name: "zip", BaseType: "int4", TypeLen: 4, NotNull: true, Checks: []string{"CHECK ((VALUE > 0))"}},
+			{Name: "tags", BaseType: "_text", TypeLen: -1, ElemType: "text", ElemLen: sql.NullInt64{Int64: -1, Valid: true}},
+		},
+	}
+	//nolint:exhaustruct // ok for tests
+	email := userType{BaseType: "text", TypeLen: -1, NotNull: true, Checks: []string{
+		"CHECK ((VALUE ~* '^.+@.+$'::text))",
+		"CHECK ((length(VALUE) <= 64))",
+	}}
+
+	columns := []model.TargetType{
+		withUserType(column("home"), address),
+		withUserType(column("email"), email),
+	}
+	unsupported := applyUserTypeChecks(model.PGIdentifier("home"), address, columns)
+	unsupported = append(unsupported, applyUserTypeChecks(model.PGIdentifier("email"), email, columns)...)
+
+	require.Equal(t, []string{"CHECK ((VALUE ~* '^.+@.+$'::text)) of email"}, unsupported)
+
+	home := columns[0]
+	require.Equal(t, model.Composite, home.Type)
+	require.Equal(t, "public.address", home.SourceType)
+	require.Equal(t, model.TableName{
+		Schema: model.PGIdentifier("public"),
+		Table:  model.PGIdentifier("address"),
+	}, home.Composite.Name)
+
+	fields := home.Composite.Fields
+	require.Len(t, fields, 3)
+	require.Equal(t, model.Text, fields[0].Type)
+	require.Equal(t, 4, lo.FromPtr(lo.FromPtr(fields[0].Check).MinLength))
+	require.Equal(t, model.Integer, fields[1].Type)
+	require.False(t, fields[1].IsNullable)
+	require.Equal(t, &model.CheckBound{Value: "0", Inclusive: false}, lo.FromPtr(fields[1].Check).Min)
+	require.Equal(t, model.Array, fields[2].Type)
+	require.Equal(t, model.ArrayInfo{ElemType: model.Text, SourceType: "text", ElemSize: -1}, fields[2].ArrayElem)
+
+	require.Equal(t, model.Text, columns[1].Type)
+	require.False(t, columns[1].IsNullable)
+	require.Equal(t, 64, lo.FromPtr(lo.FromPtr(columns[1].Check).MaxLength))
+}
@@ -0,0 +1,362 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
+
+	"github.com/samber/lo"
+	_ "modernc.org/sqlite" // register driver
+)
+
+type connect struct {
+	dsn string
+}
+
+func newConnect(dsn string) *connect {
+	return &connect{dsn: dsn}
+}
+
+func (c *connect) open() (*sql.DB, error) {
+	db, err := sql.Open("sqlite", c.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: open", err)
+	}
+
+	return db, nil
+}
+
+func (c *connect) Table(ctx context.Context, name model.TableName) (model.Table, error) {
+	db, err := c.open()
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table", err)
+	}
+	defer db.Close()
+
+	tables, err := c.resolveTableNames(ctx, db, name.Table.AsArgument(), name.Schema.AsArgument())
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	if len(tables) == 0 {
+		return model.Table{}, fmt.Errorf("%w: table %s", schema.ErrEntityNotFound, name.Quoted())
+	}
+
+	columns, rowID, err := c.selectTableColumns(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	uniqueIndexes, err := c.selectUniqueConstraints(ctx, db, name)
+	if err != nil {
+		return model.Table{}, fmt.Errorf("%w: table %s", err, name.Quoted())
+	}
+
+	if rowID.Valid {
+		uniqueIndexes = append(uniqueIndexes, []model.Identifier{model.SQLiteIdentifier(rowID.String)})
+	}
+
+	return model.Table{
+		Name:          name,
+		Columns:       columns,
+		UniqueIndexes: uniqueIndexes,
+		Checks:        nil,
+	}, nil
+}
+
+// fixedSizes holds byte sizes of declared types generators rely on,
+// sqlite stores every integer as int64, so they only narrow generated ranges.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var fixedSizes = map[string]int{
+	"tinyint": 1, "smallint": 2, "int2": 2, "mediumint": 4, "int": 4, "int4": 4,
+	"integer": 8, "bigint": 8, "int8": 8,
+	"real": 8, "double": 8, "double precision": 8, "float": 8,
+	"date": 4, "datetime": 8, "timestamp": 8,
+}
+
+// BaseType lowercases a declared type and strips its modifiers, VARCHAR(20) becomes varchar.
+func BaseType(declared string) string {
+	base, _, _ := strings.Cut(strings.ToLower(declared), "(")
+
+	return strings.Join(strings.Fields(base), " ")
+}
+
+// selectTableColumns also returns the rowid alias column if the table has one.
+func (c *connect) selectTableColumns(
+	ctx context.Context,
+	db *sql.DB,
+	name model.TableName,
+) ([]model.Column, sql.NullString, error) {
+	const fnName = "select table columns"
+
+	// hidden is 2 and 3 for virtual and stored generated columns, 1 is a hidden column of a virtual table
+	const query = `
+		SELECT
+			name, type, "notnull", pk, hidden IN (2, 3) AS is_generated, dflt_value IS NOT NULL AS has_default
+		FROM
+			pragma_table_xinfo(?, ?)
+		WHERE
+			hidden <> 1
+		ORDER BY
+			cid
+	`
+
+	rows, err := db.QueryContext(ctx, query, name.Table.AsArgument(), name.Schema.AsArgument())
+	if err != nil {
+		return nil, sql.NullString{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	var (
+		columns = make([]model.Column, 0)
+		pks     = make([]string, 0)
+		rowID   sql.NullString
+	)
+	for rows.Next() {
+		var (
+			columnName, declared    string
+			notNull                 bool
+			pk                      int
+			isGenerated, hasDefault bool
+		)
+		if err := rows.Scan(&columnName, &declared, &notNull, &pk, &isGenerated, &hasDefault); err != nil {
+			return nil, sql.NullString{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if pk > 0 {
+			pks = append(pks, declared)
+			rowID = sql.NullString{String: columnName, Valid: true}
+		}
+
+		baseType := BaseType(declared)
+		columns = append(columns, model.Column{
+			Name:         model.SQLiteIdentifier(columnName),
+			IsNullable:   !notNull && pk == 0,
+			Type:         baseType,
+			FixedSize:    lo.ValueOr(fixedSizes, baseType, -1),
+			ElemSizeByte: sql.NullInt64{Int64: 0, Valid: false},
+			Generated:    isGenerated,
+			HasDefault:   hasDefault,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sql.NullString{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// only a single INTEGER PRIMARY KEY aliases rowid, other primary keys are backed by an index
+	if len(pks) != 1 || !strings.EqualFold(pks[0], "integer") {
+		rowID = sql.NullString{}
+	}
+
+	// the rowid alias is filled by the database just like a default
+	if rowID.Valid {
+		for i := range columns {
+			if columns[i].Name.AsArgument() == rowID.String {
+				columns[i].HasDefault = true
+			}
+		}
+	}
+
+	return columns, rowID, nil
+}
+
+func (c *connect) selectUniqueConstraints(
+	ctx context.Context,
+	db *sql.DB,
+	name model.TableName,
+) ([][]model.Identifier, error) {
+	const fnName = "select unique constraints"
+
+	const query = `
+		SELECT
+			il.name, ii.name
+		FROM
+			pragma_index_list(?, ?) il JOIN pragma_index_info(il.name, ?) ii
+		WHERE
+			il."unique" = 1
+		ORDER BY
+			il.name, ii.seqno
+	`
+
+	rows, err := db.QueryContext(
+		ctx, query,
+		name.Table.AsArgument(), name.Schema.AsArgument(), name.Schema.AsArgument(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	var (
+		indexes     [][]model.Identifier
+		expressions = make(map[int]bool)
+		last        string
+	)
+	for rows.Next() {
+		var (
+			indexName  string
+			columnName sql.NullString
+		)
+		if err := rows.Scan(&indexName, &columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if len(indexes) == 0 || indexName != last {
+			indexes = append(indexes, nil)
+			last = indexName
+		}
+
+		if !columnName.Valid {
+			expressions[len(indexes)-1] = true
+
+			continue
+		}
+		indexes[len(indexes)-1] = append(indexes[len(indexes)-1], model.SQLiteIdentifier(columnName.String))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	// indexes over expressions can't be checked by columns values
+	return lo.Filter(indexes, func(_ []model.Identifier, idx int) bool {
+		return !expressions[idx]
+	}), nil
+}
+
+// ResolveTableNames looks the table up in the given schema, in every attached one if it's empty.
+func (c *connect) ResolveTableNames(ctx context.Context, name, schemaName string) ([]model.TableName, error) {
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: resolve table names", err)
+	}
+	defer db.Close()
+
+	return c.resolveTableNames(ctx, db, name, schemaName)
+}
+
+func (c *connect) resolveTableNames(
+	ctx context.Context,
+	db *sql.DB,
+	name, schemaName string,
+) ([]model.TableName, error) {
+	const fnName = "resolve table names"
+	const query = `
+		SELECT
+			schema, name
+		FROM
+			pragma_table_list
+		WHERE
+			name = ? AND (? = '' OR schema = ?) AND type = 'table'
+	`
+
+	rows, err := db.QueryContext(ctx, query, name, schemaName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.SQLiteIdentifier(tableSchema),
+			Table:  model.SQLiteIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+// SchemaTables lists tables of the schema, main if it's empty; sqlite's internal tables are skipped.
+func (c *connect) SchemaTables(ctx context.Context, schemaName string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+	const query = `
+		SELECT
+			schema, name
+		FROM
+			pragma_table_list
+		WHERE
+			schema = COALESCE(NULLIF(?, ''), 'main') AND type = 'table' AND name NOT LIKE 'sqlite\_%' ESCAPE '\'
+		ORDER BY
+			name
+	`
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	tables := make([]model.TableName, 0)
+	for rows.Next() {
+		var tableSchema, tableName string
+		if err := rows.Scan(&tableSchema, &tableName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		tables = append(tables, model.TableName{
+			Schema: model.SQLiteIdentifier(tableSchema),
+			Table:  model.SQLiteIdentifier(tableName),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return tables, nil
+}
+
+func (c *connect) ResolveColumnNames(ctx context.Context, name model.TableName, column string) ([]model.Identifier, error) {
+	const fnName = "resolve column names"
+	const query = "SELECT name FROM pragma_table_info(?, ?) WHERE name = ?"
+
+	db, err := c.open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, query, name.Table.AsArgument(), name.Schema.AsArgument(), column)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+	defer rows.Close()
+
+	columns := make([]model.Identifier, 0)
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		columns = append(columns, model.SQLiteIdentifier(columnName))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,156 @@
+package sqlite //nolint:testpackage // connect isn't exported
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	testsqlite "github.com/jmozgit/datagen/internal/pkg/testconn/sqlite"
+	"github.com/jmozgit/datagen/internal/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConnect(t *testing.T, table *model.Table, opts ...options.CreateTableOption) *connect {
+	t.Helper()
+
+	conn, err := testsqlite.New(t)
+	require.NoError(t, err)
+
+	if table != nil {
+		require.NoError(t, conn.CreateTable(t.Context(), *table, opts...))
+	}
+
+	return newConnect(conn.SQLiteDatabase().DSN())
+}
+
+func Test_UnknownTable(t *testing.T) {
+	t.Parallel()
+
+	c := newTestConnect(t, nil)
+	_, err := c.Table(t.Context(), model.TableName{
+		Schema: model.SQLiteIdentifier("main"),
+		Table:  model.SQLiteIdentifier("unknown"),
+	})
+	require.ErrorIs(t, err, schema.ErrEntityNotFound)
+}
+
+func Test_ColumnsAndUniqueIndexes(t *testing.T) {
+	t.Parallel()
+
+	name := model.TableName{
+		Schema: model.SQLiteIdentifier("main"),
+		Table:  model.SQLiteIdentifier("users"),
+	}
+	c := newTestConnect(t, &model.Table{
+		Name: name,
+		Columns: []model.Column{
+			{Name: model.SQLiteIdentifier("id"), Type: "INTEGER"},
+			{Name: model.SQLiteIdentifier("login"), Type: "VARCHAR(20) NOT NULL UNIQUE"},
+			{Name: model.SQLiteIdentifier("score"), Type: "double precision"},
+			{Name: model.SQLiteIdentifier("note"), Type: ""},
+		},
+		UniqueIndexes: nil,
+	}, options.WithPKs([]string{"id"}))
+
+	actual, err := c.Table(t.Context(), name)
+	require.NoError(t, err)
+
+	noElem := sql.NullInt64{Int64: 0, Valid: false}
+	require.Equal(t, []model.Column{
+		{
+			Name: model.SQLiteIdentifier("id"), IsNullable: false, Type: "integer", FixedSize: 8, ElemSizeByte: noElem,
+			HasDefault: true,
+		},
+		{Name: model.SQLiteIdentifier("login"), IsNullable: false, Type: "varchar", FixedSize: -1, ElemSizeByte: noElem},
+		{Name: model.SQLiteIdentifier("score"), IsNullable: true, Type: "double precision", FixedSize: 8, ElemSizeByte: noElem},
+		{Name: model.SQLiteIdentifier("note"), IsNullable: true, Type: "", FixedSize: -1, ElemSizeByte: noElem},
+	}, actual.Columns)
+	require.ElementsMatch(t, [][]model.Identifier{
+		{model.SQLiteIdentifier("login")},
+		{model.SQLiteIdentifier("id")},
+	}, actual.UniqueIndexes)
+}
+
+func Test_CompositePrimaryKeyIsNotRowID(t *testing.T) {
+	t.Parallel()
+
+	name := model.TableName{
+		Schema: model.SQLiteIdentifier("main"),
+		Table:  model.SQLiteIdentifier("pairs"),
+	}
+	c := newTestConnect(t, &model.Table{
+		Name: name,
+		Columns: []model.Column{
+			{Name: model.SQLiteIdentifier("a"), Type: "integer"},
+			{Name: model.SQLiteIdentifier("b"), Type: "integer"},
+		},
+		UniqueIndexes: nil,
+	}, options.WithPKs([]string{"a", "b"}))
+
+	actual, err := c.Table(t.Context(), name)
+	require.NoError(t, err)
+	require.Equal(t, [][]model.Identifier{
+		{model.SQLiteIdentifier("a"), model.SQLiteIdentifier("b")},
+	}, actual.UniqueIndexes)
+}
+
+func Test_SchemaTablesSkipsInternal(t *testing.T) {
+	t.Parallel()
+
+	conn, err := testsqlite.New(t)
+	require.NoError(t, err)
+
+	for _, name := range []string{"orders", "accounts"} {
+		require.NoError(t, conn.CreateTable(t.Context(), model.Table{
+			Name: model.TableName{Schema: model.SQLiteIdentifier("main"), Table: model.SQLiteIdentifier(name)},
+			Columns: []model.Column{
+				// autoincrement makes sqlite create its sqlite_sequence table
+				{Name: model.SQLiteIdentifier("id"), Type: "INTEGER PRIMARY KEY AUTOINCREMENT"},
+			},
+			UniqueIndexes: nil,
+		}))
+	}
+
+	c := newConnect(conn.SQLiteDatabase().DSN())
+	tables, err := c.SchemaTables(t.Context(), "")
+	require.NoError(t, err)
+	require.Equal(t, []model.TableName{
+		{Schema: model.SQLiteIdentifier("main"), Table: model.SQLiteIdentifier("accounts")},
+		{Schema: model.SQLiteIdentifier("main"), Table: model.SQLiteIdentifier("orders")},
+	}, tables)
+}
+
+func Test_GeneratedAndDefaultColumns(t *testing.T) {
+	t.Parallel()
+
+	name := model.TableName{
+		Schema: model.SQLiteIdentifier("main"),
+		Table:  model.SQLiteIdentifier("prices"),
+	}
+	c := newTestConnect(t, &model.Table{
+		Name: name,
+		Columns: []model.Column{
+			{Name: model.SQLiteIdentifier("amount"), Type: "INTEGER NOT NULL"},
+			{Name: model.SQLiteIdentifier("currency"), Type: "TEXT NOT NULL DEFAULT 'EUR'"},
+			{Name: model.SQLiteIdentifier("doubled"), Type: "INTEGER GENERATED ALWAYS AS (amount * 2) STORED"},
+			{Name: model.SQLiteIdentifier("tripled"), Type: "INTEGER AS (amount * 3)"},
+		},
+		UniqueIndexes: nil,
+	})
+
+	actual, err := c.Table(t.Context(), name)
+	require.NoError(t, err)
+
+	flags := make(map[string][2]bool, len(actual.Columns))
+	for _, col := range actual.Columns {
+		flags[col.Name.AsArgument()] = [2]bool{col.Generated, col.HasDefault}
+	}
+	require.Equal(t, map[string][2]bool{
+		"amount":   {false, false},
+		"currency": {false, true},
+		"doubled":  {true, false},
+		"tripled":  {true, false},
+	}, flags)
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/schema"
+)
+
+// Inspector reads table definitions with table-valued pragmas, a schema is an attached database.
+type Inspector struct {
+	connect *connect
+}
+
+func NewInspector(conn *config.SQLiteDatabase) *Inspector {
+	return &Inspector{connect: newConnect(conn.DSN())}
+}
+
+// sqliteRegistryTypes maps declared types, sqlite accepts any name and only derives an affinity from it.
+//
+//nolint:gochecknoglobals // more convenient that constants here
+var sqliteRegistryTypes = map[string]model.CommonType{
+	"tinyint": model.Integer, "smallint": model.Integer, "int2": model.Integer, "mediumint": model.Integer,
+	"int": model.Integer, "int4": model.Integer, "integer": model.Integer, "bigint": model.Integer, "int8": model.Integer,
+	"real": model.Float, "double": model.Float, "double precision": model.Float, "float": model.Float,
+	"numeric": model.Float, "decimal": model.Float,
+	"datetime": model.Timestamp, "timestamp": model.Timestamp, "date": model.Date,
+	"text": model.Text, "clob": model.Text, "char": model.Text, "character": model.Text,
+	"varchar": model.Text, "varying character": model.Text, "nchar": model.Text, "nvarchar": model.Text,
+}
+
+func (i *Inspector) TableIdentifier(ctx context.Context, table *config.Table) (model.TableName, error) {
+	const fnName = "table identifier"
+
+	matchedTables, err := i.connect.ResolveTableNames(ctx, table.Table, table.Schema)
+	if err != nil {
+		return model.TableName{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	switch {
+	case len(matchedTables) == 0:
+		return model.TableName{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s",
+			schema.ErrEntityNotFound, fnName,
+			table.Schema, table.Table,
+		)
+	case len(matchedTables) > 1:
+		return model.TableName{}, fmt.Errorf(
+			"%w: %s try to specify schema for %s",
+			schema.ErrTooManyTablesMatched, fnName, table.Table,
+		)
+	default:
+		return matchedTables[0], nil
+	}
+}
+
+func (i *Inspector) SchemaTables(ctx context.Context, schemaName string) ([]model.TableName, error) {
+	const fnName = "schema tables"
+
+	tables, err := i.connect.SchemaTables(ctx, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("%w: %s schema: %s", schema.ErrEntityNotFound, fnName, schemaName)
+	}
+
+	return tables, nil
+}
+
+func (i *Inspector) ColumnIdentifier(ctx context.Context, tableName model.TableName, column string) (model.Identifier, error) {
+	const fnName = "column identifier"
+
+	columns, err := i.connect.ResolveColumnNames(ctx, tableName, column)
+	if err != nil {
+		return model.Identifier{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	switch {
+	case len(columns) == 0:
+		return model.Identifier{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s column: %s",
+			schema.ErrEntityNotFound, fnName,
+			tableName.Schema.AsArgument(), tableName.Table.AsArgument(), column,
+		)
+	case len(columns) > 1:
+		return model.Identifier{}, fmt.Errorf(
+			"%w: %s schema: %s table: %s column: %s",
+			schema.ErrTooManyColumnsMatched, fnName,
+			tableName.Schema.AsArgument(), tableName.Table.AsArgument(), column,
+		)
+	default:
+		return columns[0], nil
+	}
+}
+
+func (i *Inspector) Table(ctx context.Context, name model.TableName) (model.DatasetSchema, error) {
+	const fnName = "table"
+
+	table, err := i.connect.Table(ctx, name)
+	if err != nil {
+		return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	dataTypes := make([]model.TargetType, len(table.Columns))
+	for i, col := range table.Columns {
+		tp, ok := sqliteRegistryTypes[col.Type]
+		if !ok {
+			tp = model.DriverSpecified
+		}
+
+		dataTypes[i] = model.TargetType{
+			SourceName: col.Name,
+			SourceType: col.Type,
+			Type:       tp,
+			IsNullable: col.IsNullable,
+			FixedSize:  col.FixedSize,
+			ArrayElem:  model.ArrayInfo{ElemType: 0, SourceType: "", ElemSize: 0},
+			Composite:  model.CompositeInfo{Name: model.TableName{}, Fields: nil},
+			Generated:  col.Generated,
+			HasDefault: col.HasDefault,
+			Check:      nil,
+			Partitions: nil,
+		}
+	}
+
+	return model.DatasetSchema{
+		TableName:         name,
+		Columns:           dataTypes,
+		UniqueConstraints: table.UniqueIndexes,
+		ColumnOrders:      nil,
+		UnsupportedChecks: nil,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package taskbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var (
+	ErrCycledColumns     = errors.New("derived columns read each other in a cycle")
+	ErrUnknownDependency = errors.New("derived column reads a column that isn't generated")
+)
+
+// rowOrder orders columns so that derived ones are generated after the columns they read,
+// and maps names derived generators use to column indexes. Both are nil without derived columns.
+func (t *tableTaskBuilder) rowOrder(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	flows []findGeneratorFlow,
+) ([]int, map[string]int, error) {
+	const fnName = "row order"
+
+	indexByID := make(map[model.Identifier]int, len(dataset.Columns))
+	for i, col := range dataset.Columns {
+		indexByID[col.SourceName] = i
+	}
+
+	deps := make([][]int, len(flows))
+	rowColumns := make(map[string]int)
+	for i, flow := range flows {
+		derived, ok := flow.Gen.(model.DerivedGenerator)
+		if !ok {
+			continue
+		}
+
+		for _, name := range derived.DependsOn() {
+			id, err := t.schemaProvider.ColumnIdentifier(ctx, dataset.TableName, name)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: %s", err, fnName)
+			}
+
+			idx, ok := indexByID[id]
+			if !ok {
+				return nil, nil, fmt.Errorf(
+					"%w: %s reads %s %s",
+					ErrUnknownDependency, dataset.Columns[i].SourceName.AsArgument(), name, fnName,
+				)
+			}
+
+			rowColumns[name] = idx
+			deps[i] = append(deps[i], idx)
+		}
+	}
+
+	if len(rowColumns) == 0 {
+		return nil, nil, nil
+	}
+
+	names := make([]string, len(dataset.Columns))
+	for i, col := range dataset.Columns {
+		names[i] = col.SourceName.AsArgument()
+	}
+
+	order, err := columnOrder(names, deps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s %s", err, dataset.TableName.Quoted(), fnName)
+	}
+
+	return order, rowColumns, nil
+}
+
+// columnOrder sorts column indexes so that every column goes after the ones it depends on.
+func columnOrder(names []string, deps [][]int) ([]int, error) {
+	out := make([]int, 0, len(deps))
+	visited := make([]bool, len(deps))
+	inProgress := make([]bool, len(deps))
+
+	var visit func(int) error
+	visit = func(idx int) error {
+		if visited[idx] {
+			return nil
+		}
+
+		if inProgress[idx] {
+			return fmt.Errorf("%w: %s", ErrCycledColumns, names[idx])
+		}
+
+		inProgress[idx] = true
+		for _, d := range deps[idx] {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+
+		visited[idx] = true
+		out = append(out, idx)
+
+		return nil
+	}
+
+	for idx := range deps {
+		if err := visit(idx); err != nil {
+			return nil, fmt.Errorf("%w: column order", err)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,21 @@
+package taskbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_columnOrder(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"total", "qty", "price", "note"}
+
+	order, err := columnOrder(names, [][]int{{1, 2}, nil, nil, {0}})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 0, 3}, order)
+
+	_, err = columnOrder(names, [][]int{{3}, nil, nil, {0}})
+	require.ErrorIs(t, err, ErrCycledColumns)
+	require.ErrorContains(t, err, "total")
+}
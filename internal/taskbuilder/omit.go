@@ -0,0 +1,76 @@
+package taskbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+)
+
+var (
+	ErrGeneratedColumnSettings = errors.New("generated columns are filled by the database, settings aren't allowed")
+	ErrNoDefaultValue          = errors.New("column has no default and doesn't accept nulls")
+	ErrNothingToInsert         = errors.New("every column is filled by the database")
+)
+
+// omitColumns leaves out columns the database fills itself: generated ones and ones set to type: default.
+func (t *tableTaskBuilder) omitColumns(
+	ctx context.Context,
+	dataset model.DatasetSchema,
+	target *config.Table,
+) (model.DatasetSchema, error) {
+	const fnName = "omit columns"
+
+	configured := make(map[model.Identifier]bool, len(target.Generators))
+	defaulted := make(map[model.Identifier]bool)
+	for _, settings := range target.Generators {
+		id, err := t.schemaProvider.ColumnIdentifier(ctx, dataset.TableName, settings.Column)
+		if err != nil {
+			return model.DatasetSchema{}, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		configured[id] = true
+		if settings.Type == config.GeneratorTypeDefault {
+			defaulted[id] = true
+		}
+	}
+
+	return omitDatabaseFilled(dataset, configured, defaulted)
+}
+
+func omitDatabaseFilled(
+	dataset model.DatasetSchema,
+	configured, defaulted map[model.Identifier]bool,
+) (model.DatasetSchema, error) {
+	const fnName = "omit database filled"
+
+	columns := make([]model.TargetType, 0, len(dataset.Columns))
+	for _, col := range dataset.Columns {
+		switch {
+		case col.Generated && configured[col.SourceName] && !defaulted[col.SourceName]:
+			return model.DatasetSchema{}, fmt.Errorf(
+				"%w: %s %s", ErrGeneratedColumnSettings, col.SourceName.AsArgument(), fnName,
+			)
+		case col.Generated:
+			continue
+		case defaulted[col.SourceName] && !col.HasDefault && !col.IsNullable:
+			return model.DatasetSchema{}, fmt.Errorf(
+				"%w: %s %s", ErrNoDefaultValue, col.SourceName.AsArgument(), fnName,
+			)
+		case defaulted[col.SourceName]:
+			continue
+		}
+
+		columns = append(columns, col)
+	}
+
+	if len(columns) == 0 {
+		return model.DatasetSchema{}, fmt.Errorf("%w: %s %s", ErrNothingToInsert, dataset.TableName.Quoted(), fnName)
+	}
+
+	dataset.Columns = columns
+
+	return dataset, nil
+}
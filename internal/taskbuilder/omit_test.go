@@ -0,0 +1,108 @@
+package taskbuilder
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_omitDatabaseFilled(t *testing.T) {
+	t.Parallel()
+
+	id, total, note, login := model.PGIdentifier("id"), model.PGIdentifier("total"),
+		model.PGIdentifier("note"), model.PGIdentifier("login")
+
+	//nolint:exhaustruct // ok for tests
+	dataset := model.DatasetSchema{
+		TableName: model.TableName{Schema: model.PGIdentifier("public"), Table: model.PGIdentifier("orders")},
+		Columns: []model.TargetType{
+			{SourceName: id, HasDefault: true},
+			{SourceName: total, Generated: true, HasDefault: true},
+			{SourceName: note, IsNullable: true},
+			{SourceName: login},
+		},
+	}
+	names := func(ds model.DatasetSchema) []model.Identifier {
+		out := make([]model.Identifier, 0, len(ds.Columns))
+		for _, col := range ds.Columns {
+			out = append(out, col.SourceName)
+		}
+
+		return out
+	}
+
+	testCases := []struct {
+		desc       string
+		configured []model.Identifier
+		defaulted  []model.Identifier
+
+		expected    []model.Identifier
+		expectedErr error
+	}{
+		{
+			desc:        "generated_skipped",
+			configured:  nil,
+			defaulted:   nil,
+			expected:    []model.Identifier{id, note, login},
+			expectedErr: nil,
+		},
+		{
+			desc:        "defaults_fire",
+			configured:  []model.Identifier{id, note},
+			defaulted:   []model.Identifier{id, note},
+			expected:    []model.Identifier{login},
+			expectedErr: nil,
+		},
+		{
+			desc:        "generated_set_to_default",
+			configured:  []model.Identifier{total},
+			defaulted:   []model.Identifier{total},
+			expected:    []model.Identifier{id, note, login},
+			expectedErr: nil,
+		},
+		{
+			desc:        "generated_with_settings",
+			configured:  []model.Identifier{total},
+			defaulted:   nil,
+			expected:    nil,
+			expectedErr: ErrGeneratedColumnSettings,
+		},
+		{
+			desc:        "not_null_without_default",
+			configured:  []model.Identifier{login},
+			defaulted:   []model.Identifier{login},
+			expected:    nil,
+			expectedErr: ErrNoDefaultValue,
+		},
+	}
+
+	set := func(ids []model.Identifier) map[model.Identifier]bool {
+		out := make(map[model.Identifier]bool, len(ids))
+		for _, id := range ids {
+			out[id] = true
+		}
+
+		return out
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := omitDatabaseFilled(dataset, set(tC.configured), set(tC.defaulted))
+			require.ErrorIs(t, err, tC.expectedErr)
+			if tC.expectedErr == nil {
+				require.Equal(t, tC.expected, names(actual))
+				require.Len(t, dataset.Columns, 4)
+			}
+		})
+	}
+
+	//nolint:exhaustruct // ok for tests
+	_, err := omitDatabaseFilled(model.DatasetSchema{
+		Columns: []model.TargetType{{SourceName: total, Generated: true}},
+	}, nil, nil)
+	require.ErrorIs(t, err, ErrNothingToInsert)
+}
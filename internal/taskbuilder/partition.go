@@ -0,0 +1,121 @@
+package taskbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jmozgit/datagen/internal/acceptor/check"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/generator/oneof"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/mo"
+)
+
+var (
+	ErrNoPartitions                   = errors.New("partitioned table has no partitions, see createPartitions")
+	ErrPartitionsCreationNotSupported = errors.New("partitions creation isn't supported")
+)
+
+type partitionCreator interface {
+	CreatePartitions(
+		ctx context.Context,
+		name model.TableName,
+		cfg *config.CreatePartitions,
+		dryRun bool,
+	) ([]string, error)
+}
+
+// createPartitions returns true if a dry run left out partitions it would create.
+func (t *tableTaskBuilder) createPartitions(
+	ctx context.Context,
+	name model.TableName,
+	cfg *config.CreatePartitions,
+) (bool, error) {
+	const fnName = "create partitions"
+
+	creator, ok := t.schemaProvider.(partitionCreator)
+	if !ok {
+		return false, fmt.Errorf("%w: %s %s", ErrPartitionsCreationNotSupported, t.cfg.Connection.Type, fnName)
+	}
+
+	created, err := creator.CreatePartitions(ctx, name, cfg, t.opts.dryRun)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(created) == 0 {
+		return false, nil
+	}
+
+	if t.opts.dryRun {
+		if t.opts.planned != nil {
+			t.opts.planned(name, created)
+		}
+
+		return true, nil
+	}
+
+	slog.Info("partitions are created", slog.String("table", name.String()), slog.Any("partitions", created))
+
+	return false, nil
+}
+
+// withPlannedPartitions lets the key column of a table whose partitions weren't created
+// take any value instead of failing with ErrNoPartitions.
+func withPlannedPartitions(columns []model.TargetType) {
+	for i := range columns {
+		if columns[i].Partitions != nil && len(columns[i].Partitions) == 0 {
+			columns[i].Partitions = nil
+		}
+	}
+}
+
+// partitionsGenerator takes every value of the partition key from a random partition,
+// partitions the column's own check leaves nothing of are skipped.
+func partitionsGenerator(
+	ctx context.Context,
+	registry generatorRegistry,
+	req contract.AcceptRequest,
+) (model.Generator, error) {
+	const fnName = "partitions generator"
+
+	baseType := req.BaseType.MustGet()
+	if len(baseType.Partitions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoPartitions, fnName)
+	}
+
+	gens := make([]model.Generator, 0, len(baseType.Partitions))
+	for i := range baseType.Partitions {
+		partitionCheck, err := check.Intersect(baseType.Check, &baseType.Partitions[i], req.Now)
+		if errors.Is(err, check.ErrUnsatisfiable) {
+			continue
+		}
+
+		partitionType := baseType
+		partitionType.Check = partitionCheck
+		partitionType.Partitions = nil
+
+		partitionReq := req
+		partitionReq.BaseType = mo.Some(partitionType)
+
+		gen, err := registry.GetGenerator(ctx, partitionReq)
+		switch {
+		case errors.Is(err, check.ErrUnsatisfiable):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		gens = append(gens, gen)
+	}
+
+	if len(gens) == 0 {
+		return nil, fmt.Errorf("%w: no partition satisfies the column checks %s", check.ErrUnsatisfiable, fnName)
+	}
+
+	return oneof.NewGenerators(req.Rand, gens), nil
+}
@@ -0,0 +1,91 @@
+package taskbuilder
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/acceptor/check"
+	"github.com/jmozgit/datagen/internal/acceptor/commontype/integer"
+	"github.com/jmozgit/datagen/internal/acceptor/contract"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/model"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+)
+
+type integerRegistry struct{}
+
+func (integerRegistry) GetGenerator(ctx context.Context, req contract.AcceptRequest) (model.Generator, error) {
+	decision, err := integer.NewProvider().Accept(ctx, req)
+
+	return decision.Generator, err //nolint:wrapcheck // ok for tests
+}
+
+func (integerRegistry) ApplyOptions(_ context.Context, req contract.AcceptRequest) (model.Generator, error) {
+	return req.BaseGenerator.MustGet(), nil
+}
+
+func Test_partitionsGenerator(t *testing.T) {
+	t.Parallel()
+
+	bound := func(value string, inclusive bool) *model.CheckBound {
+		return &model.CheckBound{Value: value, Inclusive: inclusive}
+	}
+
+	//nolint:exhaustruct // ok for tests
+	newRequest := func(columnCheck *model.ColumnCheck, partitions ...model.ColumnCheck) contract.AcceptRequest {
+		return contract.AcceptRequest{
+			UserSettings: mo.None[config.Generator](),
+			BaseType: mo.Some(model.TargetType{
+				SourceName: model.PGIdentifier("id"), Type: model.Integer, SourceType: "int8", FixedSize: 8,
+				Check: columnCheck, Partitions: partitions,
+			}),
+			Rand: rand.New(rand.NewPCG(1, 2)), //nolint:gosec // ok for tests
+		}
+	}
+
+	//nolint:exhaustruct // ok for tests
+	partitions := []model.ColumnCheck{
+		{Min: bound("0", true), Max: bound("10", false)},
+		{Min: bound("100", true), Max: bound("110", false)},
+	}
+
+	gen, err := partitionsGenerator(t.Context(), integerRegistry{}, newRequest(nil, partitions...))
+	require.NoError(t, err)
+
+	seen := make(map[bool]bool)
+	for range 1000 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+
+		v, ok := val.(int64)
+		require.True(t, ok)
+		require.True(t, (0 <= v && v < 10) || (100 <= v && v < 110), v)
+		seen[v >= 100] = true
+	}
+	require.Len(t, seen, 2)
+
+	// the column check leaves only the second partition
+	//nolint:exhaustruct // ok for tests
+	gen, err = partitionsGenerator(t.Context(), integerRegistry{}, newRequest(
+		&model.ColumnCheck{Min: bound("50", false)}, partitions...,
+	))
+	require.NoError(t, err)
+
+	for range 100 {
+		val, err := gen.Gen(t.Context())
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, val, int64(100))
+	}
+
+	//nolint:exhaustruct // ok for tests
+	_, err = partitionsGenerator(t.Context(), integerRegistry{}, newRequest(
+		&model.ColumnCheck{Min: bound("500", false)}, partitions...,
+	))
+	require.ErrorIs(t, err, check.ErrUnsatisfiable)
+
+	_, err = partitionsGenerator(t.Context(), integerRegistry{}, newRequest(nil, []model.ColumnCheck{}...))
+	require.ErrorIs(t, err, ErrNoPartitions)
+}
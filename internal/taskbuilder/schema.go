@@ -0,0 +1,88 @@
+package taskbuilder
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jmozgit/datagen/internal/config"
+)
+
+// addSchemaTasks adds a task for every matched table of the schema which has no task yet.
+func (t *tableTaskBuilder) addSchemaTasks(ctx context.Context, target *config.Schema) error {
+	const fnName = "add schema tasks"
+
+	tables, err := t.schemaProvider.SchemaTables(ctx, target.Name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	for _, table := range tables {
+		if t.added[table] {
+			continue
+		}
+
+		matched, err := matchTable(table.Table.AsArgument(), target.Include, target.Exclude)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+
+		if !matched {
+			continue
+		}
+
+		//nolint:exhaustruct // generators are picked by the registry
+		tableTarget := &config.Table{
+			Schema:     table.Schema.AsArgument(),
+			Table:      table.Table.AsArgument(),
+			LimitRows:  target.LimitRows,
+			LimitBytes: target.LimitBytes,
+		}
+		if err := t.addResolvedTableTask(ctx, table, tableTarget); err != nil {
+			return fmt.Errorf("%w: %s %s", err, table.Quoted(), fnName)
+		}
+	}
+
+	return nil
+}
+
+// matchTable reports whether the name matches any include glob and none of the exclude ones.
+func matchTable(name string, include, exclude []string) (bool, error) {
+	const fnName = "match table"
+
+	anyMatch := func(patterns []string) (bool, error) {
+		for _, pattern := range patterns {
+			ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+			if err != nil {
+				return false, fmt.Errorf("%w: %q", err, pattern)
+			}
+
+			if ok {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	excluded, err := anyMatch(exclude)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if excluded {
+		return false, nil
+	}
+
+	if len(include) == 0 {
+		return true, nil
+	}
+
+	included, err := anyMatch(include)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return included, nil
+}
@@ -0,0 +1,45 @@
+package taskbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_matchTable(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // ok for tests
+	testCases := []struct {
+		desc    string
+		name    string
+		include []string
+		exclude []string
+
+		expected    bool
+		expectedErr bool
+	}{
+		{desc: "no_patterns", name: "invoices", expected: true},
+		{desc: "included", name: "invoices", include: []string{"acc_*", "inv*"}, expected: true},
+		{desc: "not_included", name: "payments", include: []string{"inv*"}, expected: false},
+		{desc: "excluded_wins", name: "invoices_audit", include: []string{"inv*"}, exclude: []string{"*_audit"}},
+		{desc: "case_insensitive", name: "INVOICES", include: []string{"inv*"}, expected: true},
+		{desc: "bad_pattern", name: "invoices", exclude: []string{"[inv"}, expectedErr: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := matchTable(tC.name, tC.include, tC.exclude)
+			if tC.expectedErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tC.expected, actual)
+		})
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/jmozgit/datagen/internal/pkg/closer"
 	"github.com/jmozgit/datagen/internal/progress"
 	"github.com/jmozgit/datagen/internal/refresolver"
+	"github.com/jmozgit/datagen/internal/schema/mysql"
+	"github.com/jmozgit/datagen/internal/schema/oracle"
 	"github.com/jmozgit/datagen/internal/schema/postgres"
+	"github.com/jmozgit/datagen/internal/schema/sqlite"
 )
 
 var (
@@ -39,11 +42,35 @@ func makeSchemaProvider(cfg config.Config) (model.SchemaProvider, error) {
 		}
 
 		return inspector, nil
+	case config.MySQLConnection:
+		return mysql.NewInspector(cfg.Connection.MySQL), nil
+	case config.SQLiteConnection:
+		return sqlite.NewInspector(cfg.Connection.SQLite), nil
+	case config.OracleConnection:
+		return oracle.NewInspector(cfg.Connection.Oracle), nil
 	default:
 		return nil, fmt.Errorf("%w: make schema provider", ErrUnknownConnectionType)
 	}
 }
 
+// Option changes how Build makes tasks.
+type Option func(opts *options)
+
+type options struct {
+	dryRun bool
+	// planned gets partitions a dry run would create.
+	planned func(table model.TableName, partitions []string)
+}
+
+// WithDryRun leaves the database as it is: partitions aren't created,
+// the ones which would be are passed to planned.
+func WithDryRun(planned func(table model.TableName, partitions []string)) Option {
+	return func(opts *options) {
+		opts.dryRun = true
+		opts.planned = planned
+	}
+}
+
 func Build(
 	ctx context.Context,
 	cfg config.Config,
@@ -51,6 +78,7 @@ func Build(
 	refSvc *refresolver.Service,
 	collector *progress.Controller,
 	closer *closer.Registry,
+	opts ...Option,
 ) ([]model.Task, error) {
 	const fnName = "taskbuilder: build"
 
@@ -59,11 +87,27 @@ func Build(
 		return nil, fmt.Errorf("%w: %s", err, fnName)
 	}
 
-	ttb := newTableTaskBuilder(cfg, collector, schemaProvider, registry, refSvc, closer)
+	//nolint:exhaustruct // nothing is planned unless asked
+	var buildOpts options
+	for _, opt := range opts {
+		opt(&buildOpts)
+	}
+
+	ttb := newTableTaskBuilder(cfg, collector, schemaProvider, registry, refSvc, closer, buildOpts)
+	// explicit tables go first, so schema targets don't override their settings
 	for _, task := range cfg.Targets {
 		table := task.Table
 		if table == nil {
-			return nil, fmt.Errorf("%w: %s", ErrUnsupportedTargetType, fnName)
+			if task.Schema == nil {
+				return nil, fmt.Errorf("%w: %s", ErrUnsupportedTargetType, fnName)
+			}
+
+			// schema targets are checked before any table is built
+			if err := checkLimits(task.Schema.LimitRows, task.Schema.LimitBytes); err != nil {
+				return nil, fmt.Errorf("%w: schema %s %s", err, task.Schema.Name, fnName)
+			}
+
+			continue
 		}
 
 		if err := ttb.addTableTask(ctx, table); err != nil {
@@ -71,5 +115,15 @@ func Build(
 		}
 	}
 
+	for _, task := range cfg.Targets {
+		if task.Schema == nil {
+			continue
+		}
+
+		if err := ttb.addSchemaTasks(ctx, task.Schema); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
 	return ttb.sortTasks()
 }
@@ -1,10 +1,14 @@
 package taskbuilder
 
 import (
+	"cmp"
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"maps"
+	"math/rand/v2"
 	"slices"
 	"time"
 
@@ -13,12 +17,16 @@ import (
 	"github.com/jmozgit/datagen/internal/acceptor/contract"
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/limit/rows"
+	"github.com/jmozgit/datagen/internal/limit/size"
 	"github.com/jmozgit/datagen/internal/limit/size/postgres"
+	"github.com/jmozgit/datagen/internal/limit/size/sqlite"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/chans"
 	"github.com/jmozgit/datagen/internal/pkg/closer"
 	"github.com/jmozgit/datagen/internal/pkg/db"
 	pgxadapter "github.com/jmozgit/datagen/internal/pkg/db/adapter/pgx"
+	"github.com/jmozgit/datagen/internal/pkg/db/adapter/stdsql"
+	"github.com/jmozgit/datagen/internal/pkg/xrand"
 	"github.com/jmozgit/datagen/internal/progress"
 	"github.com/jmozgit/datagen/internal/refresolver"
 
@@ -29,11 +37,13 @@ import (
 var (
 	ErrCycledRefences              = errors.New("cycled refernces are not allowed")
 	ErrMisleadingLimits            = errors.New("both limit_rows and limit_bytes cannot be set")
+	ErrNoLimit                     = errors.New("either limit_rows or limit_bytes must be set")
 	ErrUnsupportedLimitSizerDriver = errors.New("unsupported limit sizer driver")
 )
 
 type tableTaskBuilder struct {
 	tasks []model.Task
+	added map[model.TableName]bool
 
 	collector      *progress.Controller
 	lazyCommonPool db.Connect
@@ -42,6 +52,7 @@ type tableTaskBuilder struct {
 	refresolver    *refresolver.Service
 	schemaProvider model.SchemaProvider
 	closer         *closer.Registry
+	opts           options
 }
 
 func newTableTaskBuilder(
@@ -51,16 +62,19 @@ func newTableTaskBuilder(
 	registry generatorRegistry,
 	refresolver *refresolver.Service,
 	closer *closer.Registry,
+	opts options,
 ) tableTaskBuilder {
 	return tableTaskBuilder{
 		cfg:            cfg,
 		tasks:          make([]model.Task, 0),
+		added:          make(map[model.TableName]bool),
 		refresolver:    refresolver,
 		registry:       registry,
 		schemaProvider: schemaProvider,
 		lazyCommonPool: nil,
 		collector:      collector,
 		closer:         closer,
+		opts:           opts,
 	}
 }
 
@@ -72,13 +86,52 @@ func (t *tableTaskBuilder) addTableTask(ctx context.Context, target *config.Tabl
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
 
+	if err := t.addResolvedTableTask(ctx, schemaAwareID, target); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	return nil
+}
+
+func (t *tableTaskBuilder) addResolvedTableTask(
+	ctx context.Context,
+	schemaAwareID model.TableName,
+	target *config.Table,
+) error {
+	const fnName = "add resolved table task"
+
+	planned := false
+	if target.CreatePartitions != nil {
+		var err error
+		if planned, err = t.createPartitions(ctx, schemaAwareID, target.CreatePartitions); err != nil {
+			return fmt.Errorf("%w: %s", err, fnName)
+		}
+	}
+
 	schema, err := t.schemaProvider.Table(ctx, schemaAwareID)
 	if err != nil {
 		return fmt.Errorf("%w: %s", err, fnName)
 	}
 
-	if target.LimitRows != 0 && target.LimitBytes != 0 {
-		return fmt.Errorf("%w: %s", ErrMisleadingLimits, fnName)
+	if planned {
+		withPlannedPartitions(schema.Columns)
+	}
+
+	schema, err = t.omitColumns(ctx, schema, target)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if err := checkLimits(target.LimitRows, target.LimitBytes); err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
+	if len(schema.UnsupportedChecks) > 0 {
+		slog.Warn(
+			"check constraints aren't understood, generated rows may be rejected",
+			slog.String("table", schemaAwareID.String()),
+			slog.Any("checks", schema.UnsupportedChecks),
+		)
 	}
 
 	flows, err := t.schemaGenerators(ctx, schema, target, t.registry)
@@ -126,8 +179,27 @@ func (t *tableTaskBuilder) addTableTask(ctx context.Context, target *config.Tabl
 		datasize.ByteSize(target.LimitBytes),
 	)
 
+	genOrder, rowColumns, err := t.rowOrder(ctx, schema, flows)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, fnName)
+	}
+
 	gens := make([]model.Generator, len(flows))
+	randStates := make([]model.RandState, len(flows))
+	var (
+		genStates  []model.GeneratorState
+		configured []bool
+	)
 	for i := range flows {
+		randStates[i] = flows[i].Src
+		if flows[i].Req.UserSettings.IsPresent() {
+			if configured == nil {
+				configured = make([]bool, len(flows))
+			}
+			configured[i] = true
+		}
+		genStates = append(genStates, *flows[i].Req.States...)
+
 		req := flows[i].Req
 		req.BaseGenerator = mo.Some(flows[i].Gen)
 
@@ -139,18 +211,37 @@ func (t *tableTaskBuilder) addTableTask(ctx context.Context, target *config.Tabl
 		gens[i] = gen
 	}
 
+	t.added[schemaAwareID] = true
 	t.tasks = append(t.tasks, model.Task{
-		DatasetSchema: schema,
-		Limiter:       stopper,
-		Generators:    gens,
+		DatasetSchema:   schema,
+		Limiter:         stopper,
+		Generators:      gens,
+		RandStates:      randStates,
+		GeneratorStates: genStates,
+		GenOrder:        genOrder,
+		RowColumns:      rowColumns,
+		Configured:      configured,
 	})
 
 	return nil
 }
 
+// checkLimits requires exactly one of the limits, the task can't know when to stop otherwise.
+func checkLimits(limitRows uint64, limitBytes datasize.ByteSize) error {
+	switch {
+	case limitRows != 0 && limitBytes != 0:
+		return ErrMisleadingLimits
+	case limitRows == 0 && limitBytes == 0:
+		return ErrNoLimit
+	default:
+		return nil
+	}
+}
+
 type findGeneratorFlow struct {
 	Req contract.AcceptRequest
 	Gen model.Generator
+	Src *xrand.Source
 }
 
 func (t *tableTaskBuilder) schemaGenerators(
@@ -163,6 +254,10 @@ func (t *tableTaskBuilder) schemaGenerators(
 
 	userSettingsByID := make(map[model.Identifier]config.Generator)
 	for _, settings := range target.Generators {
+		if settings.Type == config.GeneratorTypeDefault {
+			continue
+		}
+
 		id, err := t.schemaProvider.ColumnIdentifier(ctx, dataset.TableName, settings.Column)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", err, fnName)
@@ -179,13 +274,29 @@ func (t *tableTaskBuilder) schemaGenerators(
 			userSettings = mo.Some(set)
 		}
 
+		src := xrand.NewSource(
+			lo.FromPtr(t.cfg.Options.Seed),
+			dataset.TableName.String(), targetType.SourceName.AsArgument(),
+		)
 		req := contract.AcceptRequest{
-			Dataset:      dataset,
-			UserSettings: userSettings,
-			BaseType:     mo.Some(targetType),
+			Dataset:       dataset,
+			UserSettings:  userSettings,
+			BaseType:      mo.Some(targetType),
+			BaseGenerator: mo.None[model.Generator](),
+			Rand:          rand.New(src), //nolint:gosec // reproducibility is the point
+			Now:           t.cfg.Options.Now(),
+			States:        &[]model.GeneratorState{},
 		}
 
-		gen, err := registry.GetGenerator(ctx, req)
+		var (
+			gen model.Generator
+			err error
+		)
+		if targetType.Partitions != nil && userSettings.IsAbsent() {
+			gen, err = partitionsGenerator(ctx, registry, req)
+		} else {
+			gen, err = registry.GetGenerator(ctx, req)
+		}
 		if err != nil {
 			return nil, fmt.Errorf(
 				"%w: %s.%s %s",
@@ -196,7 +307,7 @@ func (t *tableTaskBuilder) schemaGenerators(
 			)
 		}
 
-		generators = append(generators, findGeneratorFlow{Req: req, Gen: gen})
+		generators = append(generators, findGeneratorFlow{Req: req, Gen: gen, Src: src})
 	}
 
 	if len(userSettingsByID) > 0 {
@@ -215,6 +326,11 @@ func (t *tableTaskBuilder) startSizerStopper(
 ) (model.Limiter, error) {
 	const fnName = "start sizer stopper"
 
+	// the size is measured on the database, rows written anywhere else never show up there
+	if t.cfg.Output != nil {
+		return nil, fmt.Errorf("%w: output %s %s", ErrUnsupportedLimitSizerDriver, t.cfg.Output.Type, fnName)
+	}
+
 	switch t.cfg.Connection.Type {
 	case config.PostgresqlConnection:
 		if t.lazyCommonPool == nil {
@@ -225,29 +341,56 @@ func (t *tableTaskBuilder) startSizerStopper(
 			t.lazyCommonPool = pgxadapter.NewAdapterPool(pool)
 		}
 
-		stopper, err := postgres.NewStopper(
-			ctx, limit, t.lazyCommonPool,
-			table, t.collector, gens...,
-		)
+		sizer, err := postgres.NewSizer(ctx, t.lazyCommonPool, table)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", err, fnName)
 		}
-		t.closer.Add(closer.Fn(stopper.Close))
 
-		stopper.Run(ctx, fetchDuration)
+		return t.runSizerStopper(ctx, limit, sizer, table, fetchDuration, gens)
+	case config.SQLiteConnection:
+		if t.lazyCommonPool == nil {
+			sqlDB, err := sql.Open("sqlite", t.cfg.Connection.ConnString())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, fnName)
+			}
+			t.lazyCommonPool = stdsql.NewAdapterDB(sqlDB)
+			t.closer.Add(t.lazyCommonPool)
+		}
 
-		return stopper, nil
+		return t.runSizerStopper(ctx, limit, sqlite.NewSizer(t.lazyCommonPool, table), table, fetchDuration, gens)
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLimitSizerDriver, fnName)
 	}
 }
 
+func (t *tableTaskBuilder) runSizerStopper(
+	ctx context.Context,
+	limit uint64,
+	sizer size.TableSizer,
+	table model.TableName,
+	fetchDuration time.Duration,
+	gens []<-chan []model.LOGenerated,
+) (model.Limiter, error) {
+	stopper, err := size.NewStopper(ctx, limit, sizer, table, t.collector, gens...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: run sizer stopper", err)
+	}
+	t.closer.Add(closer.Fn(stopper.Close))
+
+	stopper.Run(ctx, fetchDuration)
+
+	return stopper, nil
+}
+
 func (t *tableTaskBuilder) sortTasks() ([]model.Task, error) {
 	byID := lo.SliceToMap(t.tasks, func(t model.Task) (model.TableName, model.Task) {
 		return t.DatasetSchema.TableName, t
 	})
 
-	ids := slices.Collect(maps.Keys(byID))
+	// tables independent of each other run in the same order every time
+	ids := slices.SortedFunc(maps.Keys(byID), func(a, b model.TableName) int {
+		return cmp.Compare(a.String(), b.String())
+	})
 
 	sortedIDs, err := topSort(ids, t.refresolver.DepsOn())
 	if err != nil {
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/jmozgit/datagen/internal/model"
+	"github.com/jmozgit/datagen/internal/refresolver"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -87,3 +91,41 @@ func Test_topSort(t *testing.T) {
 		})
 	}
 }
+
+func Test_sortTasksIsStable(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"e", "d", "c", "b", "a"}
+	tasks := make([]model.Task, len(names))
+	for i, name := range names {
+		//nolint:exhaustruct // ok for tests
+		tasks[i] = model.Task{DatasetSchema: model.DatasetSchema{
+			TableName: model.TableName{Schema: model.PGIdentifier("public"), Table: model.PGIdentifier(name)},
+		}}
+	}
+
+	refs := refresolver.NewService()
+	// b depends on e
+	refs.Register(tasks[3].DatasetSchema.TableName, tasks[0].DatasetSchema.TableName, nil)
+
+	//nolint:exhaustruct // only tasks are sorted
+	builder := tableTaskBuilder{tasks: tasks, refresolver: refs}
+
+	for range 10 {
+		sorted, err := builder.sortTasks()
+		require.NoError(t, err)
+		require.Equal(t, []string{"public.a", "public.e", "public.b", "public.c", "public.d"}, lo.Map(
+			sorted, func(task model.Task, _ int) string { return task.TableName() },
+		))
+	}
+}
+
+func Test_checkLimits(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkLimits(10, 0))
+	require.NoError(t, checkLimits(0, datasize.MB))
+	require.ErrorIs(t, checkLimits(10, datasize.MB), ErrMisleadingLimits)
+	// a schema target without limits would make tasks which never stop
+	require.ErrorIs(t, checkLimits(0, 0), ErrNoLimit)
+}
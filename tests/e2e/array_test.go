@@ -9,6 +9,8 @@ import (
 )
 
 func Test_DriverArray(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	bs := suite.NewBaseSuite(t)
 	table := bs.NewTable("array_driver_test", []suite.Column{
 		suite.NewColumn("array_of_int", suite.TypeArrayInt),
@@ -45,6 +47,8 @@ func Test_DriverArray(t *testing.T) {
 }
 
 func Test_UserSettingsArray(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	bs := suite.NewBaseSuite(t)
 	table := bs.NewTable("array_user_settings_test", []suite.Column{
 		suite.NewColumn("array_of_int", suite.TypeArrayInt),
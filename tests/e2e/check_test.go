@@ -0,0 +1,76 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresqlCheckConstraints(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("test_checks", []suite.Column{
+		suite.NewColumnRawType("price", "numeric(8, 2) check (price > 0)"),
+		suite.NewColumnRawType("qty", "int4 check (qty between 1 and 10)"),
+		suite.NewColumnRawType("status", "varchar(10) check (status in ('new', 'paid'))"),
+		suite.NewColumnRawType("code", "text check (length(code) <= 8)"),
+		suite.NewColumnRawType("starts_at", "timestamp"),
+		suite.NewColumnRawType("ends_at", "timestamp check (starts_at < ends_at)"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(20),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			Generators: []config.Generator{},
+			LimitRows:  100,
+			LimitBytes: 0,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		cnt++
+		require.Positive(t, toFloat(t, row[0]))
+
+		qty := toInteger(t, row[1])
+		require.True(t, 1 <= qty && qty <= 10)
+
+		require.Contains(t, []string{"new", "paid"}, toString(t, row[2]))
+		require.LessOrEqual(t, len(toString(t, row[3])), 8)
+		require.True(t, toTime(t, row[4]).Before(toTime(t, row[5])))
+	})
+	require.Equal(t, 100, cnt)
+}
+
+func Test_PostgresqlUnsupportedCheckStops(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("test_unsupported_check", []suite.Column{
+		suite.NewColumnRawType("qty", "int4 check (qty % 7 = 100)"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(10),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			Generators: []config.Generator{},
+			LimitRows:  10,
+			LimitBytes: 0,
+		}),
+	)
+
+	// no row passes the check, the run has to give up instead of retrying forever
+	require.Error(t, bs.RunDatagen(t.Context()))
+}
@@ -0,0 +1,74 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GeneratedAndDefaultColumns(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql", "sqlite")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("priced", []suite.Column{
+		suite.NewColumnRawType("amount", "smallint not null"),
+		suite.NewColumnRawType("doubled", "bigint generated always as (amount * 2) stored"),
+		suite.NewColumnRawType("currency", "text not null default 'EUR'"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+			Generators: []config.Generator{
+				{Column: "currency", Type: config.GeneratorTypeDefault},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		cnt++
+		require.Equal(t, toInteger(t, row[0])*2, toInteger(t, row[1]))
+		require.Equal(t, "EUR", toString(t, row[2]))
+	})
+	require.Equal(t, 100, cnt)
+}
+
+func Test_PostgresqlIdentityAlways(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("identity_always", []suite.Column{
+		suite.NewColumnRawType("id", "int8 generated always as identity"),
+		suite.NewColumn("comment", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	ids := make(map[int64]bool)
+	bs.OnEachRow(table, func(row []any) {
+		ids[toInteger(t, row[0])] = true
+	})
+	require.Len(t, ids, 100)
+}
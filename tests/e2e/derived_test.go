@@ -0,0 +1,98 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DerivedColumns(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	// derived columns go before the columns they read to check the generation order
+	table := bs.NewTable("order_lines", []suite.Column{
+		suite.NewColumn("total", suite.TypeFloat8),
+		suite.NewColumn("full_name", suite.TypeText),
+		suite.NewColumn("qty", suite.TypeInt4),
+		suite.NewColumn("price", suite.TypeFloat8),
+		suite.NewColumn("first_name", suite.TypeText),
+		suite.NewColumn("last_name", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	minQty, maxQty := int64(1), int64(10)
+	bs.SaveConfig(
+		suite.WithBatchSize(20),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 60,
+			Generators: []config.Generator{
+				{Column: "total", Type: config.GeneratorTypeDerived, Derived: &config.Derived{Expr: "qty * price"}},
+				{
+					Column:  "full_name",
+					Type:    config.GeneratorTypeDerived,
+					Derived: &config.Derived{Expr: "first_name || ' ' || upper(last_name)"},
+				},
+				{
+					Column:  "qty",
+					Type:    config.GeneratorTypeInteger,
+					Integer: &config.Integer{MinValue: &minQty, MaxValue: &maxQty},
+				},
+				{
+					Column:  "price",
+					Type:    config.GeneratorTypeDerived,
+					Derived: &config.Derived{Expr: "rand_int(100, 9999) / 100.0"},
+				},
+				{Column: "first_name", Type: config.GeneratorTypeFaker, Faker: &config.Faker{Kind: "first_name"}},
+				{Column: "last_name", Type: config.GeneratorTypeFaker, Faker: &config.Faker{Kind: "last_name"}},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 6)
+
+		qty, price := toInteger(t, row[2]), toFloat(t, row[3])
+		require.GreaterOrEqual(t, price, 1.0)
+		require.InDelta(t, float64(qty)*price, toFloat(t, row[0]), 1e-6)
+
+		first, last := toString(t, row[4]), toString(t, row[5])
+		require.NotEmpty(t, first)
+		require.Equal(t, first+" "+strings.ToUpper(last), toString(t, row[1]))
+		cnt++
+	})
+
+	require.Equal(t, 60, cnt)
+}
+
+func Test_DerivedColumnsCycle(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("cycled", []suite.Column{
+		suite.NewColumn("a", suite.TypeInt8),
+		suite.NewColumn("b", suite.TypeInt8),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 10,
+			Generators: []config.Generator{
+				{Column: "a", Type: config.GeneratorTypeDerived, Derived: &config.Derived{Expr: "b + 1"}},
+				{Column: "b", Type: config.GeneratorTypeDerived, Derived: &config.Derived{Expr: "a + 1"}},
+			},
+		}),
+	)
+
+	require.Error(t, bs.RunDatagen(t.Context()))
+}
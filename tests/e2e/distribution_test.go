@@ -0,0 +1,96 @@
+package e2e_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Distributions(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("skewed", []suite.Column{
+		suite.NewColumn("customer_id", suite.TypeInt4),
+		suite.NewColumn("amount", suite.TypeFloat8),
+		suite.NewColumn("created_at", suite.TypeTimestamp),
+	})
+	bs.CreateTable(table)
+
+	minID, maxID := int64(1), int64(1000)
+	minAmount, maxAmount := 0.0, 500.0
+	to := time.Now().Truncate(time.Second)
+	from := to.AddDate(0, 0, -30)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(100),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 1000,
+			Generators: []config.Generator{
+				{
+					Column: "customer_id",
+					Type:   config.GeneratorTypeInteger,
+					Integer: &config.Integer{
+						MinValue:     &minID,
+						MaxValue:     &maxID,
+						Distribution: &config.Distribution{Kind: "zipf", S: lo.ToPtr(1.5)},
+					},
+				},
+				{
+					Column: "amount",
+					Type:   config.GeneratorTypeFloat,
+					Float: &config.Float{
+						MinValue:     &minAmount,
+						MaxValue:     &maxAmount,
+						Distribution: &config.Distribution{Kind: "normal", Mean: lo.ToPtr(100.0), StdDev: lo.ToPtr(10.0)},
+					},
+				},
+				{
+					Column: "created_at",
+					Type:   config.GeneratorTypeTimestamp,
+					Timestamp: &config.Timestamp{
+						From: lo.ToPtr(from),
+						To:   lo.ToPtr(to),
+						Distribution: &config.Distribution{
+							Kind: "histogram", Bounds: []float64{0, 29, 30}, Weights: []int{0, 1},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt, firstCustomer, amounts := 0, 0, 0.0
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 3)
+
+		id := toInteger(t, row[0])
+		require.GreaterOrEqual(t, id, minID)
+		require.LessOrEqual(t, id, maxID)
+		if id == minID {
+			firstCustomer++
+		}
+
+		amount := toFloat(t, row[1])
+		require.GreaterOrEqual(t, amount, minAmount)
+		require.LessOrEqual(t, amount, maxAmount)
+		amounts += amount
+
+		created := toTime(t, row[2])
+		require.False(t, created.Before(to.AddDate(0, 0, -1)), "%s is before the last day", created)
+		require.False(t, created.After(to), "%s is after %s", created, to)
+		cnt++
+	})
+
+	require.Equal(t, 1000, cnt)
+	require.Greater(t, firstCustomer, 300)
+	require.InDelta(t, 100, amounts/float64(cnt), 2)
+}
@@ -0,0 +1,69 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresqlDomainsAndComposites(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		return c.Execute(ctx, `
+			CREATE DOMAIN positive_money AS numeric(10, 2) CHECK (VALUE > 0);
+			CREATE DOMAIN short_code AS varchar(8) NOT NULL CHECK (length(VALUE) >= 3);
+			CREATE DOMAIN strict_code AS short_code CHECK (length(VALUE) <= 5);
+			CREATE TYPE address_kind AS enum ('home', 'work');
+			CREATE TYPE address AS (street varchar(40), zip int4, kind address_kind, code short_code);
+		`)
+	})
+
+	table := bs.NewTable("customers", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt8),
+		suite.NewColumnRawType("balance", "positive_money"),
+		suite.NewColumnRawType("code", "strict_code"),
+		suite.NewColumnRawType("home", "address"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 200,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var total, valid int
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		const query = `
+			SELECT
+				count(*),
+				count(*) FILTER (
+					WHERE balance > 0 AND length(code) BETWEEN 3 AND 5
+						AND length((home).street) <= 40 AND (home).kind IS NOT NULL
+						AND length((home).code) BETWEEN 3 AND 8
+				)
+			FROM customers
+		`
+		if err := c.QueryRow(ctx, query).Scan(&total, &valid); err != nil {
+			return fmt.Errorf("%w: count customers", err)
+		}
+
+		return nil
+	})
+	require.Equal(t, 200, total)
+	require.Equal(t, total, valid)
+}
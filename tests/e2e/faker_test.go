@@ -0,0 +1,54 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Faker(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("customers", []suite.Column{
+		suite.NewColumn("email", suite.TypeText),
+		suite.NewColumn("owner", suite.TypeText),
+		suite.NewColumn("nickname", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(20),
+		suite.WithFakerByColumnName(),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 50,
+			Generators: []config.Generator{
+				{Column: "owner", Type: config.GeneratorTypeFaker, Faker: &config.Faker{Kind: "full_name"}},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 3)
+
+		email := toString(t, row[0])
+		require.Contains(t, email, "@", email)
+
+		owner := toString(t, row[1])
+		require.Len(t, strings.Fields(owner), 2, owner)
+
+		// a name the faker doesn't know keeps random text
+		require.NotEmpty(t, toString(t, row[2]))
+		cnt++
+	})
+
+	require.Equal(t, 50, cnt)
+}
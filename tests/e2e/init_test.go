@@ -0,0 +1,70 @@
+package e2e_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InitScaffoldsConfig(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		return c.Execute(ctx, `
+			CREATE TYPE init_status AS enum ('new', 'paid');
+			CREATE TABLE init_customers (id serial PRIMARY KEY, email varchar(64) NOT NULL);
+			CREATE TABLE init_orders (
+				id int8 GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+				customer_id int4 REFERENCES init_customers (id),
+				status init_status NOT NULL,
+				code char(8),
+				qty int2 CHECK (qty >= 0),
+				total int4 GENERATED ALWAYS AS (qty * 10) STORED
+			);
+		`)
+	})
+
+	output := filepath.Join(t.TempDir(), "config.yaml")
+	url := bs.Connection().Postgresql.ConnString("postgresql")
+	require.NoError(t, bs.RunWithoutConfig(t.Context(), "init", "--url", url, "--schema", "public", "-o", output))
+
+	cfg, err := config.Load(output)
+	require.NoError(t, err)
+	require.Equal(t, config.PostgresqlConnection, cfg.Connection.Type)
+
+	tables := make(map[string]config.Table)
+	for _, target := range cfg.Targets {
+		require.NotNil(t, target.Table)
+		require.Empty(t, target.Table.Generators)
+		require.Equal(t, uint64(1000), target.Table.LimitRows)
+		tables[target.Table.Table] = *target.Table
+	}
+	require.Contains(t, tables, "init_customers")
+	require.Contains(t, tables, "init_orders")
+
+	raw, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		"- column: id # takes values of its sequence",
+		"- column: customer_id # references public.init_customers, takes values saved there",
+		"- column: status # enum init_status",
+		"- paid",
+		"minValue: 0",
+		"- column: total # generated by the database, left out of inserts",
+	} {
+		require.Contains(t, string(raw), line)
+	}
+
+	// varchar limits the length, char fixes it, charToFrom is how the config spells the lower bound
+	require.Regexp(t, `charToFrom: 20\n\s*#?\s*charLenTo: 64\n`, string(raw))
+	require.Regexp(t, `charToFrom: 8\n\s*#?\s*charLenTo: 8\n`, string(raw))
+}
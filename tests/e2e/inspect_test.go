@@ -0,0 +1,45 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InspectExplainsChoices(t *testing.T) {
+	refSuite := newReferenceSuite(t)
+
+	refSuite.bs.SaveConfig(
+		suite.WithTableTarget(config.Table{
+			Schema:     refSuite.childTable.Schema,
+			Table:      refSuite.childTable.Name,
+			LimitRows:  10,
+			LimitBytes: 0,
+			Generators: make([]config.Generator, 0),
+		}),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:     refSuite.baseTable.Schema,
+			Table:      refSuite.baseTable.Name,
+			LimitRows:  10,
+			Generators: []config.Generator{{Column: "id", NullFraction: 10, ReuseFraction: 20}},
+		}),
+	)
+
+	require.NoError(t, refSuite.bs.RunCommand(t.Context(), "inspect"))
+	out := refSuite.bs.Stdout()
+
+	require.Contains(t, out, "base_id: integer")
+	require.Contains(t, out, "reference.Provider (reference)")
+	require.Contains(t, out, "null: 10%, reuse: 20%")
+
+	child, base := refSuite.childTable.Name, refSuite.baseTable.Name
+	require.Regexp(t, `references\n\s+\S*`+child+` -> \S*`+base+`\n`, out)
+
+	order := out[strings.Index(out, "order"):]
+	require.Less(t, strings.Index(order, base), strings.Index(order, child))
+}
@@ -124,6 +124,8 @@ func Test_SerialPostgresqlDefault(t *testing.T) {
 }
 
 func Test_SerialGeneratorFromConfig(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	baseSuite := suite.NewBaseSuite(t)
 	table := baseSuite.NewTable("test_serial", []suite.Column{
 		suite.NewColumn("smallserial", suite.TypeSerialInt2),
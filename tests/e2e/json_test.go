@@ -0,0 +1,79 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresqlJSONSchema(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("events", []suite.Column{
+		suite.NewColumnRawType("payload", "jsonb not null"),
+		suite.NewColumnRawType("extra", "json not null"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+			Generators: []config.Generator{
+				{
+					Column: "payload",
+					Type:   config.GeneratorTypeJSON,
+					JSON: &config.JSON{
+						Schema: map[string]any{
+							"type":     "object",
+							"required": []any{"kind", "qty", "tags"},
+							"properties": map[string]any{
+								"kind": map[string]any{"enum": []any{"click", "view"}},
+								"qty":  map[string]any{"type": "integer", "minimum": 1, "maximum": 5},
+								"tags": map[string]any{
+									"type": "array", "minItems": 1, "maxItems": 3,
+									"items": map[string]any{"type": "string", "maxLength": 8},
+								},
+							},
+						},
+						Path: "",
+					},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var total, valid int
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		const query = `
+			SELECT
+				count(*),
+				count(*) FILTER (
+					WHERE payload->>'kind' IN ('click', 'view')
+						AND (payload->>'qty')::int BETWEEN 1 AND 5
+						AND jsonb_array_length(payload->'tags') BETWEEN 1 AND 3
+						AND json_typeof(extra) IS NOT NULL
+				)
+			FROM events
+		`
+		if err := c.QueryRow(ctx, query).Scan(&total, &valid); err != nil {
+			return fmt.Errorf("%w: count events", err)
+		}
+
+		return nil
+	})
+	require.Equal(t, 100, total)
+	require.Equal(t, total, valid)
+}
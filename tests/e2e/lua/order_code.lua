@@ -0,0 +1,10 @@
+-- numbers codes per customer, the counter lives between calls
+
+local counters = {}
+
+function gen(row, ctx)
+  local n = (counters[row.customer] or 0) + 1
+  counters[row.customer] = n
+
+  return ctx.args.prefix .. "-" .. row.customer .. "-" .. n
+end
@@ -0,0 +1,3 @@
+function gen(row, ctx)
+  return { n = ctx.n, dice = math.random(1, 6) }
+end
@@ -1,6 +1,8 @@
 package e2e_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/jmozgit/datagen/internal/config"
@@ -9,6 +11,8 @@ import (
 )
 
 func Test_LuaUserSettings(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	bs := suite.NewBaseSuite(t)
 
 	table := bs.NewTable("lua_scripts", []suite.Column{
@@ -59,6 +63,73 @@ func Test_LuaUserSettings(t *testing.T) {
 	require.GreaterOrEqual(t, cnt, 137)
 }
 
+func Test_LuaGenFunction(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("lua_orders", []suite.Column{
+		suite.NewColumn("code", suite.TypeText),
+		suite.NewColumn("customer", suite.TypeInt4),
+		suite.NewColumn("meta", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	minCustomer, maxCustomer := int64(1), int64(5)
+	bs.SaveConfig(
+		suite.WithBatchSize(16),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 50,
+			Generators: []config.Generator{
+				{
+					Column: "code",
+					Type:   config.GeneratorTypeLua,
+					Lua: &config.Lua{
+						Path:    "./lua/order_code.lua",
+						Args:    map[string]any{"prefix": "ord"},
+						Columns: []string{"customer"},
+					},
+				},
+				{
+					Column:  "customer",
+					Type:    config.GeneratorTypeInteger,
+					Integer: &config.Integer{MinValue: &minCustomer, MaxValue: &maxCustomer},
+				},
+				{
+					Column: "meta",
+					Type:   config.GeneratorTypeLua,
+					Lua:    &config.Lua{Path: "./lua/order_meta.lua"},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	codes := make(map[string]struct{})
+	ns := make(map[int]struct{})
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 3)
+
+		code, customer := toString(t, row[0]), toInteger(t, row[1])
+		require.Regexp(t, fmt.Sprintf(`^ord-%d-\d+$`, customer), code)
+		require.NotContains(t, codes, code)
+		codes[code] = struct{}{}
+
+		var meta struct {
+			N    int `json:"n"`
+			Dice int `json:"dice"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(toString(t, row[2])), &meta))
+		require.GreaterOrEqual(t, meta.Dice, 1)
+		require.LessOrEqual(t, meta.Dice, 6)
+		ns[meta.N] = struct{}{}
+	})
+
+	require.Len(t, codes, 50)
+	require.Len(t, ns, 50)
+}
+
 func toBoolean(t *testing.T, val any) bool {
 	t.Helper()
 
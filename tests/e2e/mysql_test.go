@@ -0,0 +1,92 @@
+package e2e_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MySQLTypes(t *testing.T) {
+	suite.TestOnlyFor(t, "mysql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("mysql_types", []suite.Column{
+		suite.NewColumnRawType("id", "int auto_increment unique"),
+		suite.NewColumnRawType("tiny", "tinyint unsigned"),
+		suite.NewColumnRawType("medium", "mediumint"),
+		suite.NewColumnRawType("mood", "enum('sad','ok','it''s fine')"),
+		suite.NewColumnRawType("flags", "set('a','b','c')"),
+		suite.NewColumnRawType("name", "varchar(12)"),
+		suite.NewColumnRawType("price", "decimal(6,2)"),
+		suite.NewColumnRawType("payload", "varbinary(16)"),
+	})
+	bs.CreateTable(table, options.WithPKs([]string{"id"}))
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 200,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	ids := make(map[int64]bool)
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 8)
+
+		id := toInteger(t, row[0])
+		require.False(t, ids[id])
+		ids[id] = true
+
+		tiny := toInteger(t, row[1])
+		require.True(t, 0 <= tiny && tiny <= 255)
+		medium := toInteger(t, row[2])
+		require.True(t, -1<<23 <= medium && medium < 1<<23)
+
+		require.Contains(t, []string{"sad", "ok", "it's fine"}, toString(t, row[3]))
+		for _, flag := range strings.Split(toString(t, row[4]), ",") {
+			require.Contains(t, []string{"", "a", "b", "c"}, flag)
+		}
+
+		require.Len(t, toString(t, row[5]), 12)
+		require.LessOrEqual(t, len(row[7].([]byte)), 16) //nolint:forcetypeassert // ok for tests
+	})
+	require.Len(t, ids, 200)
+}
+
+func Test_MySQLUniqueViolations(t *testing.T) {
+	suite.TestOnlyFor(t, "mysql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("mysql_unique", []suite.Column{
+		suite.NewColumnRawType("code", "tinyint unsigned"),
+	})
+	bs.CreateTable(table, options.WithPKs([]string{"code"}))
+
+	bs.SaveConfig(
+		suite.WithBatchSize(64),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(_ []any) {
+		cnt++
+	})
+	require.GreaterOrEqual(t, cnt, 100)
+}
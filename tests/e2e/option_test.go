@@ -97,6 +97,8 @@ func Test_NullFractionOptionOnlyNull(t *testing.T) {
 }
 
 func Test_ReuseValueOptionNoInitValues(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	bs := suite.NewBaseSuite(t)
 
 	table := bs.NewTable(
@@ -145,6 +147,8 @@ func Test_ReuseValueOptionNoInitValues(t *testing.T) {
 }
 
 func Test_ReuseValueOptionWithInitValues(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
 	bs := suite.NewBaseSuite(t)
 
 	table := bs.NewTable(
@@ -0,0 +1,53 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OracleTypes(t *testing.T) {
+	suite.TestOnlyFor(t, "oracle")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("ora_types", []suite.Column{
+		suite.NewColumnRawType("id", "number(10) generated always as identity"),
+		suite.NewColumnRawType("small", "number(3)"),
+		suite.NewColumnRawType("price", "number(6,2) not null"),
+		suite.NewColumnRawType("name", "varchar2(12)"),
+		suite.NewColumnRawType("created", "date"),
+		suite.NewColumnRawType("payload", "raw(16)"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 200,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	ids := make(map[int64]bool)
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 6)
+
+		id := toInteger(t, row[0])
+		require.False(t, ids[id])
+		ids[id] = true
+
+		small := toInteger(t, row[1])
+		require.True(t, -999 <= small && small <= 999)
+
+		require.Len(t, toString(t, row[3]), 12)
+		require.IsType(t, []byte(nil), row[5])
+	})
+	require.Len(t, ids, 200)
+}
@@ -0,0 +1,178 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/tests/suite"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresqlRangePartitions(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("events", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt8),
+		suite.NewColumnRawType("created_at", "timestamp not null"),
+	})
+	bs.CreateTable(table, options.WithRangePartitions(
+		"created_at",
+		[2]string{"2024-01-01", "2024-02-01"},
+		[2]string{"2024-03-01", "2024-04-01"},
+	))
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			LimitRows:  200,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	jan := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		cnt++
+		createdAt := toTime(t, row[1])
+		inJan := !createdAt.Before(jan) && createdAt.Before(jan.AddDate(0, 1, 0))
+		inMar := !createdAt.Before(jan.AddDate(0, 2, 0)) && createdAt.Before(jan.AddDate(0, 3, 0))
+		require.True(t, inJan || inMar, createdAt)
+	})
+	require.Equal(t, 200, cnt)
+}
+
+func Test_PostgresqlCreatePartitions(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("events_created", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt8),
+		suite.NewColumnRawType("created_at", "date not null"),
+	})
+	bs.CreateTable(table, options.WithRangePartitions("created_at"))
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			LimitRows:  100,
+			Generators: make([]config.Generator, 0),
+			CreatePartitions: &config.CreatePartitions{
+				From: "2025-01-01",
+				To:   "2025-07-01",
+				Step: "1 month",
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var partitions int
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		err := c.QueryRow(ctx, "select count(*) from pg_inherits where inhparent = 'events_created'::regclass").
+			Scan(&partitions)
+		if err != nil {
+			return fmt.Errorf("%w: count partitions", err)
+		}
+
+		return nil
+	})
+	require.Equal(t, 6, partitions)
+
+	cnt := 0
+	bs.OnEachRow(table, func(_ []any) {
+		cnt++
+	})
+	require.Equal(t, 100, cnt)
+}
+
+func Test_PostgresqlInspectPlansPartitions(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("events_planned", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt8),
+		suite.NewColumnRawType("created_at", "date not null"),
+	})
+	bs.CreateTable(table, options.WithRangePartitions("created_at"))
+
+	bs.SaveConfig(
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			LimitRows:  100,
+			Generators: make([]config.Generator, 0),
+			CreatePartitions: &config.CreatePartitions{
+				From: "2025-01-01",
+				To:   "2025-03-01",
+				Step: "1 month",
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunCommand(t.Context(), "inspect"))
+	require.Regexp(
+		t, `partitions to create\n\s+\S*events_planned: \S*_20250101, \S*_20250201\n`, bs.Stdout(),
+	)
+
+	var partitions int
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		err := c.QueryRow(ctx, "select count(*) from pg_inherits where inhparent = 'events_planned'::regclass").
+			Scan(&partitions)
+		if err != nil {
+			return fmt.Errorf("%w: count partitions", err)
+		}
+
+		return nil
+	})
+	require.Zero(t, partitions)
+}
+
+func Test_PostgresqlLimitPartitionedTableSize(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("size_partitioned", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt8),
+		suite.NewColumn("comment", suite.TypeText),
+	})
+	bs.CreateTable(table, options.WithHashPartitions(4, "id"))
+
+	threshold := datasize.KB * 350
+	bs.SaveConfig(
+		suite.WithBatchSize(100),
+		suite.WithCheckTableSize(time.Millisecond*250),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			LimitBytes: threshold,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var actualSize int64
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		const query = "select sum(pg_table_size(relid)) from pg_partition_tree('size_partitioned')"
+		if err := c.QueryRow(ctx, query).Scan(&actualSize); err != nil {
+			return fmt.Errorf("%w: get table size", err)
+		}
+
+		return nil
+	})
+
+	require.GreaterOrEqual(t, actualSize, int64(threshold))
+}
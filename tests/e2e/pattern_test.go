@@ -0,0 +1,66 @@
+package e2e_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pattern(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("orders", []suite.Column{
+		suite.NewColumnRawType("code", "varchar(12)"),
+		suite.NewColumnRawType("plate", "varchar(20)"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(25),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+			Generators: []config.Generator{
+				{
+					Column:  "code",
+					Type:    config.GeneratorTypePattern,
+					Pattern: &config.Pattern{Regex: `ORD-[0-9]{8}`},
+				},
+				{
+					Column:       "plate",
+					Type:         config.GeneratorTypePattern,
+					Pattern:      &config.Pattern{Regex: `[A-Z]{2}[0-9]+`},
+					NullFraction: 50,
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	code := regexp.MustCompile(`^ORD-[0-9]{8}$`)
+	plate := regexp.MustCompile(`^[A-Z]{2}[0-9]+$`)
+
+	cnt, nulls := 0, 0
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 2)
+		require.Regexp(t, code, toString(t, row[0]))
+
+		if row[1] == nil {
+			nulls++
+		} else {
+			val := toString(t, row[1])
+			require.Regexp(t, plate, val)
+			require.LessOrEqual(t, len(val), 20)
+		}
+		cnt++
+	})
+
+	require.Equal(t, 100, cnt)
+	require.Positive(t, nulls)
+}
@@ -21,7 +21,7 @@ type SimpleJSON struct {
 
 func Gen(ctx context.Context) (any, error) {
 	val := SimpleJSON{
-		Name: xrand.LowerCaseString(5),
+		Name: xrand.LowerCaseString(xrand.Unseeded(), 5),
 		Top: []string{
 			"football", "tv", "books",
 		},
@@ -0,0 +1,164 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+type previewTable struct {
+	Table   string           `json:"table"`
+	Columns []string         `json:"columns"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+func (r *referenceSuite) savePreviewConfig() {
+	r.bs.SaveConfig(
+		suite.WithTableTarget(config.Table{
+			Schema:     r.childTable.Schema,
+			Table:      r.childTable.Name,
+			LimitRows:  1000,
+			LimitBytes: 0,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+}
+
+func Test_PreviewSamplesParentRows(t *testing.T) {
+	refSuite := newReferenceSuite(t)
+	refSuite.bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		return c.Execute(ctx, fmt.Sprintf(
+			"INSERT INTO %s.%s (id) VALUES (7), (8), (9)", refSuite.baseTable.Schema, refSuite.baseTable.Name,
+		))
+	})
+	refSuite.savePreviewConfig()
+
+	require.NoError(t, refSuite.bs.RunCommand(t.Context(), "preview", "--rows", "5", "--format", "json"))
+
+	var tables []previewTable
+	require.NoError(t, json.Unmarshal([]byte(refSuite.bs.Stdout()), &tables))
+	require.Len(t, tables, 1)
+	require.Equal(t, []string{"id", "base_id"}, tables[0].Columns)
+	require.Len(t, tables[0].Rows, 5)
+	for _, row := range tables[0].Rows {
+		require.Contains(t, []float64{7, 8, 9}, row["base_id"])
+	}
+
+	cnt := 0
+	refSuite.bs.OnEachRow(refSuite.childTable, func([]any) { cnt++ })
+	require.Zero(t, cnt)
+}
+
+func Test_PreviewDoesntWaitForEmptyParent(t *testing.T) {
+	refSuite := newReferenceSuite(t)
+	refSuite.savePreviewConfig()
+
+	require.NoError(t, refSuite.bs.RunCommand(t.Context(), "preview", "--rows", "5", "--format", "json"))
+
+	// the parent isn't previewed and has no rows, references are NULL instead of waiting for the timeout
+	var tables []previewTable
+	require.NoError(t, json.Unmarshal([]byte(refSuite.bs.Stdout()), &tables))
+	require.Len(t, tables, 1)
+	require.Len(t, tables[0].Rows, 5)
+	for _, row := range tables[0].Rows {
+		require.Nil(t, row["base_id"])
+	}
+}
+
+func Test_PreviewFeedsParentRowsToChildren(t *testing.T) {
+	refSuite := newReferenceSuite(t)
+	refSuite.bs.SaveConfig(
+		suite.WithTableTarget(config.Table{
+			Schema:     refSuite.childTable.Schema,
+			Table:      refSuite.childTable.Name,
+			LimitRows:  1000,
+			LimitBytes: 0,
+			Generators: make([]config.Generator, 0),
+		}),
+		suite.WithTableTarget(config.Table{
+			Schema:     refSuite.baseTable.Schema,
+			Table:      refSuite.baseTable.Name,
+			LimitRows:  1000,
+			LimitBytes: 0,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+
+	require.NoError(t, refSuite.bs.RunCommand(t.Context(), "preview", "--rows", "5", "--format", "json"))
+
+	var tables []previewTable
+	require.NoError(t, json.Unmarshal([]byte(refSuite.bs.Stdout()), &tables))
+	require.Len(t, tables, 2)
+
+	// the parent is previewed first
+	ids := make([]any, 0, len(tables[0].Rows))
+	for _, row := range tables[0].Rows {
+		ids = append(ids, row["id"])
+	}
+	require.Len(t, tables[1].Rows, 5)
+	for _, row := range tables[1].Rows {
+		require.Contains(t, ids, row["base_id"])
+	}
+}
+
+func Test_PostgresqlPreviewLeavesDatabaseUntouched(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("preview_untouched", []suite.Column{
+		suite.NewColumn("id", suite.TypeSerialInt4),
+		suite.NewColumnRawType("blob", "oid"),
+	})
+	bs.CreateTable(table)
+
+	largeObjects := func() int {
+		var cnt int
+		bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+			return c.QueryRow(ctx, "SELECT count(*) FROM pg_largeobject_metadata").Scan(&cnt)
+		})
+
+		return cnt
+	}
+	before := largeObjects()
+
+	bs.SaveConfig(
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 1000,
+			Generators: []config.Generator{
+				//nolint:exhaustruct // ok
+				{Column: "blob", Type: config.GeneratorTypeLO, LO: &config.LO{Size: datasize.KB, Range: 0}},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunCommand(t.Context(), "preview", "--rows", "5", "--format", "json"))
+
+	var tables []previewTable
+	require.NoError(t, json.Unmarshal([]byte(bs.Stdout()), &tables))
+	require.Len(t, tables, 1)
+	require.Len(t, tables[0].Rows, 5)
+	// ids are counted from the sequence, it isn't advanced
+	require.InDelta(t, float64(1), tables[0].Rows[0]["id"], 0)
+
+	require.Equal(t, before, largeObjects())
+
+	var called bool
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		return c.QueryRow(ctx, "SELECT is_called FROM preview_untouched_id_seq").Scan(&called)
+	})
+	require.False(t, called)
+
+	cnt := 0
+	bs.OnEachRow(table, func([]any) { cnt++ })
+	require.Zero(t, cnt)
+}
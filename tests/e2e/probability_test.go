@@ -0,0 +1,49 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ProbabilityList runs the list stanza profile and init write for columns with few distinct values.
+func Test_ProbabilityList(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("orders_by_status", []suite.Column{
+		suite.NewColumn("status", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(10),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 30,
+			Generators: []config.Generator{
+				//nolint:exhaustruct // ok
+				{
+					Column: "status",
+					Type:   config.GeneratorTypeProbabilityList,
+					ListProbability: &config.ListProbability{
+						Values:       []any{"new", "paid", "shipped"},
+						Distribution: []int{50, 30, 20},
+					},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		require.Contains(t, []string{"new", "paid", "shipped"}, toString(t, row[0]))
+		cnt++
+	})
+	require.Equal(t, 30, cnt)
+}
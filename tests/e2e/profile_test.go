@@ -0,0 +1,75 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProfileFromStats(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("profiled_orders", []suite.Column{
+		suite.NewColumn("status", suite.TypeText),
+		suite.NewColumn("amount", suite.TypeInt4),
+		suite.NewColumn("created_at", suite.TypeTimestamp),
+		suite.NewColumn("note", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		name := table.Schema + "." + table.Name
+
+		return c.Execute(ctx, fmt.Sprintf(`
+			INSERT INTO %[1]s (status, amount, created_at, note)
+			SELECT
+				(ARRAY['new', 'paid', 'shipped'])[1 + i %% 3],
+				(i * 7919) %% 100000,
+				'2024-01-01'::timestamp + make_interval(mins => i),
+				CASE WHEN i %% 2 = 0 THEN md5(i::text) END
+			FROM generate_series(1, 5000) AS i;
+			ANALYZE %[1]s;
+		`, name))
+	})
+
+	bs.SaveConfig(
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+		}),
+	)
+
+	output := filepath.Join(t.TempDir(), "profiled.yaml")
+	require.NoError(t, bs.RunCommand(t.Context(), "profile", "-o", output))
+
+	profiled, err := config.Load(output)
+	require.NoError(t, err)
+	require.Len(t, profiled.Targets, 1)
+
+	gens := lo.KeyBy(profiled.Targets[0].Table.Generators, func(g config.Generator) string { return g.Column })
+
+	require.Equal(t, config.GeneratorTypeProbabilityList, gens["status"].Type)
+	require.ElementsMatch(t, []any{"new", "paid", "shipped"}, gens["status"].ListProbability.Values)
+
+	require.Equal(t, config.GeneratorTypeInteger, gens["amount"].Type)
+	require.Equal(t, "histogram", gens["amount"].Integer.Distribution.Kind)
+
+	require.Equal(t, config.GeneratorTypeTimestamp, gens["created_at"].Type)
+	require.NotNil(t, gens["created_at"].Timestamp.From)
+
+	require.Equal(t, config.GeneratorTypeText, gens["note"].Type)
+	require.Equal(t, 50, gens["note"].NullFraction)
+	require.LessOrEqual(t, gens["note"].Text.CharLenFrom, 32)
+	require.GreaterOrEqual(t, gens["note"].Text.CharLenTo, 32)
+}
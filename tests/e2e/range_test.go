@@ -0,0 +1,80 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresqlRanges(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("bookings", []suite.Column{
+		suite.NewColumnRawType("seats", "int4range"),
+		suite.NewColumnRawType("price", "numrange"),
+		suite.NewColumnRawType("during", "tstzrange not null"),
+		suite.NewColumnRawType("nights", "daterange"),
+		suite.NewColumnRawType("blocks", "int8multirange"),
+	})
+	bs.CreateTable(table)
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		return c.Execute(ctx, `ALTER TABLE bookings ADD EXCLUDE USING gist (during WITH &&)`)
+	})
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 200,
+			Generators: []config.Generator{
+				{
+					Column: "price",
+					Type:   config.GeneratorTypeRange,
+					Range:  &config.Range{Bounds: "(]", EmptyFraction: 50, MinWidth: "", MaxWidth: ""},
+				},
+				{
+					Column: "during",
+					Type:   config.GeneratorTypeRange,
+					Range:  &config.Range{Bounds: "[)", EmptyFraction: 0, MinWidth: "10m", MaxWidth: "1h"},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var total, valid, emptyPrices int
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		const query = `
+			SELECT
+				count(*),
+				count(*) FILTER (
+					WHERE NOT isempty(during)
+						AND upper(during) - lower(during) BETWEEN interval '10 minutes' AND interval '1 hour'
+						AND (isempty(price) OR (NOT lower_inc(price) AND upper_inc(price)))
+						AND (seats IS NULL OR isempty(seats) OR lower(seats) < upper(seats))
+						AND (nights IS NULL OR isempty(nights) OR lower(nights) < upper(nights))
+				),
+				count(*) FILTER (WHERE isempty(price))
+			FROM bookings
+		`
+		if err := c.QueryRow(ctx, query).Scan(&total, &valid, &emptyPrices); err != nil {
+			return fmt.Errorf("%w: count bookings", err)
+		}
+
+		return nil
+	})
+	require.Equal(t, 200, total)
+	require.Equal(t, total, valid)
+	require.Positive(t, emptyPrices)
+	require.Less(t, emptyPrices, total)
+}
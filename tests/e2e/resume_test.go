@@ -0,0 +1,64 @@
+package e2e_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResumeContinuesSerial(t *testing.T) {
+	baseSuite := suite.NewBaseSuite(t)
+	table := baseSuite.NewTable("test_resume_serial", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt4),
+		suite.NewColumn("note", suite.TypeText),
+	})
+	baseSuite.CreateTable(table, options.WithPKs([]string{"id"}))
+
+	saveConfig := func(limitRows uint64) {
+		baseSuite.SaveConfig(
+			suite.WithBatchSize(10),
+			suite.WithSeed(7),
+			// a restarted sequence only makes duplicates, they mustn't be skipped over
+			suite.WithNoAttemptsProgress(1),
+			//nolint:exhaustruct // ok
+			suite.WithTableTarget(config.Table{
+				Schema:    table.Schema,
+				Table:     table.Name,
+				LimitRows: limitRows,
+				Generators: []config.Generator{
+					//nolint:exhaustruct // ok
+					{
+						Column:  "id",
+						Type:    config.GeneratorTypeInteger,
+						Integer: &config.Integer{Format: lo.ToPtr("serial"), MinValue: lo.ToPtr(int64(1))},
+					},
+				},
+			}),
+		)
+	}
+
+	// the first run stops after 30 rows as if it was interrupted, the resumed one goes on to 60
+	saveConfig(30)
+	require.NoError(t, baseSuite.RunDatagen(t.Context(), suite.WithCheckpoint()))
+
+	saveConfig(60)
+	require.NoError(t, baseSuite.RunDatagen(t.Context(), suite.WithResume()))
+
+	ids := make([]int64, 0, 60)
+	baseSuite.OnEachRow(table, func(row []any) {
+		ids = append(ids, toInteger(t, row[0]))
+	})
+	slices.Sort(ids)
+
+	expected := make([]int64, 60)
+	for i := range expected {
+		expected[i] = int64(i + 1)
+	}
+	require.Equal(t, expected, ids)
+}
@@ -0,0 +1,48 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SchemaTargetWithExplicitTable(t *testing.T) {
+	refSuite := newReferenceSuite(t)
+
+	skippedTable := refSuite.bs.NewTable("skipped_table", []suite.Column{
+		suite.NewColumn("id", suite.TypeInt4),
+	})
+	refSuite.bs.CreateTable(skippedTable)
+
+	refSuite.bs.SaveConfig(
+		suite.WithBatchSize(10),
+		// the schema target is listed first, the explicit table still keeps its limit
+		suite.WithSchemaTarget(config.Schema{
+			Name:       refSuite.baseTable.Schema,
+			Include:    []string{"*_table"},
+			Exclude:    []string{"skipped_*"},
+			LimitRows:  30,
+			LimitBytes: 0,
+		}),
+		suite.WithTableTarget(config.Table{
+			Schema:     refSuite.baseTable.Schema,
+			Table:      refSuite.baseTable.Name,
+			LimitRows:  5,
+			LimitBytes: 0,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+
+	require.NoError(t, refSuite.bs.RunDatagen(t.Context()))
+	refSuite.checkBaseAndChildTablesGeneration(t, 5, 30)
+
+	cnt := 0
+	refSuite.bs.OnEachRow(refSuite.baseTable, func(_ []any) { cnt++ })
+	require.Less(t, cnt, 30)
+
+	cnt = 0
+	refSuite.bs.OnEachRow(skippedTable, func(_ []any) { cnt++ })
+	require.Zero(t, cnt)
+}
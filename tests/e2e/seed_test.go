@@ -0,0 +1,63 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SameSeedSameRows(t *testing.T) {
+	baseSuite := suite.NewBaseSuite(t)
+	table := baseSuite.NewTable("test_seed", []suite.Column{
+		suite.NewColumn("int", suite.TypeInt4),
+		suite.NewColumn("bigint", suite.TypeInt8),
+		suite.NewColumn("float", suite.TypeFloat8),
+		suite.NewColumn("text", suite.TypeText),
+		suite.NewColumn("timestamp", suite.TypeTimestamp),
+		suite.NewColumn("date", suite.TypeDate),
+		suite.NewColumn("uuid", suite.TypeUUID),
+		suite.NewColumn("bytea", suite.TypeBytea),
+	})
+
+	baseSuite.SaveConfig(
+		suite.WithBatchSize(10),
+		suite.WithSeed(42),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 50,
+			Generators: []config.Generator{
+				//nolint:exhaustruct // ok
+				{
+					Column:       "text",
+					Type:         config.GeneratorTypeText,
+					NullFraction: 10,
+				},
+			},
+		}),
+	)
+
+	runAndCollect := func() [][]any {
+		baseSuite.CreateTable(table)
+
+		err := baseSuite.RunDatagen(t.Context(), suite.WithWorkers(1))
+		require.NoError(t, err)
+
+		rows := make([][]any, 0)
+		baseSuite.OnEachRow(table, func(row []any) {
+			rows = append(rows, append([]any(nil), row...))
+		})
+
+		return rows
+	}
+
+	first := runAndCollect()
+	second := runAndCollect()
+
+	require.Len(t, first, 50)
+	require.Equal(t, first, second)
+}
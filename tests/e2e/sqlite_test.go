@@ -0,0 +1,127 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SQLiteTypes(t *testing.T) {
+	suite.TestOnlyFor(t, "sqlite")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("lite_types", []suite.Column{
+		suite.NewColumnRawType("id", "INTEGER PRIMARY KEY"),
+		suite.NewColumnRawType("tiny", "tinyint"),
+		suite.NewColumnRawType("name", "varchar(12)"),
+		suite.NewColumnRawType("price", "decimal(6,2) not null"),
+		suite.NewColumnRawType("active", "boolean"),
+		suite.NewColumnRawType("payload", "blob"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(50),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 200,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	ids := make(map[int64]bool)
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 6)
+
+		id := toInteger(t, row[0])
+		require.False(t, ids[id])
+		ids[id] = true
+
+		tiny := toInteger(t, row[1])
+		require.True(t, -128 <= tiny && tiny <= 127)
+
+		require.Len(t, toString(t, row[2]), 12)
+		require.IsType(t, float64(0), row[3])
+		require.Contains(t, []int64{0, 1}, toInteger(t, row[4]))
+		require.IsType(t, []byte(nil), row[5])
+	})
+	require.Len(t, ids, 200)
+}
+
+func Test_SQLiteUniqueViolations(t *testing.T) {
+	suite.TestOnlyFor(t, "sqlite")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("lite_unique", []suite.Column{
+		suite.NewColumnRawType("code", "tinyint"),
+	})
+	bs.CreateTable(table, options.WithPKs([]string{"code"}))
+
+	bs.SaveConfig(
+		suite.WithBatchSize(64),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 100,
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	cnt := 0
+	bs.OnEachRow(table, func(_ []any) {
+		cnt++
+	})
+	require.GreaterOrEqual(t, cnt, 100)
+}
+
+func Test_SQLiteLimitByTableSize(t *testing.T) {
+	suite.TestOnlyFor(t, "sqlite")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("lite_size", []suite.Column{
+		suite.NewColumn("id", suite.TypeSerialInt4),
+		suite.NewColumn("comment", suite.TypeText),
+		suite.NewColumn("created_at", suite.TypeTimestamp),
+	})
+	bs.CreateTable(table, options.WithPKs([]string{"id"}))
+
+	threshold := datasize.KB * 350
+	bs.SaveConfig(
+		suite.WithBatchSize(100),
+		suite.WithCheckTableSize(time.Millisecond*250),
+		suite.WithTableTarget(config.Table{
+			Schema:     table.Schema,
+			Table:      table.Name,
+			LimitBytes: threshold,
+			Generators: make([]config.Generator, 0),
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	var actualSize int64
+	bs.ExecuteInFunc(func(ctx context.Context, c db.Connect) error {
+		err := c.QueryRow(ctx, "SELECT SUM(pgsize) FROM dbstat WHERE name = 'lite_size'").Scan(&actualSize)
+		if err != nil {
+			return fmt.Errorf("%w: get table size", err)
+		}
+
+		return nil
+	})
+
+	require.GreaterOrEqual(t, actualSize, int64(threshold))
+}
@@ -0,0 +1,114 @@
+package e2e_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jmozgit/datagen/internal/config"
+	"github.com/jmozgit/datagen/tests/suite"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Template(t *testing.T) {
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("accounts", []suite.Column{
+		suite.NewColumn("email", suite.TypeText),
+		suite.NewColumn("code", suite.TypeText),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(30),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 90,
+			Generators: []config.Generator{
+				{
+					Column: "email",
+					Type:   config.GeneratorTypeTemplate,
+					Template: &config.Template{
+						Text:       `{{faker.first_name}}.{{seq}}@{{oneof "a.com" "b.com"}}`,
+						Generators: nil,
+					},
+				},
+				{
+					Column: "code",
+					Type:   config.GeneratorTypeTemplate,
+					Template: &config.Template{
+						Text: `{{plan}}-{{pattern "[A-Z]{3}"}}-{{integer 10 99}}`,
+						Generators: map[string]config.Generator{
+							"plan": {
+								Type: config.GeneratorTypeInteger,
+								Integer: &config.Integer{
+									ByteSize: lo.ToPtr(int8(2)),
+									MinValue: lo.ToPtr(int64(1)),
+									MaxValue: lo.ToPtr(int64(3)),
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	email := regexp.MustCompile(`^[A-Za-z]+\.[0-9]+@(a|b)\.com$`)
+	code := regexp.MustCompile(`^[1-3]-[A-Z]{3}-[1-9][0-9]$`)
+
+	seen := make(map[string]struct{})
+	bs.OnEachRow(table, func(row []any) {
+		require.Len(t, row, 2)
+		require.Regexp(t, email, toString(t, row[0]))
+		require.Regexp(t, code, toString(t, row[1]))
+		seen[toString(t, row[0])] = struct{}{}
+	})
+
+	// seq makes every email unique
+	require.Len(t, seen, 90)
+}
+
+func Test_TemplateCutToColumnLength(t *testing.T) {
+	suite.TestOnlyFor(t, "postgresql", "mysql", "sqlite")
+
+	bs := suite.NewBaseSuite(t)
+	table := bs.NewTable("short_codes", []suite.Column{
+		suite.NewColumnRawType("code", "varchar(8)"),
+	})
+	bs.CreateTable(table)
+
+	bs.SaveConfig(
+		suite.WithBatchSize(10),
+		//nolint:exhaustruct // ok
+		suite.WithTableTarget(config.Table{
+			Schema:    table.Schema,
+			Table:     table.Name,
+			LimitRows: 20,
+			Generators: []config.Generator{
+				{
+					Column: "code",
+					Type:   config.GeneratorTypeTemplate,
+					Template: &config.Template{
+						Text:       `order-{{pattern "[0-9]{6}"}}`,
+						Generators: nil,
+					},
+				},
+			},
+		}),
+	)
+
+	require.NoError(t, bs.RunDatagen(t.Context()))
+
+	code := regexp.MustCompile(`^order-[0-9]{2}$`)
+	cnt := 0
+	bs.OnEachRow(table, func(row []any) {
+		require.Regexp(t, code, toString(t, row[0]))
+		cnt++
+	})
+	require.Equal(t, 20, cnt)
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/model"
 	"github.com/jmozgit/datagen/internal/pkg/db"
 	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
@@ -15,21 +14,20 @@ var ErrUnknownTypeForDriver = errors.New("unknown err for driver")
 
 type connection interface {
 	NewTable(name string, cols []Column) Table
-	SQLConnection() *config.SQLConnection
 	CreateTable(ctx context.Context, table Table, opts ...options.CreateTableOption) error
 	OnEachRow(ctx context.Context, name Table, fn func(row []any), opts ...options.OnEachRowOption) error
 	ExecuteInFunc(ctx context.Context, fn func(ctx context.Context, c db.Connect) error) error
 }
 
 type tempConnAdapter interface {
-	SQLConnection() *config.SQLConnection
 	CreateTable(ctx context.Context, table model.Table, opts ...options.CreateTableOption) error
 	OnEachRow(ctx context.Context, name model.Table, fn func(row []any), opts ...options.OnEachRowOption) error
 	ExecuteInFunc(ctx context.Context, fn func(ctx context.Context, c db.Connect) error) error
 }
 
 type TypeResolver struct {
-	connType string
+	connType      string
+	defaultSchema string
 	tempConnAdapter
 }
 
@@ -50,9 +48,11 @@ const (
 	TypeFloat4      Type = "float4"
 	TypeFloat8      Type = "float8"
 	TypeTimestamp   Type = "timestamp"
+	TypeDate        Type = "date"
 	TypeBoolean     Type = "boolean"
 	TypeText        Type = "text"
 	TypeBytea       Type = "bytea"
+	TypeUUID        Type = "uuid"
 	TypeArrayInt    Type = "array_int"
 	TypeArrayString Type = "array_string"
 	TypeLO          Type = "lo"
@@ -86,18 +86,9 @@ type Table struct {
 	Columns []Column
 }
 
-func (c *TypeResolver) defaultSchema() string {
-	switch c.connType {
-	case postgresqlConnection:
-		return "public"
-	default:
-		return ""
-	}
-}
-
 func (c *TypeResolver) NewTable(name string, columns []Column) Table {
 	return Table{
-		Schema:  c.defaultSchema(),
+		Schema:  c.defaultSchema,
 		Name:    name,
 		Columns: columns,
 	}
@@ -114,6 +105,12 @@ func (c *TypeResolver) resolveIdentifier(id string) model.Identifier {
 	switch c.connType {
 	case postgresqlConnection:
 		return model.PGIdentifier(id)
+	case mysqlConnection:
+		return model.MySQLIdentifier(id)
+	case sqliteConnection:
+		return model.SQLiteIdentifier(id)
+	case oracleConnection:
+		return model.OracleIdentifier(id)
 	default:
 		return model.Identifier{}
 	}
@@ -136,6 +133,24 @@ func (c *TypeResolver) mapColumns(columns []Column) ([]model.Column, error) {
 					return nil, fmt.Errorf("%w: %s", ErrUnknownTypeForDriver, col.Type)
 				}
 				mapped.Type = c
+			case mysqlConnection:
+				c, ok := mysqlMappingType[col.Type]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", ErrUnknownTypeForDriver, col.Type)
+				}
+				mapped.Type = c
+			case sqliteConnection:
+				c, ok := sqliteMappingType[col.Type]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", ErrUnknownTypeForDriver, col.Type)
+				}
+				mapped.Type = c
+			case oracleConnection:
+				c, ok := oracleMappingType[col.Type]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", ErrUnknownTypeForDriver, col.Type)
+				}
+				mapped.Type = c
 			default:
 				return nil, fmt.Errorf("%w %s", ErrUnknownTypeForDriver, c.connType)
 			}
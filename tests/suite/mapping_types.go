@@ -11,10 +11,74 @@ var pgMappgingType = map[Type]string{
 	TypeFloat4:      "float",
 	TypeFloat8:      "double precision",
 	TypeTimestamp:   "timestamptz",
+	TypeDate:        "date",
 	TypeBoolean:     "boolean",
 	TypeText:        "text",
 	TypeBytea:       "bytea",
+	TypeUUID:        "uuid",
 	TypeArrayInt:    "int[]",
 	TypeArrayString: "text[]",
 	TypeLO:          "oid",
 }
+
+// mysql has no arrays and large objects, tests using them run only for postgresql.
+// An auto_increment column has to be a key. Uuids are kept as text.
+//
+//nolint:gochecknoglobals // ok
+var mysqlMappingType = map[Type]string{
+	TypeInt2:       "smallint",
+	TypeInt4:       "int",
+	TypeInt8:       "bigint",
+	TypeSerialInt2: "smallint auto_increment unique",
+	TypeSerialInt4: "int auto_increment unique",
+	TypeSerialInt8: "bigint auto_increment unique",
+	TypeFloat4:     "float",
+	TypeFloat8:     "double",
+	TypeTimestamp:  "datetime(6)",
+	TypeDate:       "date",
+	TypeBoolean:    "boolean",
+	TypeText:       "text",
+	TypeBytea:      "blob",
+	TypeUUID:       "char(36)",
+}
+
+// sqlite has no arrays and large objects either. Only a single INTEGER primary key
+// generates values, so serials are plain integers and get their keys from the tests. Uuids are text.
+//
+//nolint:gochecknoglobals // ok
+var sqliteMappingType = map[Type]string{
+	TypeInt2:       "smallint",
+	TypeInt4:       "int",
+	TypeInt8:       "bigint",
+	TypeSerialInt2: "integer",
+	TypeSerialInt4: "integer",
+	TypeSerialInt8: "integer",
+	TypeFloat4:     "float",
+	TypeFloat8:     "double",
+	TypeTimestamp:  "timestamp",
+	TypeDate:       "date",
+	TypeBoolean:    "boolean",
+	TypeText:       "text",
+	TypeBytea:      "blob",
+	TypeUUID:       "text",
+}
+
+// oracle has no arrays, large objects and booleans before 23ai. Text is varchar2,
+// clob columns can't be keys. Uuids are kept as text.
+//
+//nolint:gochecknoglobals // ok
+var oracleMappingType = map[Type]string{
+	TypeInt2:       "number(5)",
+	TypeInt4:       "number(10)",
+	TypeInt8:       "number(19)",
+	TypeSerialInt2: "number(5) generated by default as identity",
+	TypeSerialInt4: "number(10) generated by default as identity",
+	TypeSerialInt8: "number(19) generated by default as identity",
+	TypeFloat4:     "binary_float",
+	TypeFloat8:     "binary_double",
+	TypeTimestamp:  "timestamp with time zone",
+	TypeDate:       "date",
+	TypeText:       "varchar2(4000)",
+	TypeBytea:      "blob",
+	TypeUUID:       "varchar2(36)",
+}
@@ -13,8 +13,11 @@ import (
 
 	"github.com/jmozgit/datagen/internal/config"
 	"github.com/jmozgit/datagen/internal/pkg/db"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/mysql"
 	"github.com/jmozgit/datagen/internal/pkg/testconn/options"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/oracle"
 	"github.com/jmozgit/datagen/internal/pkg/testconn/postgres"
+	"github.com/jmozgit/datagen/internal/pkg/testconn/sqlite"
 
 	"github.com/stretchr/testify/require"
 	"go.yaml.in/yaml/v3"
@@ -25,6 +28,9 @@ const (
 	datagenBin           = "datagen"
 	testLogsPath         = "../testlogs"
 	postgresqlConnection = "postgresql"
+	mysqlConnection      = "mysql"
+	sqliteConnection     = "sqlite"
+	oracleConnection     = "oracle"
 )
 
 type BaseSuite struct {
@@ -53,7 +59,13 @@ func withVersion(version int) ConfigOption {
 
 func WithTableTarget(table config.Table) ConfigOption {
 	return func(cfg *config.Config) {
-		cfg.Targets = append(cfg.Targets, config.Target{Table: &table})
+		cfg.Targets = append(cfg.Targets, config.Target{Table: &table, Schema: nil})
+	}
+}
+
+func WithSchemaTarget(schema config.Schema) ConfigOption {
+	return func(cfg *config.Config) {
+		cfg.Targets = append(cfg.Targets, config.Target{Table: nil, Schema: &schema})
 	}
 }
 
@@ -75,7 +87,19 @@ func WithCheckTableSize(dur time.Duration) ConfigOption {
 	}
 }
 
-func postgresqlConnectionOption(t *testing.T, conn tempConnAdapter) ConfigOption {
+func WithSeed(seed uint64) ConfigOption {
+	return func(cfg *config.Config) {
+		cfg.Options.Seed = &seed
+	}
+}
+
+func WithFakerByColumnName() ConfigOption {
+	return func(cfg *config.Config) {
+		cfg.Options.FakerByColumnName = true
+	}
+}
+
+func postgresqlConnectionOption(t *testing.T, conn *postgres.Conn) ConfigOption {
 	t.Helper()
 
 	return withConnection(config.Connection{
@@ -84,6 +108,33 @@ func postgresqlConnectionOption(t *testing.T, conn tempConnAdapter) ConfigOption
 	})
 }
 
+func mysqlConnectionOption(t *testing.T, conn *mysql.Conn) ConfigOption {
+	t.Helper()
+
+	return withConnection(config.Connection{
+		Type:  mysqlConnection,
+		MySQL: conn.SQLConnection(),
+	})
+}
+
+func oracleConnectionOption(t *testing.T, conn *oracle.Conn) ConfigOption {
+	t.Helper()
+
+	return withConnection(config.Connection{
+		Type:   oracleConnection,
+		Oracle: conn.SQLConnection(),
+	})
+}
+
+func sqliteConnectionOption(t *testing.T, conn *sqlite.Conn) ConfigOption {
+	t.Helper()
+
+	return withConnection(config.Connection{
+		Type:   sqliteConnection,
+		SQLite: conn.SQLiteDatabase(),
+	})
+}
+
 func NewBaseSuite(t *testing.T) *BaseSuite {
 	t.Helper()
 
@@ -104,13 +155,61 @@ func NewBaseSuite(t *testing.T) *BaseSuite {
 
 		return &BaseSuite{
 			t:              t,
-			conn:           &TypeResolver{tempConnAdapter: conn, connType: postgresqlConnection},
+			conn:           &TypeResolver{tempConnAdapter: conn, connType: postgresqlConnection, defaultSchema: "public"},
 			connOption:     postgresqlConnectionOption(t, conn),
 			Config:         config.Config{}, //nolint:exhaustruct // ok
 			ConnectionType: connType,
 			workPath:       workPath,
 			binPath:        binPath(t),
 		}
+	case mysqlConnection:
+		dsn, ok := os.LookupEnv("TEST_DATAGEN_MYSQL_CONN")
+		require.True(t, ok)
+
+		conn, err := mysql.New(t, dsn)
+		require.NoError(t, err)
+
+		return &BaseSuite{
+			t: t,
+			conn: &TypeResolver{
+				tempConnAdapter: conn, connType: mysqlConnection, defaultSchema: conn.SQLConnection().DBName,
+			},
+			connOption:     mysqlConnectionOption(t, conn),
+			Config:         config.Config{}, //nolint:exhaustruct // ok
+			ConnectionType: connType,
+			workPath:       workPath,
+			binPath:        binPath(t),
+		}
+	case sqliteConnection:
+		// a fresh database file per test, no server is needed
+		conn, err := sqlite.New(t)
+		require.NoError(t, err)
+
+		return &BaseSuite{
+			t:              t,
+			conn:           &TypeResolver{tempConnAdapter: conn, connType: sqliteConnection, defaultSchema: "main"},
+			connOption:     sqliteConnectionOption(t, conn),
+			Config:         config.Config{}, //nolint:exhaustruct // ok
+			ConnectionType: connType,
+			workPath:       workPath,
+			binPath:        binPath(t),
+		}
+	case oracleConnection:
+		dsn, ok := os.LookupEnv("TEST_DATAGEN_ORACLE_CONN")
+		require.True(t, ok)
+
+		conn, err := oracle.New(t, dsn)
+		require.NoError(t, err)
+
+		return &BaseSuite{
+			t:              t,
+			conn:           &TypeResolver{tempConnAdapter: conn, connType: oracleConnection, defaultSchema: conn.Schema()},
+			connOption:     oracleConnectionOption(t, conn),
+			Config:         config.Config{}, //nolint:exhaustruct // ok
+			ConnectionType: connType,
+			workPath:       workPath,
+			binPath:        binPath(t),
+		}
 	default:
 		require.Failf(t, "unknown connection type %s", connType)
 
@@ -173,8 +272,10 @@ func (b *BaseSuite) datagenBin() string {
 }
 
 type flagsValues struct {
-	filePath string
-	worker   int
+	filePath   string
+	worker     int
+	checkpoint bool
+	resume     bool
 }
 
 type FlagOption func(f *flagsValues)
@@ -185,21 +286,55 @@ func WithWorkers(w uint) FlagOption {
 	}
 }
 
+// WithCheckpoint keeps progress of the run in a checkpoint file of the suite.
+func WithCheckpoint() FlagOption {
+	return func(f *flagsValues) {
+		f.checkpoint = true
+	}
+}
+
+// WithResume continues the run saved in the checkpoint file of the suite.
+func WithResume() FlagOption {
+	return func(f *flagsValues) {
+		f.checkpoint = true
+		f.resume = true
+	}
+}
+
 func (b *BaseSuite) RunDatagen(ctx context.Context, opts ...FlagOption) error {
 	flags := flagsValues{
-		filePath: b.configFileName(),
-		worker:   -1,
+		filePath:   b.configFileName(),
+		worker:     -1,
+		checkpoint: false,
+		resume:     false,
 	}
 
 	for _, opt := range opts {
 		opt(&flags)
 	}
 
-	args := []string{"gen", "-f", b.configFileName()}
+	args := make([]string, 0)
 	if flags.worker != -1 {
 		args = append(args, "-w", fmt.Sprint(flags.worker))
 	}
+	if flags.checkpoint {
+		args = append(args, "--checkpoint", filepath.Join(b.workPath, "checkpoint.json"))
+	}
+	if flags.resume {
+		args = append(args, "--resume")
+	}
+
+	return b.RunCommand(ctx, "gen", args...)
+}
 
+// RunCommand runs a datagen command with the saved config, its output goes to the stdout and stderr files.
+func (b *BaseSuite) RunCommand(ctx context.Context, command string, args ...string) error {
+	return b.RunWithoutConfig(ctx, command, append([]string{"-f", b.configFileName()}, args...)...)
+}
+
+// RunWithoutConfig runs a datagen command with the args alone, like init which writes the config.
+func (b *BaseSuite) RunWithoutConfig(ctx context.Context, command string, args ...string) error {
+	args = append([]string{command}, args...)
 	cmd := exec.CommandContext(ctx, b.datagenBin(), args...) //nolint:gosec // ok for tests
 
 	stdout, err := os.Create(filepath.Join(b.workPath, "stdout"))
@@ -214,12 +349,28 @@ func (b *BaseSuite) RunDatagen(ctx context.Context, opts ...FlagOption) error {
 	cmd.Stderr = stderr
 
 	if err = cmd.Run(); err != nil {
-		return fmt.Errorf("%w: datagen", err)
+		return fmt.Errorf("%w: datagen %s", err, command)
 	}
 
 	return nil
 }
 
+// Connection is the connection saved configs point to.
+func (b *BaseSuite) Connection() config.Connection {
+	var cfg config.Config
+	b.connOption(&cfg)
+
+	return cfg.Connection
+}
+
+// Stdout is what the last datagen command printed.
+func (b *BaseSuite) Stdout() string {
+	data, err := os.ReadFile(filepath.Join(b.workPath, "stdout"))
+	require.NoError(b.t, err)
+
+	return string(data)
+}
+
 func curConnType(t *testing.T) string {
 	t.Helper()
 